@@ -0,0 +1,255 @@
+package newrelic
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+
+	newrelic "github.com/paultyng/go-newrelic/v4/api"
+)
+
+// The New Relic client library doesn't model every attribute of alert
+// conditions, so the attributes it leaves out are sent and read here using
+// the clients' REST clients.
+
+// alertConditionRequest sends an APM, browser or mobile alert condition with
+// runbook_url even when it's empty, so that removing the URL clears it.
+// newrelic.AlertCondition omits it when empty.
+type alertConditionRequest struct {
+	newrelic.AlertCondition
+	RunbookURL string `json:"runbook_url"`
+}
+
+// alertNrqlCondition represents a NRQL alert condition, including the
+// attributes newrelic.AlertNrqlCondition doesn't model.
+type alertNrqlCondition struct {
+	PolicyID                  int                           `json:"-"`
+	ID                        int                           `json:"id,omitempty"`
+	Type                      string                        `json:"type,omitempty"`
+	Name                      string                        `json:"name,omitempty"`
+	Enabled                   bool                          `json:"enabled"`
+	RunbookURL                string                        `json:"runbook_url"`
+	Terms                     []newrelic.AlertConditionTerm `json:"terms,omitempty"`
+	ValueFunction             string                        `json:"value_function,omitempty"`
+	BaselineDirection         string                        `json:"baseline_direction,omitempty"`
+	Nrql                      alertNrqlQuery                `json:"nrql,omitempty"`
+	Signal                    *alertNrqlSignal              `json:"signal,omitempty"`
+	ViolationTimeLimitSeconds int                           `json:"violation_time_limit_seconds,omitempty"`
+}
+
+// alertNrqlQuery represents the query of a NRQL alert condition.
+type alertNrqlQuery struct {
+	Query      string `json:"query,omitempty"`
+	SinceValue string `json:"since_value,omitempty"`
+	AccountID  int    `json:"account_id,omitempty"`
+}
+
+// alertNrqlSignal configures how the data of a NRQL alert condition is
+// aggregated into windows, and how gaps in it are filled.
+type alertNrqlSignal struct {
+	AggregationWindow int      `json:"aggregation_window,omitempty"`
+	AggregationMethod string   `json:"aggregation_method,omitempty"`
+	AggregationDelay  *int     `json:"aggregation_delay,omitempty"`
+	AggregationTimer  int      `json:"aggregation_timer,omitempty"`
+	FillOption        string   `json:"fill_option,omitempty"`
+	FillValue         *float64 `json:"fill_value,omitempty"`
+}
+
+// alertInfraCondition represents an Infrastructure alert condition with the
+// violation close timer, which newrelic.AlertInfraCondition doesn't model.
+type alertInfraCondition struct {
+	newrelic.AlertInfraCondition
+	ViolationCloseTimer int `json:"violation_close_timer,omitempty"`
+}
+
+func createAlertCondition(client *newrelic.Client, condition newrelic.AlertCondition) (*newrelic.AlertCondition, error) {
+	req := struct {
+		Condition alertConditionRequest `json:"condition"`
+	}{
+		Condition: alertConditionRequest{AlertCondition: condition, RunbookURL: condition.RunbookURL},
+	}
+
+	resp := struct {
+		Condition newrelic.AlertCondition `json:"condition,omitempty"`
+	}{}
+
+	if _, err := client.Do("POST", fmt.Sprintf("/alerts_conditions/policies/%d.json", condition.PolicyID), req, &resp); err != nil {
+		return nil, err
+	}
+
+	resp.Condition.PolicyID = condition.PolicyID
+
+	return &resp.Condition, nil
+}
+
+func updateAlertCondition(client *newrelic.Client, condition newrelic.AlertCondition) (*newrelic.AlertCondition, error) {
+	req := struct {
+		Condition alertConditionRequest `json:"condition"`
+	}{
+		Condition: alertConditionRequest{AlertCondition: condition, RunbookURL: condition.RunbookURL},
+	}
+
+	resp := struct {
+		Condition newrelic.AlertCondition `json:"condition,omitempty"`
+	}{}
+
+	if _, err := client.Do("PUT", fmt.Sprintf("/alerts_conditions/%d.json", condition.ID), req, &resp); err != nil {
+		return nil, err
+	}
+
+	resp.Condition.PolicyID = condition.PolicyID
+
+	return &resp.Condition, nil
+}
+
+func listAlertNrqlConditions(client *newrelic.Client, policyID int) ([]alertNrqlCondition, error) {
+	conditions := []alertNrqlCondition{}
+
+	qs := url.Values{}
+	qs.Set("policy_id", strconv.Itoa(policyID))
+
+	nextPath := "/alerts_nrql_conditions.json?" + qs.Encode()
+
+	for nextPath != "" {
+		resp := struct {
+			NrqlConditions []alertNrqlCondition `json:"nrql_conditions,omitempty"`
+		}{}
+
+		var err error
+		nextPath, err = client.Do("GET", nextPath, nil, &resp)
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range resp.NrqlConditions {
+			resp.NrqlConditions[i].PolicyID = policyID
+		}
+
+		conditions = append(conditions, resp.NrqlConditions...)
+	}
+
+	return conditions, nil
+}
+
+func getAlertNrqlCondition(client *newrelic.Client, policyID int, id int) (*alertNrqlCondition, error) {
+	conditions, err := listAlertNrqlConditions(client, policyID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, condition := range conditions {
+		if condition.ID == id {
+			return &condition, nil
+		}
+	}
+
+	return nil, newrelic.ErrNotFound
+}
+
+func createAlertNrqlCondition(client *newrelic.Client, condition alertNrqlCondition) (*alertNrqlCondition, error) {
+	req := struct {
+		Condition alertNrqlCondition `json:"nrql_condition"`
+	}{
+		Condition: condition,
+	}
+
+	resp := struct {
+		Condition alertNrqlCondition `json:"nrql_condition,omitempty"`
+	}{}
+
+	if _, err := client.Do("POST", fmt.Sprintf("/alerts_nrql_conditions/policies/%d.json", condition.PolicyID), req, &resp); err != nil {
+		return nil, err
+	}
+
+	resp.Condition.PolicyID = condition.PolicyID
+
+	return &resp.Condition, nil
+}
+
+func updateAlertNrqlCondition(client *newrelic.Client, condition alertNrqlCondition) (*alertNrqlCondition, error) {
+	req := struct {
+		Condition alertNrqlCondition `json:"nrql_condition"`
+	}{
+		Condition: condition,
+	}
+
+	resp := struct {
+		Condition alertNrqlCondition `json:"nrql_condition,omitempty"`
+	}{}
+
+	if _, err := client.Do("PUT", fmt.Sprintf("/alerts_nrql_conditions/%d.json", condition.ID), req, &resp); err != nil {
+		return nil, err
+	}
+
+	resp.Condition.PolicyID = condition.PolicyID
+
+	return &resp.Condition, nil
+}
+
+func getAlertInfraCondition(client *newrelic.InfraClient, policyID int, id int) (*alertInfraCondition, error) {
+	qs := url.Values{}
+	qs.Set("policy_id", strconv.Itoa(policyID))
+
+	nextPath := "/alerts/conditions?" + qs.Encode()
+
+	for nextPath != "" {
+		resp := struct {
+			InfraConditions []alertInfraCondition `json:"data,omitempty"`
+		}{}
+
+		var err error
+		nextPath, err = client.Do("GET", nextPath, nil, &resp)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, condition := range resp.InfraConditions {
+			if condition.ID == id {
+				condition.PolicyID = policyID
+				return &condition, nil
+			}
+		}
+	}
+
+	return nil, newrelic.ErrNotFound
+}
+
+func createAlertInfraCondition(client *newrelic.InfraClient, condition alertInfraCondition) (*alertInfraCondition, error) {
+	req := struct {
+		Condition alertInfraCondition `json:"data"`
+	}{
+		Condition: condition,
+	}
+
+	resp := struct {
+		Condition alertInfraCondition `json:"data,omitempty"`
+	}{}
+
+	if _, err := client.Do("POST", "/alerts/conditions", req, &resp); err != nil {
+		return nil, err
+	}
+
+	resp.Condition.PolicyID = condition.PolicyID
+
+	return &resp.Condition, nil
+}
+
+func updateAlertInfraCondition(client *newrelic.InfraClient, condition alertInfraCondition) (*alertInfraCondition, error) {
+	req := struct {
+		Condition alertInfraCondition `json:"data"`
+	}{
+		Condition: condition,
+	}
+
+	resp := struct {
+		Condition alertInfraCondition `json:"data,omitempty"`
+	}{}
+
+	if _, err := client.Do("PUT", fmt.Sprintf("/alerts/conditions/%d", condition.ID), req, &resp); err != nil {
+		return nil, err
+	}
+
+	resp.Condition.PolicyID = condition.PolicyID
+
+	return &resp.Condition, nil
+}
@@ -0,0 +1,80 @@
+package newrelic
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	newrelic "github.com/paultyng/go-newrelic/v4/api"
+)
+
+func TestUpdateAlertCondition_clearsRunbookURL(t *testing.T) {
+	var received map[string]map[string]interface{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&received)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"condition":{"id":456,"name":"foo"}}`))
+	}))
+	defer server.Close()
+
+	client := newrelic.New(newrelic.Config{BaseURL: server.URL})
+
+	condition, err := updateAlertCondition(&client, newrelic.AlertCondition{PolicyID: 123, ID: 456, Name: "foo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if url, ok := received["condition"]["runbook_url"]; !ok || url != "" {
+		t.Fatalf("expected an empty runbook_url to be sent, got %#v", received["condition"])
+	}
+
+	if condition.ID != 456 || condition.PolicyID != 123 {
+		t.Fatalf("expected condition 456 of policy 123, got %#v", condition)
+	}
+}
+
+func TestAlertInfraCondition_violationCloseTimer(t *testing.T) {
+	var received map[string]map[string]interface{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.Method == "POST" {
+			json.NewDecoder(r.Body).Decode(&received)
+			w.Write([]byte(`{"data":{"id":456,"name":"foo","violation_close_timer":24}}`))
+			return
+		}
+
+		w.Write([]byte(`{"data":[{"id":789},{"id":456,"name":"foo","violation_close_timer":24}]}`))
+	}))
+	defer server.Close()
+
+	client := newrelic.NewInfraClient(newrelic.Config{BaseURL: server.URL})
+
+	condition := alertInfraCondition{ViolationCloseTimer: 24}
+	condition.PolicyID = 123
+	condition.Name = "foo"
+
+	if _, err := createAlertInfraCondition(&client, condition); err != nil {
+		t.Fatal(err)
+	}
+
+	if received["data"]["violation_close_timer"] != 24.0 || received["data"]["policy_id"] != 123.0 {
+		t.Fatalf("expected the violation close timer to be sent with the condition, got %#v", received["data"])
+	}
+
+	found, err := getAlertInfraCondition(&client, 123, 456)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if found.ViolationCloseTimer != 24 || found.PolicyID != 123 {
+		t.Fatalf("expected the violation close timer to be read back, got %#v", found)
+	}
+
+	if _, err := getAlertInfraCondition(&client, 123, 1); err != newrelic.ErrNotFound {
+		t.Fatalf("expected ErrNotFound for a missing condition, got %v", err)
+	}
+}
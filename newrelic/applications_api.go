@@ -0,0 +1,93 @@
+package newrelic
+
+import (
+	"fmt"
+
+	newrelic "github.com/paultyng/go-newrelic/v4/api"
+)
+
+// The New Relic client library can only list applications, so reading and
+// updating a single application is done here using the client's REST client.
+// Key transactions are listed here too, since the library doesn't model the
+// application they belong to.
+
+// applicationSettings holds the APM settings managed by
+// newrelic_application_settings. Unlike newrelic.ApplicationSettings it leaves
+// out use_server_side_config so updates don't reset it.
+type applicationSettings struct {
+	AppApdexThreshold        float64 `json:"app_apdex_threshold,omitempty"`
+	EndUserApdexThreshold    float64 `json:"end_user_apdex_threshold,omitempty"`
+	EnableRealUserMonitoring bool    `json:"enable_real_user_monitoring"`
+}
+
+// keyTransaction represents a New Relic key transaction.
+type keyTransaction struct {
+	ID              int                         `json:"id"`
+	Name            string                      `json:"name"`
+	TransactionName string                      `json:"transaction_name"`
+	Summary         newrelic.ApplicationSummary `json:"application_summary,omitempty"`
+	Links           keyTransactionLinks         `json:"links,omitempty"`
+}
+
+// keyTransactionLinks represents the links of a New Relic key transaction.
+type keyTransactionLinks struct {
+	Application int `json:"application"`
+}
+
+func listKeyTransactions(client *newrelic.Client) ([]keyTransaction, error) {
+	transactions := []keyTransaction{}
+
+	nextPath := "/key_transactions.json"
+
+	for nextPath != "" {
+		resp := struct {
+			Transactions []keyTransaction `json:"key_transactions,omitempty"`
+		}{}
+
+		var err error
+		nextPath, err = client.Do("GET", nextPath, nil, &resp)
+		if err != nil {
+			return nil, err
+		}
+
+		transactions = append(transactions, resp.Transactions...)
+	}
+
+	return transactions, nil
+}
+
+func getApplication(client *newrelic.Client, id int) (*newrelic.Application, error) {
+	resp := struct {
+		Application newrelic.Application `json:"application,omitempty"`
+	}{}
+
+	apiResponse, err := client.RestyClient.R().
+		SetHeader("Content-Type", "application/json").
+		SetResult(&resp).
+		Get(fmt.Sprintf("/applications/%d.json", id))
+	if err != nil {
+		return nil, err
+	}
+
+	if apiResponse.StatusCode() == 404 {
+		return nil, newrelic.ErrNotFound
+	}
+
+	if apiResponse.StatusCode()/100 != 2 {
+		return nil, fmt.Errorf("Unexpected status %v returned from API", apiResponse.StatusCode())
+	}
+
+	return &resp.Application, nil
+}
+
+func updateApplicationSettings(client *newrelic.Client, id int, settings applicationSettings) error {
+	req := struct {
+		Application struct {
+			Settings applicationSettings `json:"settings"`
+		} `json:"application"`
+	}{}
+	req.Application.Settings = settings
+
+	_, err := client.Do("PUT", fmt.Sprintf("/applications/%d.json", id), req, nil)
+	return err
+}
@@ -0,0 +1,122 @@
+package newrelic
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"sync/atomic"
+)
+
+const defaultCompressionThreshold = 4096
+
+// compressionTransport is an http.RoundTripper that gzips the bodies of POST
+// and PUT requests larger than minSize.
+//
+// If the API rejects a compressed request with 415 Unsupported Media Type,
+// the request is sent again uncompressed and compression is disabled for the
+// remaining requests.
+type compressionTransport struct {
+	transport http.RoundTripper
+	minSize   int64
+
+	// disabled is set to 1 once the API has rejected a compressed request.
+	disabled int32
+}
+
+func newCompressionTransport(transport http.RoundTripper, minSize int64) *compressionTransport {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	return &compressionTransport{
+		transport: transport,
+		minSize:   minSize,
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *compressionTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !t.shouldCompress(req) {
+		return t.transport.RoundTrip(req)
+	}
+
+	compressed, err := t.compress(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.transport.RoundTrip(compressed)
+	if err != nil || resp.StatusCode != http.StatusUnsupportedMediaType {
+		return resp, err
+	}
+
+	resp.Body.Close()
+	atomic.StoreInt32(&t.disabled, 1)
+
+	log.Printf("[WARN] New Relic API rejected a gzip compressed request for %s %s, disabling request compression",
+		req.Method, req.URL.Path)
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = body
+
+	return t.transport.RoundTrip(req)
+}
+
+func (t *compressionTransport) shouldCompress(req *http.Request) bool {
+	if atomic.LoadInt32(&t.disabled) == 1 {
+		return false
+	}
+
+	if req.Method != http.MethodPost && req.Method != http.MethodPut {
+		return false
+	}
+
+	// The uncompressed body must be replayable to fall back on a 415.
+	if req.Body == nil || req.GetBody == nil || req.ContentLength <= t.minSize {
+		return false
+	}
+
+	return req.Header.Get("Content-Encoding") == ""
+}
+
+// compress returns a copy of req with a gzipped body.
+func (t *compressionTransport) compress(req *http.Request) (*http.Request, error) {
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+
+	if _, err := io.Copy(w, body); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+
+	data := buf.Bytes()
+
+	compressed := new(http.Request)
+	*compressed = *req
+	compressed.Header = make(http.Header, len(req.Header)+1)
+	for k, v := range req.Header {
+		compressed.Header[k] = v
+	}
+	compressed.Header.Set("Content-Encoding", "gzip")
+	compressed.Body = ioutil.NopCloser(bytes.NewReader(data))
+	compressed.ContentLength = int64(len(data))
+	compressed.GetBody = func() (io.ReadCloser, error) {
+		return ioutil.NopCloser(bytes.NewReader(data)), nil
+	}
+
+	return compressed, nil
+}
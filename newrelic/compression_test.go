@@ -0,0 +1,88 @@
+package newrelic
+
+import (
+	"compress/gzip"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func testCompressionServer(unsupported bool) (*httptest.Server, *[]string) {
+	var encodings []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		encoding := r.Header.Get("Content-Encoding")
+		encodings = append(encodings, encoding)
+
+		if encoding == "gzip" {
+			if unsupported {
+				w.WriteHeader(http.StatusUnsupportedMediaType)
+				return
+			}
+
+			gz, err := gzip.NewReader(r.Body)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			r.Body = gz
+		}
+
+		body, _ := ioutil.ReadAll(r.Body)
+		w.Write(body)
+	}))
+
+	return server, &encodings
+}
+
+func TestCompressionTransport_CompressesLargeBodies(t *testing.T) {
+	server, encodings := testCompressionServer(false)
+	defer server.Close()
+
+	client := &http.Client{Transport: newCompressionTransport(nil, 16)}
+	large := strings.Repeat("a", 32)
+
+	for _, body := range []string{"{}", large} {
+		resp, err := client.Post(server.URL, "application/json", strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		echoed, _ := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+
+		if string(echoed) != body {
+			t.Fatalf("expected the server to receive %q, got %q", body, echoed)
+		}
+	}
+
+	if (*encodings)[0] != "" || (*encodings)[1] != "gzip" {
+		t.Fatalf("expected only the large body to be compressed, got encodings %q", *encodings)
+	}
+}
+
+func TestCompressionTransport_FallsBackOnUnsupportedMediaType(t *testing.T) {
+	server, encodings := testCompressionServer(true)
+	defer server.Close()
+
+	client := &http.Client{Transport: newCompressionTransport(nil, 16)}
+	large := strings.Repeat("a", 32)
+
+	for i := 0; i < 2; i++ {
+		resp, err := client.Post(server.URL, "application/json", strings.NewReader(large))
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", resp.StatusCode)
+		}
+	}
+
+	expected := []string{"gzip", "", ""}
+	if strings.Join(*encodings, ",") != strings.Join(expected, ",") {
+		t.Fatalf("expected encodings %q, got %q", expected, *encodings)
+	}
+}
@@ -0,0 +1,66 @@
+package newrelic
+
+import (
+	"io"
+	"net/http"
+	"sync"
+)
+
+// defaultParallelism matches Terraform's default number of concurrent
+// operations.
+const defaultParallelism = 10
+
+// concurrencyTransport is an http.RoundTripper that limits the number of
+// requests in flight to the capacity of slots. The slots are shared by every
+// client of the provider, so the limit applies to the provider as a whole.
+//
+// A slot is held until the response body is closed.
+type concurrencyTransport struct {
+	transport http.RoundTripper
+	slots     chan struct{}
+}
+
+func newConcurrencyTransport(transport http.RoundTripper, slots chan struct{}) *concurrencyTransport {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	return &concurrencyTransport{
+		transport: transport,
+		slots:     slots,
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *concurrencyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	select {
+	case t.slots <- struct{}{}:
+	case <-req.Context().Done():
+		return nil, req.Context().Err()
+	}
+
+	resp, err := t.transport.RoundTrip(req)
+	if err != nil || resp.Body == nil {
+		<-t.slots
+		return resp, err
+	}
+
+	resp.Body = &releasingBody{ReadCloser: resp.Body, release: func() { <-t.slots }}
+
+	return resp, nil
+}
+
+// releasingBody releases a concurrencyTransport slot when it is closed.
+type releasingBody struct {
+	io.ReadCloser
+
+	once    sync.Once
+	release func()
+}
+
+func (b *releasingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+
+	return err
+}
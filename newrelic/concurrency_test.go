@@ -0,0 +1,178 @@
+package newrelic
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// testConcurrencyServer returns a server that answers dashboard reads after
+// delay and records the largest number of requests it served at once.
+func testConcurrencyServer(delay time.Duration) (*httptest.Server, *int64) {
+	var inFlight, maxInFlight int64
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt64(&inFlight, 1)
+		defer atomic.AddInt64(&inFlight, -1)
+
+		for {
+			max := atomic.LoadInt64(&maxInFlight)
+			if n <= max || atomic.CompareAndSwapInt64(&maxInFlight, max, n) {
+				break
+			}
+		}
+
+		time.Sleep(delay)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"dashboard":{"id":1,"title":"foo","widgets":[]}}`))
+	}))
+
+	return server, &maxInFlight
+}
+
+func testConcurrencyProviderConfig(t testing.TB, url string, parallelism int) *ProviderConfig {
+	config := Config{
+		APIKey:       "foo",
+		APIURL:       url,
+		Parallelism:  parallelism,
+		requestSlots: make(chan struct{}, parallelism),
+	}
+
+	client, err := config.Client()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &ProviderConfig{Client: client, config: config}
+}
+
+// testRefreshDashboards reads resources dashboards the way Terraform refreshes
+// a state, with workers concurrent reads.
+func testRefreshDashboards(t testing.TB, meta *ProviderConfig, resources, workers int) {
+	r := resourceNewRelicDashboard()
+
+	ids := make(chan int)
+	go func() {
+		for i := 1; i <= resources; i++ {
+			ids <- i
+		}
+		close(ids)
+	}()
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for id := range ids {
+				d := r.TestResourceData()
+				d.SetId(strconv.Itoa(id))
+
+				if err := r.Read(d, meta); err != nil {
+					t.Error(err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestConcurrencyTransport_limitsRequestsInFlight(t *testing.T) {
+	server, maxInFlight := testConcurrencyServer(10 * time.Millisecond)
+	defer server.Close()
+
+	meta := testConcurrencyProviderConfig(t, server.URL, 3)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			client := meta.Client
+
+			// Clients for resource-level API keys share the provider's limit.
+			if i%2 == 0 {
+				d := schema.TestResourceDataRaw(t, resourceNewRelicDashboard().Schema, map[string]interface{}{
+					"title":   "foo",
+					"api_key": "bar",
+				})
+
+				var err error
+				if client, err = meta.clientFor(d); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+
+			if _, err := client.Do("GET", "/dashboards/1.json", nil, nil); err != nil {
+				t.Error(err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if n := atomic.LoadInt64(maxInFlight); n < 2 || n > 3 {
+		t.Fatalf("expected between 2 and 3 requests in flight, got %d", n)
+	}
+
+	if len(meta.resourceClients) != 1 {
+		t.Fatalf("expected a single client for the resource api_key, got %d", len(meta.resourceClients))
+	}
+}
+
+func TestConcurrencyTransport_contextCanceled(t *testing.T) {
+	slots := make(chan struct{}, 1)
+	slots <- struct{}{}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	req, _ := http.NewRequest("GET", "http://localhost", nil)
+
+	_, err := newConcurrencyTransport(nil, slots).RoundTrip(req.WithContext(ctx))
+	if err != context.Canceled {
+		t.Fatalf("expected the request to be canceled while waiting for a slot, got %v", err)
+	}
+}
+
+func TestConcurrencyTransport_releasesSlots(t *testing.T) {
+	server, _ := testConcurrencyServer(0)
+	defer server.Close()
+
+	meta := testConcurrencyProviderConfig(t, server.URL, 2)
+
+	testRefreshDashboards(t, meta, 20, 4)
+
+	if n := len(meta.config.requestSlots); n != 0 {
+		t.Fatalf("expected every slot to be released, got %d held", n)
+	}
+}
+
+// BenchmarkRefresh_500Dashboards refreshes a state of 500 dashboards with
+// Terraform's default of 10 concurrent operations against an API with 5ms of
+// latency, with the provider limited to 1 and 10 concurrent requests.
+func BenchmarkRefresh_500Dashboards(b *testing.B) {
+	for _, parallelism := range []int{1, defaultParallelism} {
+		b.Run("parallelism="+strconv.Itoa(parallelism), func(b *testing.B) {
+			server, _ := testConcurrencyServer(5 * time.Millisecond)
+			defer server.Close()
+
+			meta := testConcurrencyProviderConfig(b, server.URL, parallelism)
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				testRefreshDashboards(b, meta, 500, defaultParallelism)
+			}
+		})
+	}
+}
@@ -1,28 +1,80 @@
 package newrelic
 
 import (
+	"context"
+	"encoding/base64"
+	"fmt"
 	"log"
+	"net/http"
+	"net/url"
+	"sort"
+	"sync"
+	"time"
 
 	synthetics "github.com/dollarshaveclub/new-relic-synthetics-go"
-	"github.com/hashicorp/terraform/helper/logging"
+	"github.com/hashicorp/terraform/helper/schema"
 	newrelic "github.com/paultyng/go-newrelic/v4/api"
+	resty "gopkg.in/resty.v1"
+)
+
+const (
+	apiKeySourceProvider = "provider"
+	apiKeySourceResource = "resource-level"
 )
 
 // Config contains New Relic provider settings
 type Config struct {
 	APIKey string
 	APIURL string
+
+	// SyntheticsAPIURL is the base URL of the Synthetics API, e.g.
+	// "https://synthetics.eu.newrelic.com/synthetics/api".
+	SyntheticsAPIURL string
+
+	// APIKeySource describes where APIKey was configured and is used to
+	// report which key was rejected by the API.
+	APIKeySource string
+
+	MaxRetries    int
+	MinRetryDelay time.Duration
+
+	// EnableRequestCompression gzips large POST and PUT request bodies.
+	EnableRequestCompression bool
+
+	// ProxyURL is the HTTP proxy requests are sent through, instead of the
+	// one set by the HTTP_PROXY and HTTPS_PROXY environment variables.
+	ProxyURL string
+
+	// CACertFile is a PEM file of CA certificates trusted in addition to the
+	// system's, e.g. for a proxy that intercepts TLS.
+	CACertFile string
+
+	// InsecureSkipVerify disables verification of the APIs' TLS certificates.
+	InsecureSkipVerify bool
+
+	// Parallelism is the maximum number of concurrent requests to New Relic.
+	Parallelism int
+
+	// Debug logs each request and response, with API keys and sensitive
+	// fields redacted.
+	Debug bool
+
+	// requestSlots limits the requests in flight, shared by every client
+	// created from copies of the Config.
+	requestSlots chan struct{}
 }
 
 // Client returns a new client for accessing New Relic
 func (c *Config) Client() (*newrelic.Client, error) {
 	nrConfig := newrelic.Config{
 		APIKey:  c.APIKey,
-		Debug:   logging.IsDebugOrHigher(),
 		BaseURL: c.APIURL,
 	}
 
 	client := newrelic.New(nrConfig)
+	if err := c.configureRestyClient(client.RestyClient); err != nil {
+		return nil, err
+	}
 
 	log.Printf("[INFO] New Relic client configured")
 
@@ -33,11 +85,13 @@ func (c *Config) Client() (*newrelic.Client, error) {
 func (c *Config) ClientInfra() (*newrelic.InfraClient, error) {
 	nrConfig := newrelic.Config{
 		APIKey:  c.APIKey,
-		Debug:   logging.IsDebugOrHigher(),
 		BaseURL: c.APIURL,
 	}
 
 	client := newrelic.NewInfraClient(nrConfig)
+	if err := c.configureRestyClient(client.RestyClient); err != nil {
+		return nil, err
+	}
 
 	log.Printf("[INFO] New Relic Infra client configured")
 
@@ -46,8 +100,27 @@ func (c *Config) ClientInfra() (*newrelic.InfraClient, error) {
 
 // ClientSynthetics returns a new client for accessing New Relic Synthetics
 func (c *Config) ClientSynthetics() (*synthetics.Client, error) {
+	transport, err := c.httpTransport()
+	if err != nil {
+		return nil, err
+	}
+
+	// Logged after the URL is rewritten, so that the URL actually requested
+	// is shown.
+	if c.Debug {
+		transport = newDebugTransport(transport)
+	}
+
+	if c.SyntheticsAPIURL != "" && c.SyntheticsAPIURL != syntheticsAPIURL {
+		transport = newSyntheticsURLTransport(transport, c.SyntheticsAPIURL)
+	}
+
 	conf := func(s *synthetics.Client) {
 		s.APIKey = c.APIKey
+
+		if transport != nil {
+			s.HTTPClient = &http.Client{Transport: transport}
+		}
 	}
 
 	client, _ := synthetics.NewClient(conf)
@@ -57,9 +130,363 @@ func (c *Config) ClientSynthetics() (*synthetics.Client, error) {
 	return client, nil
 }
 
+func (c *Config) configureRestyClient(r *resty.Client) error {
+	transport, err := c.httpTransport()
+	if err != nil {
+		return err
+	}
+	if transport == nil {
+		transport = r.GetClient().Transport
+	}
+
+	if c.EnableRequestCompression {
+		transport = newCompressionTransport(transport, defaultCompressionThreshold)
+	}
+
+	// Logged before compression so that bodies are readable.
+	if c.Debug {
+		transport = newDebugTransport(transport)
+	}
+
+	r.SetTransport(newRetryTransport(transport, c.MaxRetries, c.MinRetryDelay))
+	r.OnAfterResponse(c.unauthorizedResponseHook())
+	r.OnAfterResponse(notFoundResponseHook)
+
+	return nil
+}
+
+// notFoundResponseHook returns newrelic.ErrNotFound for 404 responses. The
+// client library only returns it from lookups that filter a list, so a 404,
+// e.g. for a deleted dashboard or the conditions of a deleted policy, would
+// otherwise surface as an error instead of removing the resource from state.
+func notFoundResponseHook(_ *resty.Client, r *resty.Response) error {
+	if r.StatusCode() == http.StatusNotFound {
+		return newrelic.ErrNotFound
+	}
+
+	return nil
+}
+
+func (c *Config) unauthorizedResponseHook() func(*resty.Client, *resty.Response) error {
+	source := c.APIKeySource
+	if source == "" {
+		source = apiKeySourceProvider
+	}
+
+	return func(_ *resty.Client, r *resty.Response) error {
+		if r.StatusCode() == http.StatusUnauthorized {
+			return fmt.Errorf("New Relic rejected the %s API key (401 Unauthorized)", source)
+		}
+
+		return nil
+	}
+}
+
 // ProviderConfig for the custom provider
 type ProviderConfig struct {
 	Client      *newrelic.Client
 	InfraClient *newrelic.InfraClient
 	Synthetics  *synthetics.Client
+
+	config      Config
+	infraConfig Config
+
+	// preventDestroyWithConditions makes alert policy deletes fail while
+	// the policy still has conditions.
+	preventDestroyWithConditions bool
+
+	// uniquePolicyNames makes alert policy creates and renames fail when
+	// another policy already has the name.
+	uniquePolicyNames bool
+
+	// accountID is the default account NRQL queries are validated and run
+	// against.
+	accountID int
+
+	// uiDomain is the domain of the New Relic UI of the provider's region,
+	// e.g. "eu.newrelic.com".
+	uiDomain string
+
+	// validateNrql makes plans of NRQL alert conditions run their query.
+	validateNrql bool
+
+	// defaultTags are the tags set on every entity-taggable resource, unless
+	// the resource's own tags set the same key.
+	defaultTags map[string]interface{}
+
+	// stopCtx is cancelled when Terraform is interrupted.
+	stopCtx context.Context
+
+	mu                   sync.Mutex
+	resourceClients      map[string]*newrelic.Client
+	resourceInfraClients map[string]*newrelic.InfraClient
+	syntheticsLocations  []syntheticsLocation
+
+	// applications caches application lists by API key, i.e. by account,
+	// for the duration of a single Terraform run.
+	applicationsMu sync.Mutex
+	applications   map[string]*cachedApplications
+
+	// alertChannels caches alert channel lists by API key, so that refreshing
+	// many policy channel links lists the channels once.
+	alertChannelsMu sync.Mutex
+	alertChannels   map[string]*cachedAlertChannels
+}
+
+// applicationsCacheTTL is how long a cached application list is used.
+const applicationsCacheTTL = time.Minute
+
+type cachedApplications struct {
+	applications []newrelic.Application
+	expires      time.Time
+}
+
+// alertChannelsCacheTTL is how long a cached alert channel list is used. It's
+// short, since the list also holds the channels' policy links.
+const alertChannelsCacheTTL = 30 * time.Second
+
+type cachedAlertChannels struct {
+	channels []newrelic.AlertChannel
+	expires  time.Time
+}
+
+// apiKeySchema returns the schema for the optional resource-level api_key
+// that overrides the provider-level key.
+func apiKeySchema() *schema.Schema {
+	return &schema.Schema{
+		Type:      schema.TypeString,
+		Optional:  true,
+		Sensitive: true,
+	}
+}
+
+// stopContext returns the context that is cancelled when Terraform is
+// interrupted, so that waits end early.
+func (p *ProviderConfig) stopContext() context.Context {
+	if p.stopCtx == nil {
+		return context.Background()
+	}
+
+	return p.stopCtx
+}
+
+// permalink returns the URL of an account's page in the New Relic UI on the
+// given host of the provider's region, e.g. "alerts" and "policies/123" for an
+// alert policy. It's empty when the account isn't known: only the provider
+// account_id is, so resources using their own api_key, which may belong to
+// another account, get none.
+func (p *ProviderConfig) permalink(d resourceAttributes, host string, path string) string {
+	if _, ok := d.GetOk("api_key"); ok || p.accountID == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("https://%s.%s/accounts/%d/%s", host, urlOrDefault(p.uiDomain, regionURLs["US"].ui), p.accountID, path)
+}
+
+// syntheticsMonitorGUID returns the entity GUID of the Synthetics monitor with
+// id, or "" when the provider has no account_id to build it from.
+func (p *ProviderConfig) syntheticsMonitorGUID(id string) string {
+	if p.accountID == 0 {
+		return ""
+	}
+
+	return base64.RawStdEncoding.EncodeToString([]byte(fmt.Sprintf("%d|SYNTH|MONITOR|%s", p.accountID, id)))
+}
+
+// validateCredentials makes a single authenticated request, so that a wrong
+// api_key, or one used with the wrong region, fails once with a clear error
+// rather than in every resource.
+func (p *ProviderConfig) validateCredentials(region string) error {
+	_, err := p.Client.Do("GET", "/applications.json", nil, nil)
+	if err == nil {
+		return nil
+	}
+
+	if _, ok := err.(*url.Error); ok {
+		return fmt.Errorf("Error connecting to the New Relic API at %s: %s. Check the network, proxy_url and api_url, or set skip_validation to plan offline", p.config.APIURL, err)
+	}
+
+	return fmt.Errorf("Error validating the api_key against the New Relic API at %s: %s. Check that the key is valid and that its account is in the %s region", p.config.APIURL, err, region)
+}
+
+// resourceAttributes is implemented by both schema.ResourceData and
+// schema.ResourceDiff, so clients can also be looked up in CustomizeDiff.
+type resourceAttributes interface {
+	GetOk(string) (interface{}, bool)
+}
+
+// clientFor returns the REST client for a resource, using the resource-level
+// api_key when one is set and falling back to the provider client otherwise.
+func (p *ProviderConfig) clientFor(d resourceAttributes) (*newrelic.Client, error) {
+	apiKey, ok := d.GetOk("api_key")
+	if !ok {
+		return p.Client, nil
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if client, ok := p.resourceClients[apiKey.(string)]; ok {
+		return client, nil
+	}
+
+	config := p.config
+	config.APIKey = apiKey.(string)
+	config.APIKeySource = apiKeySourceResource
+
+	client, err := config.Client()
+	if err != nil {
+		return nil, fmt.Errorf("Error initializing New Relic client: %s", err)
+	}
+
+	if p.resourceClients == nil {
+		p.resourceClients = make(map[string]*newrelic.Client)
+	}
+	p.resourceClients[apiKey.(string)] = client
+
+	return client, nil
+}
+
+// infraClientFor returns the Infra client for a resource, using the
+// resource-level api_key when one is set.
+func (p *ProviderConfig) infraClientFor(d resourceAttributes) (*newrelic.InfraClient, error) {
+	apiKey, ok := d.GetOk("api_key")
+	if !ok {
+		return p.InfraClient, nil
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if client, ok := p.resourceInfraClients[apiKey.(string)]; ok {
+		return client, nil
+	}
+
+	config := p.infraConfig
+	config.APIKey = apiKey.(string)
+	config.APIKeySource = apiKeySourceResource
+
+	client, err := config.ClientInfra()
+	if err != nil {
+		return nil, fmt.Errorf("Error initializing New Relic Infra client: %s", err)
+	}
+
+	if p.resourceInfraClients == nil {
+		p.resourceInfraClients = make(map[string]*newrelic.InfraClient)
+	}
+	p.resourceInfraClients[apiKey.(string)] = client
+
+	return client, nil
+}
+
+// syntheticsLocationList returns the Synthetics locations available to the
+// account, sorted by name. The list is fetched once and then cached.
+func (p *ProviderConfig) syntheticsLocationList() ([]syntheticsLocation, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.syntheticsLocations != nil {
+		return p.syntheticsLocations, nil
+	}
+
+	locations, err := listSyntheticsLocations(p.Synthetics)
+	if err != nil {
+		return nil, fmt.Errorf("Error listing New Relic Synthetics locations: %s", err)
+	}
+
+	if locations == nil {
+		locations = []syntheticsLocation{}
+	}
+	sort.Slice(locations, func(i, j int) bool { return locations[i].Name < locations[j].Name })
+
+	p.syntheticsLocations = locations
+
+	return locations, nil
+}
+
+// syntheticsLocationNames returns the names of the Synthetics locations
+// available to the account, sorted.
+func (p *ProviderConfig) syntheticsLocationNames() ([]string, error) {
+	locations, err := p.syntheticsLocationList()
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, len(locations))
+	for i, l := range locations {
+		names[i] = l.Name
+	}
+
+	return names, nil
+}
+
+// listApplications returns the applications visible to client. The list is
+// cached for applicationsCacheTTL so that data sources referencing many
+// applications don't each list every application.
+func (p *ProviderConfig) listApplications(client *newrelic.Client) ([]newrelic.Application, error) {
+	key := client.RestyClient.Header.Get("X-Api-Key")
+
+	p.applicationsMu.Lock()
+	defer p.applicationsMu.Unlock()
+
+	if cached, ok := p.applications[key]; ok && time.Now().Before(cached.expires) {
+		return cached.applications, nil
+	}
+
+	log.Printf("[INFO] Listing New Relic applications")
+
+	applications, err := client.ListApplications()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.applications == nil {
+		p.applications = make(map[string]*cachedApplications)
+	}
+	p.applications[key] = &cachedApplications{
+		applications: applications,
+		expires:      time.Now().Add(applicationsCacheTTL),
+	}
+
+	return applications, nil
+}
+
+// listAlertChannels returns the alert channels visible to client, with the
+// IDs of the policies each is attached to. The list is cached for
+// alertChannelsCacheTTL; concurrent callers wait for a single listing.
+func (p *ProviderConfig) listAlertChannels(client *newrelic.Client) ([]newrelic.AlertChannel, error) {
+	key := client.RestyClient.Header.Get("X-Api-Key")
+
+	p.alertChannelsMu.Lock()
+	defer p.alertChannelsMu.Unlock()
+
+	if cached, ok := p.alertChannels[key]; ok && time.Now().Before(cached.expires) {
+		return cached.channels, nil
+	}
+
+	log.Printf("[INFO] Listing New Relic alert channels")
+
+	channels, err := client.ListAlertChannels()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.alertChannels == nil {
+		p.alertChannels = make(map[string]*cachedAlertChannels)
+	}
+	p.alertChannels[key] = &cachedAlertChannels{
+		channels: channels,
+		expires:  time.Now().Add(alertChannelsCacheTTL),
+	}
+
+	return channels, nil
+}
+
+// forgetAlertChannels drops the cached alert channels of client, after their
+// policy links changed.
+func (p *ProviderConfig) forgetAlertChannels(client *newrelic.Client) {
+	p.alertChannelsMu.Lock()
+	defer p.alertChannelsMu.Unlock()
+
+	delete(p.alertChannels, client.RestyClient.Header.Get("X-Api-Key"))
 }
@@ -0,0 +1,161 @@
+package newrelic
+
+import (
+	"fmt"
+
+	newrelic "github.com/paultyng/go-newrelic/v4/api"
+)
+
+// The New Relic client library only sends the dashboard attributes it models,
+// so newrelic_dashboard sends and reads dashboards with the attributes it
+// leaves out, and newrelic_dashboard_json raw dashboards, here using the
+// client's REST client.
+
+// dashboard represents a New Relic dashboard.
+type dashboard struct {
+	ID              int                        `json:"id"`
+	Title           string                     `json:"title,omitempty"`
+	Icon            string                     `json:"icon,omitempty"`
+	CreatedAt       string                     `json:"created_at,omitempty"`
+	UpdatedAt       string                     `json:"updated_at,omitempty"`
+	Visibility      string                     `json:"visibility,omitempty"`
+	Editable        string                     `json:"editable,omitempty"`
+	GridColumnCount int                        `json:"grid_column_count,omitempty"`
+	UIURL           string                     `json:"ui_url,omitempty"`
+	APIURL          string                     `json:"api_url,omitempty"`
+	OwnerEmail      string                     `json:"owner_email,omitempty"`
+	Metadata        newrelic.DashboardMetadata `json:"metadata"`
+	Filter          newrelic.DashboardFilter   `json:"filter,omitempty"`
+	Widgets         []dashboardWidget          `json:"widgets,omitempty"`
+}
+
+// dashboardWidget represents a widget in a dashboard.
+type dashboardWidget struct {
+	Visualization string                         `json:"visualization,omitempty"`
+	AccountID     int                            `json:"account_id,omitempty"`
+	Data          []dashboardWidgetData          `json:"data,omitempty"`
+	Presentation  dashboardWidgetPresentation    `json:"presentation,omitempty"`
+	Layout        newrelic.DashboardWidgetLayout `json:"layout,omitempty"`
+}
+
+// dashboardWidgetData represents the data backing a dashboard widget: a NRQL
+// query, or the markdown source of a markdown widget.
+type dashboardWidgetData struct {
+	NRQL   string `json:"nrql,omitempty"`
+	Source string `json:"source,omitempty"`
+}
+
+// dashboardWidgetPresentation represents the visual presentation of a
+// dashboard widget.
+type dashboardWidgetPresentation struct {
+	Title                string                    `json:"title,omitempty"`
+	Notes                string                    `json:"notes,omitempty"`
+	DrilldownDashboardID int                       `json:"drilldown_dashboard_id,omitempty"`
+	Threshold            *dashboardWidgetThreshold `json:"threshold,omitempty"`
+}
+
+// dashboardWidgetThreshold represents the threshold levels of a billboard
+// widget.
+type dashboardWidgetThreshold struct {
+	Red    float64 `json:"red,omitempty"`
+	Yellow float64 `json:"yellow,omitempty"`
+}
+
+func getDashboard(client *newrelic.Client, id int) (*dashboard, error) {
+	resp := struct {
+		Dashboard dashboard `json:"dashboard,omitempty"`
+	}{}
+
+	if _, err := client.Do("GET", fmt.Sprintf("/dashboards/%d.json", id), nil, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp.Dashboard, nil
+}
+
+func createDashboard(client *newrelic.Client, d dashboard) (*dashboard, error) {
+	req := struct {
+		Dashboard dashboard `json:"dashboard"`
+	}{
+		Dashboard: d,
+	}
+
+	resp := struct {
+		Dashboard dashboard `json:"dashboard,omitempty"`
+	}{}
+
+	if _, err := client.Do("POST", "/dashboards.json", req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp.Dashboard, nil
+}
+
+func updateDashboard(client *newrelic.Client, d dashboard) (*dashboard, error) {
+	req := struct {
+		Dashboard dashboard `json:"dashboard"`
+	}{
+		Dashboard: d,
+	}
+
+	resp := struct {
+		Dashboard dashboard `json:"dashboard,omitempty"`
+	}{}
+
+	if _, err := client.Do("PUT", fmt.Sprintf("/dashboards/%d.json", d.ID), req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp.Dashboard, nil
+}
+
+func createDashboardJSON(client *newrelic.Client, dashboard map[string]interface{}) (map[string]interface{}, error) {
+	req := struct {
+		Dashboard map[string]interface{} `json:"dashboard"`
+	}{
+		Dashboard: dashboard,
+	}
+
+	resp := struct {
+		Dashboard map[string]interface{} `json:"dashboard,omitempty"`
+	}{}
+
+	if _, err := client.Do("POST", "/dashboards.json", req, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.Dashboard, nil
+}
+
+func getDashboardJSON(client *newrelic.Client, id int) (map[string]interface{}, error) {
+	resp := struct {
+		Dashboard map[string]interface{} `json:"dashboard,omitempty"`
+	}{}
+
+	if _, err := client.Do("GET", fmt.Sprintf("/dashboards/%d.json", id), nil, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.Dashboard, nil
+}
+
+func updateDashboardJSON(client *newrelic.Client, id int, dashboard map[string]interface{}) error {
+	req := struct {
+		Dashboard map[string]interface{} `json:"dashboard"`
+	}{
+		Dashboard: dashboard,
+	}
+
+	_, err := client.Do("PUT", fmt.Sprintf("/dashboards/%d.json", id), req, nil)
+	return err
+}
+
+// dashboardJSONID returns the ID of a dashboard returned by the API.
+func dashboardJSONID(dashboard map[string]interface{}) (int, error) {
+	id, ok := dashboard["id"].(float64)
+	if !ok {
+		return 0, fmt.Errorf("New Relic API returned a dashboard without an id")
+	}
+
+	return int(id), nil
+}
@@ -0,0 +1,70 @@
+package newrelic
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataSourceNewRelicAccount() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceNewRelicAccountRead,
+
+		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// dataSourceNewRelicAccountRead uses the provider account_id, or the only
+// account the API key has access to when it isn't set.
+func dataSourceNewRelicAccountRead(d *schema.ResourceData, meta interface{}) error {
+	p := meta.(*ProviderConfig)
+
+	log.Printf("[INFO] Reading New Relic accounts")
+
+	accounts, err := getAccounts(p.Client)
+	if err != nil {
+		if p.accountID == 0 {
+			return fmt.Errorf("Error resolving the New Relic account, set the provider account_id: %s", err)
+		}
+
+		// The configured ID is still known, only the name can't be looked up.
+		log.Printf("[WARN] Unable to look up the name of New Relic account %d: %s", p.accountID, err)
+	}
+
+	accountID := p.accountID
+	if accountID == 0 {
+		switch len(accounts) {
+		case 0:
+			return fmt.Errorf("The API key doesn't have access to any New Relic account")
+		case 1:
+			accountID = accounts[0].ID
+		default:
+			return fmt.Errorf("The API key has access to %d New Relic accounts, set the provider account_id to choose one", len(accounts))
+		}
+	}
+
+	name := ""
+	for _, a := range accounts {
+		if a.ID == accountID {
+			name = a.Name
+			break
+		}
+	}
+
+	d.SetId(strconv.Itoa(accountID))
+	d.Set("account_id", accountID)
+	d.Set("name", name)
+
+	return nil
+}
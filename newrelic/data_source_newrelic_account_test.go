@@ -0,0 +1,90 @@
+package newrelic
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	newrelic "github.com/paultyng/go-newrelic/v4/api"
+)
+
+func TestAccNewRelicAccountDataSource_Basic(t *testing.T) {
+	accountID := testAccAccountID(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "newrelic_account" "acc" {}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"data.newrelic_account.acc", "account_id", accountID),
+					resource.TestCheckResourceAttrSet(
+						"data.newrelic_account.acc", "name"),
+				),
+			},
+		},
+	})
+}
+
+func testAccountsProviderConfig(t *testing.T, status int, body string, accountID int) (*ProviderConfig, func()) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+
+	client := newrelic.New(newrelic.Config{BaseURL: server.URL})
+
+	return &ProviderConfig{Client: &client, accountID: accountID}, server.Close
+}
+
+func TestDataSourceNewRelicAccountRead(t *testing.T) {
+	one := `{"data":{"actor":{"accounts":[{"id":1,"name":"Production"}]}}}`
+	two := `{"data":{"actor":{"accounts":[{"id":1,"name":"Production"},{"id":2,"name":"Staging"}]}}}`
+
+	cases := []struct {
+		status    int
+		body      string
+		accountID int
+		id        string
+		name      string
+		err       string
+	}{
+		{status: 200, body: one, id: "1", name: "Production"},
+		{status: 200, body: two, accountID: 2, id: "2", name: "Staging"},
+		{status: 200, body: two, accountID: 3, id: "3", name: ""},
+		{status: 403, body: `{}`, accountID: 2, id: "2", name: ""},
+		{status: 200, body: two, err: "has access to 2 New Relic accounts"},
+		{status: 200, body: `{"data":{"actor":{"accounts":[]}}}`, err: "doesn't have access to any New Relic account"},
+		{status: 403, body: `{}`, err: "Error resolving the New Relic account"},
+	}
+
+	for i, c := range cases {
+		meta, closeServer := testAccountsProviderConfig(t, c.status, c.body, c.accountID)
+
+		d := dataSourceNewRelicAccount().TestResourceData()
+		err := dataSourceNewRelicAccountRead(d, meta)
+		closeServer()
+
+		if c.err != "" {
+			if err == nil || !strings.Contains(err.Error(), c.err) {
+				t.Fatalf("case %d: expected an error containing %q, got %v", i, c.err, err)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("case %d: %s", i, err)
+		}
+
+		if d.Id() != c.id || d.Get("name").(string) != c.name {
+			t.Fatalf("case %d: expected account %s %q, got %s %q", i, c.id, c.name, d.Id(), d.Get("name"))
+		}
+	}
+}
@@ -42,18 +42,9 @@ func dataSourceNewRelicAlertChannelRead(d *schema.ResourceData, meta interface{}
 		return err
 	}
 
-	var channel *newrelic.AlertChannel
-	name := d.Get("name").(string)
-
-	for _, c := range channels {
-		if strings.Contains(strings.ToLower(c.Name), strings.ToLower(name)) {
-			channel = &c
-			break
-		}
-	}
-
-	if channel == nil {
-		return fmt.Errorf("The name '%s' does not match any New Relic alert channel.", name)
+	channel, err := findAlertChannelByName(channels, d.Get("name").(string))
+	if err != nil {
+		return err
 	}
 
 	d.SetId(strconv.Itoa(channel.ID))
@@ -63,3 +54,29 @@ func dataSourceNewRelicAlertChannelRead(d *schema.ResourceData, meta interface{}
 
 	return nil
 }
+
+// findAlertChannelByName returns the only channel whose name matches name,
+// ignoring case.
+func findAlertChannelByName(channels []newrelic.AlertChannel, name string) (*newrelic.AlertChannel, error) {
+	var matches []newrelic.AlertChannel
+
+	for _, c := range channels {
+		if strings.EqualFold(c.Name, name) {
+			matches = append(matches, c)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("The name '%s' does not match any New Relic alert channel.", name)
+	case 1:
+		return &matches[0], nil
+	default:
+		ids := make([]string, 0, len(matches))
+		for _, c := range matches {
+			ids = append(ids, strconv.Itoa(c.ID))
+		}
+
+		return nil, fmt.Errorf("The name '%s' matches %d New Relic alert channels (%s); channel names must be unique to be looked up.", name, len(matches), strings.Join(ids, ", "))
+	}
+}
@@ -2,12 +2,16 @@ package newrelic
 
 import (
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
+	newrelic "github.com/paultyng/go-newrelic/v4/api"
 )
 
 func TestAccNewRelicAlertChannelDataSource_Basic(t *testing.T) {
@@ -26,6 +30,49 @@ func TestAccNewRelicAlertChannelDataSource_Basic(t *testing.T) {
 	})
 }
 
+func TestDataSourceNewRelicAlertChannelRead_paginated(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.URL.Query().Get("page") == "2" {
+			w.Write([]byte(`{"channels":[{"id":2,"name":"Shared Oncall","type":"slack"},{"id":3,"name":"duplicate","type":"email"}]}`))
+			return
+		}
+
+		w.Header().Set("Link", fmt.Sprintf(`<%s/alerts_channels.json?page=2>; rel="next"`, server.URL))
+		w.Write([]byte(`{"channels":[{"id":1,"name":"shared oncall team","type":"email"},{"id":4,"name":"Duplicate","type":"email"}]}`))
+	}))
+	defer server.Close()
+
+	client := newrelic.New(newrelic.Config{BaseURL: server.URL})
+	meta := &ProviderConfig{Client: &client}
+
+	d := schema.TestResourceDataRaw(t, dataSourceNewRelicAlertChannel().Schema, map[string]interface{}{
+		"name": "shared oncall",
+	})
+	if err := dataSourceNewRelicAlertChannelRead(d, meta); err != nil {
+		t.Fatal(err)
+	}
+	if d.Id() != "2" || d.Get("type").(string) != "slack" {
+		t.Fatalf("expected channel 2 of type slack, got %s of type %s", d.Id(), d.Get("type"))
+	}
+
+	for name, expected := range map[string]string{
+		"missing":   "The name 'missing' does not match any New Relic alert channel.",
+		"duplicate": "The name 'duplicate' matches 2 New Relic alert channels (4, 3); channel names must be unique to be looked up.",
+	} {
+		d := schema.TestResourceDataRaw(t, dataSourceNewRelicAlertChannel().Schema, map[string]interface{}{
+			"name": name,
+		})
+
+		err := dataSourceNewRelicAlertChannelRead(d, meta)
+		if err == nil || err.Error() != expected {
+			t.Fatalf("expected %q, got %q", expected, err)
+		}
+	}
+}
+
 func testAccNewRelicAlertChannel(n string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		r := s.RootModule().Resources[n]
@@ -0,0 +1,191 @@
+package newrelic
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	newrelic "github.com/paultyng/go-newrelic/v4/api"
+)
+
+func dataSourceNewRelicAlertCondition() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceNewRelicAlertConditionRead,
+
+		Schema: map[string]*schema.Schema{
+			"policy_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+			"name": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"condition_id"},
+			},
+			"condition_id": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"name"},
+			},
+			"type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"metric": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"entities": {
+				Type:     schema.TypeList,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+				Computed: true,
+			},
+			"condition_scope": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"enabled": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"term": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"duration": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"operator": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"priority": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"threshold": {
+							Type:     schema.TypeFloat,
+							Computed: true,
+						},
+						"time_function": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceNewRelicAlertConditionRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ProviderConfig).Client
+
+	policyID := d.Get("policy_id").(int)
+	name := d.Get("name").(string)
+	id := d.Get("condition_id").(int)
+
+	if name == "" && id == 0 {
+		return fmt.Errorf("one of name or condition_id must be set")
+	}
+
+	log.Printf("[INFO] Reading New Relic alert conditions of policy %d", policyID)
+
+	conditions, err := client.ListAlertConditions(policyID)
+	if err != nil {
+		return err
+	}
+
+	var condition *newrelic.AlertCondition
+	if id != 0 {
+		for i := range conditions {
+			if conditions[i].ID == id {
+				condition = &conditions[i]
+				break
+			}
+		}
+
+		if condition == nil {
+			return fmt.Errorf("New Relic alert condition %d not found in policy %d", id, policyID)
+		}
+	} else {
+		condition, err = findAlertConditionByName(conditions, policyID, name)
+		if err != nil {
+			return err
+		}
+	}
+
+	return flattenAlertConditionDataSource(condition, policyID, d)
+}
+
+// findAlertConditionByName returns the only condition of the policy whose name
+// matches name, ignoring case.
+func findAlertConditionByName(conditions []newrelic.AlertCondition, policyID int, name string) (*newrelic.AlertCondition, error) {
+	var matches []newrelic.AlertCondition
+
+	for _, c := range conditions {
+		if strings.EqualFold(c.Name, name) {
+			matches = append(matches, c)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("The name '%s' does not match any New Relic alert condition of policy %d.", name, policyID)
+	case 1:
+		return &matches[0], nil
+	default:
+		ids := make([]string, 0, len(matches))
+		for _, c := range matches {
+			ids = append(ids, strconv.Itoa(c.ID))
+		}
+
+		return nil, fmt.Errorf("The name '%s' matches %d New Relic alert conditions of policy %d (%s); use condition_id instead.", name, len(matches), policyID, strings.Join(ids, ", "))
+	}
+}
+
+func flattenAlertConditionDataSource(condition *newrelic.AlertCondition, policyID int, d *schema.ResourceData) error {
+	entities := make([]int, len(condition.Entities))
+	for i, entity := range condition.Entities {
+		v, err := strconv.Atoi(entity)
+		if err != nil {
+			return err
+		}
+		entities[i] = v
+	}
+
+	d.SetId(serializeIDs([]int{policyID, condition.ID}))
+	d.Set("condition_id", condition.ID)
+	d.Set("name", condition.Name)
+	d.Set("type", condition.Type)
+	d.Set("metric", condition.Metric)
+	d.Set("condition_scope", condition.Scope)
+	d.Set("enabled", condition.Enabled)
+
+	if err := d.Set("entities", entities); err != nil {
+		return fmt.Errorf("[DEBUG] Error setting alert condition entities: %#v", err)
+	}
+
+	terms := make([]map[string]interface{}, 0, len(condition.Terms))
+	for _, src := range condition.Terms {
+		terms = append(terms, map[string]interface{}{
+			"duration":      src.Duration,
+			"operator":      src.Operator,
+			"priority":      src.Priority,
+			"threshold":     src.Threshold,
+			"time_function": src.TimeFunction,
+		})
+	}
+
+	if err := d.Set("term", terms); err != nil {
+		return fmt.Errorf("[DEBUG] Error setting alert condition terms: %#v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,104 @@
+package newrelic
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	newrelic "github.com/paultyng/go-newrelic/v4/api"
+)
+
+func TestAccNewRelicAlertConditionDataSource_Basic(t *testing.T) {
+	rName := acctest.RandString(5)
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNewRelicAlertConditionDataSourceConfig(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.newrelic_alert_condition.foo", "id", "newrelic_alert_condition.foo", "id"),
+					resource.TestCheckResourceAttr("data.newrelic_alert_condition.foo", "metric", "apdex"),
+					resource.TestCheckResourceAttr("data.newrelic_alert_condition.foo", "term.#", "1"),
+					resource.TestCheckResourceAttr("data.newrelic_alert_condition.foo", "term.0.operator", "below"),
+					resource.TestCheckResourceAttr("data.newrelic_alert_condition.foo", "term.0.threshold", "0.75"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSourceNewRelicAlertConditionRead(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"conditions":[` +
+			`{"id":1,"name":"Apdex","type":"apm_app_metric","metric":"apdex","entities":["10","20"],"enabled":true,` +
+			`"terms":[{"duration":"5","operator":"below","priority":"critical","threshold":"0.75","time_function":"all"},` +
+			`{"duration":"10","operator":"below","priority":"warning","threshold":"0.85","time_function":"all"}]},` +
+			`{"id":2,"name":"duplicate","type":"apm_app_metric","metric":"error_percentage","entities":["10"]},` +
+			`{"id":3,"name":"Duplicate","type":"apm_app_metric","metric":"error_percentage","entities":["20"]}]}`))
+	}))
+	defer server.Close()
+
+	client := newrelic.New(newrelic.Config{BaseURL: server.URL})
+	meta := &ProviderConfig{Client: &client}
+
+	read := func(raw map[string]interface{}) (*schema.ResourceData, error) {
+		raw["policy_id"] = 123
+		d := schema.TestResourceDataRaw(t, dataSourceNewRelicAlertCondition().Schema, raw)
+		return d, dataSourceNewRelicAlertConditionRead(d, meta)
+	}
+
+	d, err := read(map[string]interface{}{"name": "apdex"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if d.Id() != "123:1" || d.Get("condition_id").(int) != 1 || d.Get("metric").(string) != "apdex" {
+		t.Fatalf("expected condition 1 of policy 123 on apdex, got %s on %s", d.Id(), d.Get("metric"))
+	}
+
+	for k, expected := range map[string]interface{}{
+		"entities.1":       20,
+		"term.#":           2,
+		"term.0.operator":  "below",
+		"term.0.threshold": 0.75,
+		"term.1.priority":  "warning",
+		"term.1.threshold": 0.85,
+		"term.1.duration":  10,
+	} {
+		if actual := d.Get(k); actual != expected {
+			t.Errorf("expected %s to be %v, got %v", k, expected, actual)
+		}
+	}
+
+	if d, err := read(map[string]interface{}{"condition_id": 2}); err != nil || d.Get("name").(string) != "duplicate" {
+		t.Fatalf("expected condition 2 to be read by its ID, got %v", err)
+	}
+
+	for expected, raw := range map[string]map[string]interface{}{
+		"The name 'missing' does not match any New Relic alert condition of policy 123.":                            {"name": "missing"},
+		"The name 'duplicate' matches 2 New Relic alert conditions of policy 123 (2, 3); use condition_id instead.": {"name": "duplicate"},
+		"New Relic alert condition 4 not found in policy 123":                                                       {"condition_id": 4},
+		"one of name or condition_id must be set":                                                                   {},
+	} {
+		if _, err := read(raw); err == nil || err.Error() != expected {
+			t.Errorf("expected %q, got %q", expected, err)
+		}
+	}
+}
+
+func testAccNewRelicAlertConditionDataSourceConfig(rName string) string {
+	return fmt.Sprintf(`
+%s
+
+data "newrelic_alert_condition" "foo" {
+  policy_id = "${newrelic_alert_policy.foo.id}"
+  name      = "${newrelic_alert_condition.foo.name}"
+}
+`, testAccCheckNewRelicAlertConditionConfig(rName))
+}
@@ -0,0 +1,78 @@
+package newrelic
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/hashcode"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataSourceNewRelicAlertPolicies() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceNewRelicAlertPoliciesRead,
+
+		Schema: map[string]*schema.Schema{
+			"name_prefix": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"policies": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"incident_preference": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceNewRelicAlertPoliciesRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ProviderConfig).Client
+
+	log.Printf("[INFO] Reading New Relic Alert Policies")
+
+	policies, err := client.ListAlertPolicies()
+	if err != nil {
+		return err
+	}
+
+	prefix := d.Get("name_prefix").(string)
+
+	var matches []map[string]interface{}
+
+	for _, p := range policies {
+		if !strings.HasPrefix(p.Name, prefix) {
+			continue
+		}
+
+		matches = append(matches, map[string]interface{}{
+			"id":                  p.ID,
+			"name":                p.Name,
+			"incident_preference": p.IncidentPreference,
+		})
+	}
+
+	d.SetId(fmt.Sprintf("%d", hashcode.String(prefix)))
+
+	if err := d.Set("policies", matches); err != nil {
+		return fmt.Errorf("[DEBUG] Error setting alert policies: %#v", err)
+	}
+
+	return nil
+}
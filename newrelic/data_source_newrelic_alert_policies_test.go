@@ -0,0 +1,48 @@
+package newrelic
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccNewRelicAlertPoliciesDataSource_Basic(t *testing.T) {
+	rName := acctest.RandString(5)
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNewRelicAlertPoliciesDataSourceConfig(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"data.newrelic_alert_policies.policies", "policies.#", "2"),
+					resource.TestCheckResourceAttrSet(
+						"data.newrelic_alert_policies.policies", "policies.0.id"),
+					resource.TestCheckResourceAttr(
+						"data.newrelic_alert_policies.policies", "policies.0.incident_preference", "PER_POLICY"),
+				),
+			},
+		},
+	})
+}
+
+func testAccNewRelicAlertPoliciesDataSourceConfig(rName string) string {
+	return fmt.Sprintf(`
+resource "newrelic_alert_policy" "foo" {
+	name = "tf-test-%[1]s-foo"
+}
+
+resource "newrelic_alert_policy" "bar" {
+	name = "tf-test-%[1]s-bar"
+}
+
+data "newrelic_alert_policies" "policies" {
+	name_prefix = "tf-test-%[1]s-"
+
+	depends_on = ["newrelic_alert_policy.foo", "newrelic_alert_policy.bar"]
+}
+`, rName)
+}
@@ -5,6 +5,7 @@ import (
 	"log"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform/helper/schema"
 	newrelic "github.com/paultyng/go-newrelic/v4/api"
@@ -62,8 +63,8 @@ func dataSourceNewRelicAlertPolicyRead(d *schema.ResourceData, meta interface{})
 	d.SetId(strconv.Itoa(policy.ID))
 	d.Set("name", policy.Name)
 	d.Set("incident_preference", policy.IncidentPreference)
-	d.Set("created_at", policy.CreatedAt)
-	d.Set("updated_at", policy.UpdatedAt)
+	d.Set("created_at", unixMillis(policy.CreatedAt).Format(time.RFC3339))
+	d.Set("updated_at", unixMillis(policy.UpdatedAt).Format(time.RFC3339))
 
 	return nil
 }
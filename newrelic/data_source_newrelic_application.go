@@ -33,11 +33,11 @@ func dataSourceNewRelicApplication() *schema.Resource {
 }
 
 func dataSourceNewRelicApplicationRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).Client
+	providerConfig := meta.(*ProviderConfig)
 
 	log.Printf("[INFO] Reading New Relic applications")
 
-	applications, err := client.ListApplications()
+	applications, err := providerConfig.listApplications(providerConfig.Client)
 	if err != nil {
 		return err
 	}
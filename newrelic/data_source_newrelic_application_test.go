@@ -2,10 +2,15 @@ package newrelic
 
 import (
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
+	newrelic "github.com/paultyng/go-newrelic/v4/api"
 )
 
 func TestAccNewRelicApplication_Basic(t *testing.T) {
@@ -23,6 +28,45 @@ func TestAccNewRelicApplication_Basic(t *testing.T) {
 	})
 }
 
+func TestDataSourceNewRelicApplicationRead_cached(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"applications":[{"id":1,"name":"foo"},{"id":2,"name":"bar"}]}`))
+	}))
+	defer server.Close()
+
+	client := newrelic.New(newrelic.Config{APIKey: "foo", BaseURL: server.URL})
+	meta := &ProviderConfig{Client: &client}
+
+	for _, name := range []string{"foo", "bar", "foo"} {
+		d := schema.TestResourceDataRaw(t, dataSourceNewRelicApplication().Schema, map[string]interface{}{
+			"name": name,
+		})
+		if err := dataSourceNewRelicApplicationRead(d, meta); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if calls != 1 {
+		t.Fatalf("expected applications to be listed once, got %d calls", calls)
+	}
+
+	meta.applications["foo"].expires = time.Now().Add(-time.Second)
+
+	d := schema.TestResourceDataRaw(t, dataSourceNewRelicApplication().Schema, map[string]interface{}{
+		"name": "bar",
+	})
+	if err := dataSourceNewRelicApplicationRead(d, meta); err != nil {
+		t.Fatal(err)
+	}
+
+	if calls != 2 {
+		t.Fatalf("expected an expired cache to list applications again, got %d calls", calls)
+	}
+}
+
 func testAccNewRelicApplication(n string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		r := s.RootModule().Resources[n]
@@ -0,0 +1,95 @@
+package newrelic
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	newrelic "github.com/paultyng/go-newrelic/v4/api"
+)
+
+func dataSourceNewRelicDashboard() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceNewRelicDashboardRead,
+
+		Schema: map[string]*schema.Schema{
+			"title": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"exact_match": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"dashboard_id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"dashboard_url": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"icon": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"visibility": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceNewRelicDashboardRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ProviderConfig).Client
+
+	log.Printf("[INFO] Reading New Relic dashboards")
+
+	dashboards, err := client.ListDashboards()
+	if err != nil {
+		return err
+	}
+
+	title := d.Get("title").(string)
+	exactMatch := d.Get("exact_match").(bool)
+
+	var matches []newrelic.Dashboard
+
+	for _, dashboard := range dashboards {
+		if exactMatch {
+			if dashboard.Title == title {
+				matches = append(matches, dashboard)
+			}
+		} else if strings.Contains(strings.ToLower(dashboard.Title), strings.ToLower(title)) {
+			matches = append(matches, dashboard)
+		}
+	}
+
+	if len(matches) == 0 {
+		return fmt.Errorf("The title '%s' does not match any New Relic dashboard.", title)
+	}
+
+	if len(matches) > 1 {
+		ids := make([]int, len(matches))
+		for i, m := range matches {
+			ids[i] = m.ID
+		}
+
+		return fmt.Errorf("The title '%s' matches multiple New Relic dashboards: %v", title, ids)
+	}
+
+	dashboard := matches[0]
+
+	d.SetId(strconv.Itoa(dashboard.ID))
+	d.Set("title", dashboard.Title)
+	d.Set("dashboard_id", dashboard.ID)
+	d.Set("dashboard_url", dashboard.UIURL)
+	d.Set("icon", dashboard.Icon)
+	d.Set("visibility", dashboard.Visibility)
+
+	return nil
+}
@@ -0,0 +1,116 @@
+package newrelic
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+	newrelic "github.com/paultyng/go-newrelic/v4/api"
+)
+
+func TestAccNewRelicDashboardDataSource_Basic(t *testing.T) {
+	rName := fmt.Sprintf("tf-test-%s", acctest.RandString(5))
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNewRelicDashboardDataSourceConfig(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccNewRelicDashboard("data.newrelic_dashboard.dashboard", rName),
+					resource.TestCheckResourceAttrPair(
+						"data.newrelic_dashboard.dashboard", "id", "newrelic_dashboard.foo", "id"),
+					resource.TestCheckResourceAttrPair(
+						"data.newrelic_dashboard.dashboard", "dashboard_url", "newrelic_dashboard.foo", "dashboard_url"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSourceNewRelicDashboardRead_paginated(t *testing.T) {
+	pages := map[string]string{
+		"":  `{"dashboards":[{"id":1,"title":"Orders","ui_url":"https://insights.newrelic.com/1"},{"id":2,"title":"Checkout"}]}`,
+		"2": `{"dashboards":[{"id":3,"title":"Payments"}]}`,
+		"3": `{"dashboards":[{"id":4,"title":"Inventory","ui_url":"https://insights.newrelic.com/4"},{"id":5,"title":"Checkout"}]}`,
+	}
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch page := r.URL.Query().Get("page"); page {
+		case "":
+			w.Header().Set("Link", fmt.Sprintf(`<%s/dashboards.json?page=2>; rel="next", <%s/dashboards.json?page=3>; rel="last"`, server.URL, server.URL))
+		case "2":
+			w.Header().Set("Link", fmt.Sprintf(`<%s/dashboards.json?page=3>; rel="next", <%s/dashboards.json?page=3>; rel="last"`, server.URL, server.URL))
+		}
+
+		w.Write([]byte(pages[r.URL.Query().Get("page")]))
+	}))
+	defer server.Close()
+
+	client := newrelic.New(newrelic.Config{BaseURL: server.URL})
+	meta := &ProviderConfig{Client: &client}
+
+	d := schema.TestResourceDataRaw(t, dataSourceNewRelicDashboard().Schema, map[string]interface{}{
+		"title": "Inventory",
+	})
+	if err := dataSourceNewRelicDashboardRead(d, meta); err != nil {
+		t.Fatal(err)
+	}
+	if d.Id() != "4" || d.Get("dashboard_url").(string) != "https://insights.newrelic.com/4" {
+		t.Fatalf("expected dashboard 4 from the last page, got %s with URL %s", d.Id(), d.Get("dashboard_url"))
+	}
+
+	// Duplicates on different pages are found too.
+	d = schema.TestResourceDataRaw(t, dataSourceNewRelicDashboard().Schema, map[string]interface{}{
+		"title": "Checkout",
+	})
+	expected := "The title 'Checkout' matches multiple New Relic dashboards: [2 5]"
+	if err := dataSourceNewRelicDashboardRead(d, meta); err == nil || err.Error() != expected {
+		t.Fatalf("expected %q, got %q", expected, err)
+	}
+}
+
+func testAccNewRelicDashboard(n string, title string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		r := s.RootModule().Resources[n]
+		a := r.Primary.Attributes
+
+		if a["id"] == "" {
+			return fmt.Errorf("Expected to get a dashboard from New Relic")
+		}
+
+		if a["title"] != title {
+			return fmt.Errorf("Expected the dashboard title to be: %s, but got: %s", title, a["title"])
+		}
+
+		return nil
+	}
+}
+
+func testAccNewRelicDashboardDataSourceConfig(rName string) string {
+	return fmt.Sprintf(`
+resource "newrelic_dashboard" "foo" {
+	title = "%s"
+
+	widget {
+		title         = "Average Transaction Duration"
+		row           = 1
+		column        = 1
+		visualization = "faceted_line_chart"
+		nrql          = "SELECT AVERAGE(duration) from Transaction FACET appName TIMESERIES auto"
+	}
+}
+
+data "newrelic_dashboard" "dashboard" {
+	title = "${newrelic_dashboard.foo.title}"
+}
+`, rName)
+}
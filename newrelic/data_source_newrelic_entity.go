@@ -0,0 +1,62 @@
+package newrelic
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataSourceNewRelicEntity() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceNewRelicEntityRead,
+
+		Schema: map[string]*schema.Schema{
+			"guid": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateEntityGUID,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"domain": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"account_id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceNewRelicEntityRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ProviderConfig).Client
+	guid := d.Get("guid").(string)
+
+	log.Printf("[INFO] Reading New Relic entity %s", guid)
+
+	entity, err := getEntity(client, guid)
+	if err != nil {
+		if err == errEntityNotFound {
+			return fmt.Errorf("The GUID '%s' does not match any New Relic entity.", guid)
+		}
+
+		return err
+	}
+
+	d.SetId(entity.GUID)
+	d.Set("name", entity.Name)
+	d.Set("type", entity.Type)
+	d.Set("domain", entity.Domain)
+	d.Set("account_id", entity.AccountID)
+
+	return nil
+}
@@ -0,0 +1,74 @@
+package newrelic
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccNewRelicEntityDataSource_Basic(t *testing.T) {
+	key := "NEWRELIC_ENTITY_GUID"
+	guid := os.Getenv(key)
+	if guid == "" {
+		t.Skipf("Environment variable %s is not set", key)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNewRelicEntityDataSourceConfig(guid),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"data.newrelic_entity.entity", "id", guid),
+					resource.TestCheckResourceAttrSet(
+						"data.newrelic_entity.entity", "name"),
+					resource.TestCheckResourceAttrSet(
+						"data.newrelic_entity.entity", "domain"),
+					resource.TestCheckResourceAttrSet(
+						"data.newrelic_entity.entity", "account_id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccNewRelicEntityDataSource_notFound(t *testing.T) {
+	expectedErrorMsg, _ := regexp.Compile("does not match any New Relic entity")
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				// 1|APM|APPLICATION|1
+				Config:      testAccNewRelicEntityDataSourceConfig("MXxBUE18QVBQTElDQVRJT058MQ=="),
+				ExpectError: expectedErrorMsg,
+			},
+		},
+	})
+}
+
+func TestAccNewRelicEntityDataSource_invalidGUID(t *testing.T) {
+	expectedErrorMsg, _ := regexp.Compile("to be a valid entity GUID")
+	resource.Test(t, resource.TestCase{
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccNewRelicEntityDataSourceConfig("not-a-guid"),
+				ExpectError: expectedErrorMsg,
+			},
+		},
+	})
+}
+
+func testAccNewRelicEntityDataSourceConfig(guid string) string {
+	return fmt.Sprintf(`
+data "newrelic_entity" "entity" {
+	guid = "%s"
+}
+`, guid)
+}
@@ -6,7 +6,6 @@ import (
 	"strconv"
 
 	"github.com/hashicorp/terraform/helper/schema"
-	newrelic "github.com/paultyng/go-newrelic/v4/api"
 )
 
 func dataSourceNewRelicKeyTransaction() *schema.Resource {
@@ -18,39 +17,86 @@ func dataSourceNewRelicKeyTransaction() *schema.Resource {
 				Type:     schema.TypeString,
 				Required: true,
 			},
+			"transaction_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"application_id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"application_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"apdex_target": {
+				Type:     schema.TypeFloat,
+				Computed: true,
+			},
+			"response_time": {
+				Type:     schema.TypeFloat,
+				Computed: true,
+			},
 		},
 	}
 }
 
 func dataSourceNewRelicKeyTransactionRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).Client
+	providerConfig := meta.(*ProviderConfig)
+	client := providerConfig.Client
 
 	log.Printf("[INFO] Reading New Relic key transactions")
 
-	transactions, err := client.ListKeyTransactions()
+	transactions, err := listKeyTransactions(client)
 	if err != nil {
 		return err
 	}
 
-	var transaction *newrelic.KeyTransaction
-	name, ok := d.Get("name").(string)
-	if !ok {
-		return fmt.Errorf("The name '%v' is not a string.", name)
-	}
+	name := d.Get("name").(string)
+
+	var matches []keyTransaction
 
 	for _, t := range transactions {
 		if t.Name == name {
-			transaction = &t
-			break
+			matches = append(matches, t)
 		}
 	}
 
-	if transaction == nil {
+	if len(matches) == 0 {
 		return fmt.Errorf("The name '%s' does not match any New Relic key transaction.", name)
 	}
 
+	if len(matches) > 1 {
+		ids := make([]int, len(matches))
+		for i, m := range matches {
+			ids[i] = m.ID
+		}
+
+		return fmt.Errorf("The name '%s' matches multiple New Relic key transactions: %v", name, ids)
+	}
+
+	transaction := matches[0]
+
+	applications, err := providerConfig.listApplications(client)
+	if err != nil {
+		return err
+	}
+
+	applicationName := ""
+	for _, a := range applications {
+		if a.ID == transaction.Links.Application {
+			applicationName = a.Name
+			break
+		}
+	}
+
 	d.SetId(strconv.Itoa(transaction.ID))
 	d.Set("name", transaction.Name)
+	d.Set("transaction_name", transaction.TransactionName)
+	d.Set("application_id", transaction.Links.Application)
+	d.Set("application_name", applicationName)
+	d.Set("apdex_target", transaction.Summary.ApdexTarget)
+	d.Set("response_time", transaction.Summary.ResponseTime)
 
 	return nil
 }
@@ -0,0 +1,80 @@
+package newrelic
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	newrelic "github.com/paultyng/go-newrelic/v4/api"
+)
+
+func TestDataSourceNewRelicKeyTransactionRead_paginated(t *testing.T) {
+	pages := map[string]string{
+		"":  `{"key_transactions":[{"id":1,"name":"Login","links":{"application":10}},{"id":2,"name":"Checkout","links":{"application":10}}]}`,
+		"2": `{"key_transactions":[{"id":3,"name":"Search","transaction_name":"WebTransaction/Search","application_summary":{"apdex_target":0.5,"response_time":120.5},"links":{"application":20}},{"id":4,"name":"Checkout","links":{"application":20}}]}`,
+	}
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.URL.Path == "/applications.json" {
+			w.Write([]byte(`{"applications":[{"id":10,"name":"web"},{"id":20,"name":"search"}]}`))
+			return
+		}
+
+		if r.URL.Query().Get("page") == "" {
+			w.Header().Set("Link", fmt.Sprintf(`<%s/key_transactions.json?page=2>; rel="next", <%s/key_transactions.json?page=2>; rel="last"`, server.URL, server.URL))
+		}
+
+		w.Write([]byte(pages[r.URL.Query().Get("page")]))
+	}))
+	defer server.Close()
+
+	client := newrelic.New(newrelic.Config{BaseURL: server.URL})
+	meta := &ProviderConfig{Client: &client}
+
+	d := schema.TestResourceDataRaw(t, dataSourceNewRelicKeyTransaction().Schema, map[string]interface{}{
+		"name": "Search",
+	})
+	if err := dataSourceNewRelicKeyTransactionRead(d, meta); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]interface{}{
+		"transaction_name": "WebTransaction/Search",
+		"application_id":   20,
+		"application_name": "search",
+		"apdex_target":     0.5,
+		"response_time":    120.5,
+	}
+
+	if d.Id() != "3" {
+		t.Fatalf("expected key transaction 3 from the last page, got %s", d.Id())
+	}
+
+	for k, v := range expected {
+		if actual := d.Get(k); actual != v {
+			t.Errorf("expected %s to be %v, got %v", k, v, actual)
+		}
+	}
+
+	// Duplicates on different pages are found too.
+	d = schema.TestResourceDataRaw(t, dataSourceNewRelicKeyTransaction().Schema, map[string]interface{}{
+		"name": "Checkout",
+	})
+	expectedErr := "The name 'Checkout' matches multiple New Relic key transactions: [2 4]"
+	if err := dataSourceNewRelicKeyTransactionRead(d, meta); err == nil || err.Error() != expectedErr {
+		t.Fatalf("expected %q, got %q", expectedErr, err)
+	}
+
+	d = schema.TestResourceDataRaw(t, dataSourceNewRelicKeyTransaction().Schema, map[string]interface{}{
+		"name": "Logout",
+	})
+	expectedErr = "The name 'Logout' does not match any New Relic key transaction."
+	if err := dataSourceNewRelicKeyTransactionRead(d, meta); err == nil || err.Error() != expectedErr {
+		t.Fatalf("expected %q, got %q", expectedErr, err)
+	}
+}
@@ -0,0 +1,110 @@
+package newrelic
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/hashcode"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+func dataSourceNewRelicNrqlQuery() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceNewRelicNrqlQueryRead,
+
+		Schema: map[string]*schema.Schema{
+			"query": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"account_id": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"results": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"value": {
+				Type:     schema.TypeFloat,
+				Computed: true,
+			},
+			"string_value": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceNewRelicNrqlQueryRead(d *schema.ResourceData, meta interface{}) error {
+	providerConfig := meta.(*ProviderConfig)
+	client := providerConfig.Client
+
+	accountID := providerConfig.accountID
+	if v, ok := d.GetOk("account_id"); ok {
+		accountID = v.(int)
+	}
+
+	if accountID == 0 {
+		return fmt.Errorf("newrelic_nrql_query requires account_id or the provider account_id to be set")
+	}
+
+	query := d.Get("query").(string)
+
+	log.Printf("[INFO] Running NRQL query against New Relic account %d", accountID)
+
+	results, err := runNrql(client, accountID, query)
+	if err != nil {
+		if _, ok := err.(*nerdGraphQueryError); ok {
+			return fmt.Errorf("The NRQL query %q is not valid: %s", query, err)
+		}
+
+		return fmt.Errorf("Error running NRQL query %q: %s", query, err)
+	}
+
+	if results == nil {
+		results = []map[string]interface{}{}
+	}
+
+	resultsJSON, err := json.Marshal(results)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%d:%d", accountID, hashcode.String(query)))
+	d.Set("account_id", accountID)
+	d.Set("results", string(resultsJSON))
+
+	value, stringValue := nrqlSingleValue(results)
+	d.Set("value", value)
+	d.Set("string_value", stringValue)
+
+	return nil
+}
+
+// nrqlSingleValue returns the value of a query returning a single row with a
+// single scalar field, e.g. "SELECT count(*) FROM Transaction", as a number,
+// when it is one, and as a string. Other results return zero values.
+func nrqlSingleValue(results []map[string]interface{}) (float64, string) {
+	if len(results) != 1 || len(results[0]) != 1 {
+		return 0, ""
+	}
+
+	for _, v := range results[0] {
+		switch v := v.(type) {
+		case float64:
+			return v, strconv.FormatFloat(v, 'f', -1, 64)
+		case string:
+			return 0, v
+		case bool:
+			return 0, strconv.FormatBool(v)
+		}
+	}
+
+	return 0, ""
+}
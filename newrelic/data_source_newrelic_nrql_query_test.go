@@ -0,0 +1,154 @@
+package newrelic
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	newrelic "github.com/paultyng/go-newrelic/v4/api"
+)
+
+func TestAccNewRelicNrqlQueryDataSource_Basic(t *testing.T) {
+	accountID := testAccAccountID(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNewRelicNrqlQueryDataSourceConfig(accountID, "SELECT count(*) FROM Transaction SINCE 1 day ago"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(
+						"data.newrelic_nrql_query.query", "results"),
+					resource.TestCheckResourceAttrSet(
+						"data.newrelic_nrql_query.query", "string_value"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccNewRelicNrqlQueryDataSource_invalidQuery(t *testing.T) {
+	accountID := testAccAccountID(t)
+
+	expectedErrorMsg, _ := regexp.Compile("is not valid")
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccNewRelicNrqlQueryDataSourceConfig(accountID, "SELECT FROM"),
+				ExpectError: expectedErrorMsg,
+			},
+		},
+	})
+}
+
+func TestDataSourceNewRelicNrqlQueryRead(t *testing.T) {
+	var received struct {
+		Variables struct {
+			AccountID int    `json:"accountId"`
+			Query     string `json:"query"`
+		} `json:"variables"`
+	}
+
+	response := ""
+	status := http.StatusOK
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&received)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		w.Write([]byte(response))
+	}))
+	defer server.Close()
+
+	client := newrelic.New(newrelic.Config{BaseURL: server.URL})
+	meta := &ProviderConfig{Client: &client, accountID: 123}
+
+	read := func(config map[string]interface{}) (*schema.ResourceData, error) {
+		d := schema.TestResourceDataRaw(t, dataSourceNewRelicNrqlQuery().Schema, config)
+		return d, dataSourceNewRelicNrqlQueryRead(d, meta)
+	}
+
+	// A single value is also exposed as value and string_value, and the
+	// provider account_id is used by default.
+	response = `{"data":{"actor":{"account":{"nrql":{"results":[{"count":42}]}}}}}`
+	d, err := read(map[string]interface{}{"query": "SELECT count(*) FROM Transaction"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if received.Variables.AccountID != 123 || received.Variables.Query != "SELECT count(*) FROM Transaction" {
+		t.Fatalf("expected the query to be run against account 123, got %#v", received.Variables)
+	}
+
+	if results := d.Get("results").(string); results != `[{"count":42}]` {
+		t.Fatalf("expected the results as JSON, got %s", results)
+	}
+
+	if value := d.Get("value").(float64); value != 42 {
+		t.Fatalf("expected value 42, got %v", value)
+	}
+
+	if value := d.Get("string_value").(string); value != "42" {
+		t.Fatalf("expected string_value 42, got %q", value)
+	}
+
+	if !strings.HasPrefix(d.Id(), "123:") {
+		t.Fatalf("expected the ID to start with the account ID, got %s", d.Id())
+	}
+
+	// Several rows are only exposed as results.
+	response = `{"data":{"actor":{"account":{"nrql":{"results":[{"appName":"web","count":40},{"appName":"api","count":2}]}}}}}`
+	d, err = read(map[string]interface{}{"query": "SELECT count(*) FROM Transaction FACET appName", "account_id": 456})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if received.Variables.AccountID != 456 {
+		t.Fatalf("expected the query to be run against account 456, got %d", received.Variables.AccountID)
+	}
+
+	if results := d.Get("results").(string); results != `[{"appName":"web","count":40},{"appName":"api","count":2}]` {
+		t.Fatalf("expected the results as JSON, got %s", results)
+	}
+
+	if value, stringValue := d.Get("value").(float64), d.Get("string_value").(string); value != 0 || stringValue != "" {
+		t.Fatalf("expected no single value, got %v and %q", value, stringValue)
+	}
+
+	// Query errors are reported differently from transport errors.
+	response = `{"data":{"actor":{"account":{"nrql":null}}},"errors":[{"message":"NRQL Syntax Error"}]}`
+	expectedErr := `The NRQL query "SELECT FROM" is not valid: error: NerdGraph request failed: NRQL Syntax Error`
+	if _, err := read(map[string]interface{}{"query": "SELECT FROM"}); err == nil || err.Error() != expectedErr {
+		t.Fatalf("expected %q, got %q", expectedErr, err)
+	}
+
+	status = http.StatusForbidden
+	response = `{"errors":[{"message":"Invalid API key"}]}`
+	if _, err := read(map[string]interface{}{"query": "SELECT count(*) FROM Transaction"}); err == nil || !strings.HasPrefix(err.Error(), "Error running NRQL query") {
+		t.Fatalf("expected an error running the query, got %q", err)
+	}
+
+	meta.accountID = 0
+	expectedErr = "newrelic_nrql_query requires account_id or the provider account_id to be set"
+	if _, err := read(map[string]interface{}{"query": "SELECT count(*) FROM Transaction"}); err == nil || err.Error() != expectedErr {
+		t.Fatalf("expected %q, got %q", expectedErr, err)
+	}
+}
+
+func testAccNewRelicNrqlQueryDataSourceConfig(accountID string, query string) string {
+	return fmt.Sprintf(`
+data "newrelic_nrql_query" "query" {
+	account_id = %s
+	query      = "%s"
+}
+`, accountID, query)
+}
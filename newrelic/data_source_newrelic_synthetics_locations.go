@@ -0,0 +1,65 @@
+package newrelic
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataSourceNewRelicSyntheticsLocations() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceNewRelicSyntheticsLocationsRead,
+
+		Schema: map[string]*schema.Schema{
+			"locations": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"label": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"private": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceNewRelicSyntheticsLocationsRead(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[INFO] Reading New Relic Synthetics locations")
+
+	// The locations are cached by the provider, so that monitors validating
+	// their locations in the same run don't list them again.
+	locations, err := meta.(*ProviderConfig).syntheticsLocationList()
+	if err != nil {
+		return err
+	}
+
+	flattened := make([]map[string]interface{}, 0, len(locations))
+	for _, l := range locations {
+		flattened = append(flattened, map[string]interface{}{
+			"name":    l.Name,
+			"label":   l.Label,
+			"private": l.Private,
+		})
+	}
+
+	d.SetId("synthetics-locations")
+
+	if err := d.Set("locations", flattened); err != nil {
+		return fmt.Errorf("[DEBUG] Error setting Synthetics locations: %#v", err)
+	}
+
+	return nil
+}
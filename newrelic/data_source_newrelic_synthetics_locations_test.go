@@ -0,0 +1,79 @@
+package newrelic
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func TestAccNewRelicSyntheticsLocationsDataSource_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "newrelic_synthetics_locations" "all" {}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.newrelic_synthetics_locations.all", "locations.0.name"),
+					resource.TestCheckResourceAttrSet("data.newrelic_synthetics_locations.all", "locations.0.label"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSourceNewRelicSyntheticsLocationsRead(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"name":"AWS_US_WEST_1","label":"San Francisco, CA, USA","private":false},` +
+			`{"name":"AWS_EU_WEST_1","label":"Dublin, IE","private":false},` +
+			`{"name":"MXxTWU5USHxQUklWQVRFX0xPQ0FUSU9OfGFiYw","label":"Datacenter","private":true}]`))
+	}))
+	defer server.Close()
+
+	client, err := (&Config{APIKey: "foo", SyntheticsAPIURL: server.URL + "/synthetics/api"}).ClientSynthetics()
+	if err != nil {
+		t.Fatal(err)
+	}
+	meta := &ProviderConfig{Synthetics: client}
+
+	for i := 0; i < 2; i++ {
+		d := schema.TestResourceDataRaw(t, dataSourceNewRelicSyntheticsLocations().Schema, map[string]interface{}{})
+		if err := dataSourceNewRelicSyntheticsLocationsRead(d, meta); err != nil {
+			t.Fatal(err)
+		}
+
+		// Locations are sorted by name.
+		for k, expected := range map[string]interface{}{
+			"locations.#":         3,
+			"locations.0.name":    "AWS_EU_WEST_1",
+			"locations.0.label":   "Dublin, IE",
+			"locations.0.private": false,
+			"locations.2.label":   "Datacenter",
+			"locations.2.private": true,
+		} {
+			if actual := d.Get(k); actual != expected {
+				t.Errorf("expected %s to be %v, got %v", k, expected, actual)
+			}
+		}
+	}
+
+	if calls != 1 {
+		t.Fatalf("expected the locations to be listed once, got %d calls", calls)
+	}
+
+	// Monitors validating their locations reuse the same list.
+	names, err := meta.syntheticsLocationNames()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(names) != 3 || names[0] != "AWS_EU_WEST_1" || calls != 1 {
+		t.Fatalf("expected the cached location names, got %v after %d calls", names, calls)
+	}
+}
@@ -3,11 +3,16 @@ package newrelic
 import (
 	"fmt"
 	"log"
+	"strings"
 
 	synthetics "github.com/dollarshaveclub/new-relic-synthetics-go"
 	"github.com/hashicorp/terraform/helper/schema"
 )
 
+// syntheticsMonitorsPageSize is the number of monitors requested per page
+// when listing monitors.
+const syntheticsMonitorsPageSize = 100
+
 func dataSourceNewRelicSyntheticsMonitor() *schema.Resource {
 	return &schema.Resource{
 		Read: dataSourceNewRelicSyntheticsMonitorRead,
@@ -21,50 +26,81 @@ func dataSourceNewRelicSyntheticsMonitor() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"frequency": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"uri": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 		},
 	}
 }
 
-func dataSourceNewRelicSyntheticsMonitorRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).Synthetics
+// findSyntheticsMonitorByName returns the only monitor named name, reading
+// every page of the monitors list.
+func findSyntheticsMonitorByName(client *synthetics.Client, name string) (*synthetics.ExtendedMonitor, error) {
+	var matches []*synthetics.ExtendedMonitor
 
-	log.Printf("[INFO] Reading New Relic synthetics monitors")
+	for offset := uint(0); ; {
+		monitors, err := client.GetAllMonitors(offset, syntheticsMonitorsPageSize)
+		if err != nil {
+			return nil, err
+		}
 
-	offset := 0
-	max := 100
-	monitors, err := client.GetAllMonitors(uint(offset), uint(max))
-	var monitor *synthetics.ExtendedMonitor
-	name := d.Get("name").(string)
-	for monitors != nil {
-		if len(monitors.Monitors) > 0 && err == nil {
-			mon := *monitors
-
-			for i := 0; i < len(monitors.Monitors); i++ {
-				if mon.Monitors[i].Name == name {
-					monitor = mon.Monitors[i]
-					break
-				}
+		for _, m := range monitors.Monitors {
+			if m.Name == name {
+				matches = append(matches, m)
 			}
 		}
 
-		offset = offset + 100
-		monitors, err = client.GetAllMonitors(uint(offset), uint(max))
+		offset += uint(len(monitors.Monitors))
+		if len(monitors.Monitors) == 0 || offset >= monitors.Count {
+			break
+		}
+	}
 
-		if len(monitors.Monitors) == 0 {
-			monitors = nil
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("The name '%s' does not match any New Relic monitors.", name)
+	case 1:
+		return matches[0], nil
+	default:
+		ids := make([]string, 0, len(matches))
+		for _, m := range matches {
+			ids = append(ids, m.ID)
 		}
+
+		return nil, fmt.Errorf("The name '%s' matches %d New Relic monitors (%s); monitor names must be unique to be looked up.", name, len(matches), strings.Join(ids, ", "))
 	}
+}
+
+func dataSourceNewRelicSyntheticsMonitorRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ProviderConfig).Synthetics
+
+	log.Printf("[INFO] Reading New Relic synthetics monitors")
+
+	monitor, err := findSyntheticsMonitorByName(client, d.Get("name").(string))
 	if err != nil {
 		return err
 	}
 
-	if monitor == nil {
-		return fmt.Errorf("The name '%s' does not match any New Relic monitors.", name)
-	}
-
 	d.SetId(monitor.ID)
 	d.Set("name", monitor.Name)
 	d.Set("monitor_id", monitor.ID)
+	d.Set("type", monitor.Type)
+	d.Set("status", monitor.Status)
+	d.Set("frequency", int(monitor.Frequency))
+	d.Set("uri", monitor.URI)
 
 	return nil
 }
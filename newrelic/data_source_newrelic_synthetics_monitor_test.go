@@ -2,10 +2,13 @@ package newrelic
 
 import (
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
 )
 
@@ -28,6 +31,54 @@ func TestAccNewRelicSyntheticsMonitorDataSource_Basic(t *testing.T) {
 	})
 }
 
+func TestDataSourceNewRelicSyntheticsMonitorRead_paginated(t *testing.T) {
+	monitor := `{"id":"%s","name":"%s","type":"%s","frequency":15,"uri":"https://example.com","status":"ENABLED",` +
+		`"createdAt":"2019-10-14T22:00:00.000+0000","modifiedAt":"2019-10-14T22:00:00.000+0000"}`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.URL.Query().Get("offset") == "2" {
+			fmt.Fprintf(w, `{"count":3,"monitors":[`+monitor+`]}`, "c", "checkout", "SIMPLE")
+			return
+		}
+
+		fmt.Fprintf(w, `{"count":3,"monitors":[`+monitor+`,`+monitor+`]}`, "a", "duplicate", "BROWSER", "b", "duplicate", "BROWSER")
+	}))
+	defer server.Close()
+
+	client, err := (&Config{APIKey: "foo", SyntheticsAPIURL: server.URL + "/synthetics/api"}).ClientSynthetics()
+	if err != nil {
+		t.Fatal(err)
+	}
+	meta := &ProviderConfig{Synthetics: client}
+
+	d := schema.TestResourceDataRaw(t, dataSourceNewRelicSyntheticsMonitor().Schema, map[string]interface{}{
+		"name": "checkout",
+	})
+	if err := dataSourceNewRelicSyntheticsMonitorRead(d, meta); err != nil {
+		t.Fatal(err)
+	}
+	if d.Id() != "c" || d.Get("type").(string) != "SIMPLE" || d.Get("frequency").(int) != 15 ||
+		d.Get("status").(string) != "ENABLED" || d.Get("uri").(string) != "https://example.com" {
+		t.Fatalf("expected monitor c, got %s: %v", d.Id(), d.State().Attributes)
+	}
+
+	for name, expected := range map[string]string{
+		"missing":   "The name 'missing' does not match any New Relic monitors.",
+		"duplicate": "The name 'duplicate' matches 2 New Relic monitors (a, b); monitor names must be unique to be looked up.",
+	} {
+		d := schema.TestResourceDataRaw(t, dataSourceNewRelicSyntheticsMonitor().Schema, map[string]interface{}{
+			"name": name,
+		})
+
+		err := dataSourceNewRelicSyntheticsMonitorRead(d, meta)
+		if err == nil || err.Error() != expected {
+			t.Fatalf("expected %q, got %q", expected, err)
+		}
+	}
+}
+
 func testAccNewRelicSyntheticsDataSource(n string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		r := s.RootModule().Resources[n]
@@ -40,6 +91,10 @@ func testAccNewRelicSyntheticsDataSource(n string) resource.TestCheckFunc {
 		if a["name"] != expectedMonitorName {
 			return fmt.Errorf("Expected the synthetics monitor name to be: %s, but got: %s", expectedMonitorName, a["name"])
 		}
+
+		if a["type"] != "SIMPLE" || a["frequency"] != "15" || a["uri"] != "https://google.com" {
+			return fmt.Errorf("Expected the synthetics monitor attributes to be read, but got: %v", a)
+		}
 		return nil
 	}
 }
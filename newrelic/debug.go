@@ -0,0 +1,163 @@
+package newrelic
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const redacted = "[REDACTED]"
+
+// redactedHeaders are the headers that carry API keys.
+var redactedHeaders = []string{"Api-Key", "Authorization", "X-Api-Key", "X-Query-Key"}
+
+// redactedFields are the JSON fields whose values are redacted from debug
+// logs. They cover every Sensitive schema field, either by its name or by the
+// name of the block it's nested in, e.g. the fields of an alert channel's
+// config are sent in its configuration.
+var redactedFields = map[string]bool{
+	"api_key":       true,
+	"apiKey":        true,
+	"auth_password": true,
+	"config":        true,
+	"configuration": true,
+	"password":      true,
+	"service_key":   true,
+	"token":         true,
+	"value":         true,
+}
+
+// debugTransport is an http.RoundTripper that logs the method, URL and body
+// of each request and the status and body of its response, with API keys and
+// sensitive fields redacted.
+type debugTransport struct {
+	transport http.RoundTripper
+}
+
+func newDebugTransport(transport http.RoundTripper) *debugTransport {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	return &debugTransport{transport: transport}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *debugTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("[DEBUG] New Relic API request: %s %s\nHeaders: %s\nBody: %s",
+		req.Method, req.URL, redactHeaders(req.Header), redactBody(body))
+
+	resp, err := t.transport.RoundTrip(req)
+	if err != nil {
+		log.Printf("[DEBUG] New Relic API request %s %s failed: %s", req.Method, req.URL, err)
+		return resp, err
+	}
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(respBody))
+
+	log.Printf("[DEBUG] New Relic API response: %s %s\nStatus: %s\nBody: %s",
+		req.Method, req.URL, resp.Status, redactBody(respBody))
+
+	return resp, nil
+}
+
+// readRequestBody returns the body of req, leaving it to be read again by the
+// transport.
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+
+	var r io.ReadCloser = req.Body
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		r = body
+	}
+	defer r.Close()
+
+	body, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.GetBody == nil {
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+
+	return body, nil
+}
+
+func redactHeaders(header http.Header) string {
+	redactedHeader := make(http.Header, len(header))
+	for k, v := range header {
+		redactedHeader[k] = v
+	}
+
+	for _, k := range redactedHeaders {
+		if redactedHeader.Get(k) != "" {
+			redactedHeader.Set(k, redacted)
+		}
+	}
+
+	var b strings.Builder
+	redactedHeader.Write(&b)
+
+	return strings.TrimSpace(b.String())
+}
+
+// redactBody returns a JSON body with the values of redactedFields replaced.
+// Bodies that aren't JSON, e.g. gzipped ones, are only described.
+func redactBody(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		return "(" + http.DetectContentType(body) + ", " + strconv.Itoa(len(body)) + " bytes)"
+	}
+
+	data, err := json.Marshal(redactValue(v))
+	if err != nil {
+		return ""
+	}
+
+	return string(data)
+}
+
+func redactValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, field := range v {
+			if redactedFields[k] && field != nil {
+				v[k] = redacted
+			} else {
+				v[k] = redactValue(field)
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactValue(item)
+		}
+	}
+
+	return v
+}
@@ -0,0 +1,98 @@
+package newrelic
+
+import (
+	"bytes"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func TestDebugTransport_RedactsSensitiveValues(t *testing.T) {
+	var received string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		received = string(body)
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":{"title":"Invalid token"},"auth":{"token":"response-secret"}}`))
+	}))
+	defer server.Close()
+
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	defer log.SetOutput(os.Stderr)
+
+	body := `{"channel":{"name":"foo","configuration":{"api_key":"config-secret"}},"credentials":[{"key":"bar","value":"credential-secret"}]}`
+
+	req, _ := http.NewRequest("POST", server.URL+"/alerts_channels.json", strings.NewReader(body))
+	req.Header.Set("X-Api-Key", "api-key-secret")
+
+	client := &http.Client{Transport: newDebugTransport(nil)}
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if received != body {
+		t.Fatalf("expected the request body to be sent unchanged, got %s", received)
+	}
+
+	respBody, _ := ioutil.ReadAll(resp.Body)
+	if !strings.Contains(string(respBody), "response-secret") {
+		t.Fatalf("expected the response body to be returned unchanged, got %s", respBody)
+	}
+
+	output := logs.String()
+
+	if strings.Contains(output, "secret") {
+		t.Fatalf("expected sensitive values to be redacted, got %s", output)
+	}
+
+	for _, s := range []string{"POST " + server.URL + "/alerts_channels.json", `"name":"foo"`, `"key":"bar"`, "400 Bad Request", "Invalid token"} {
+		if !strings.Contains(output, s) {
+			t.Errorf("expected the logs to contain %q, got %s", s, output)
+		}
+	}
+}
+
+func TestRedactBody_notJSON(t *testing.T) {
+	if body := redactBody([]byte("password=secret")); strings.Contains(body, "secret") {
+		t.Fatalf("expected a body that isn't JSON not to be logged, got %s", body)
+	}
+}
+
+// TestRedactedFields_coverSensitiveSchema makes sure that every Sensitive
+// schema field is redacted from debug logs.
+func TestRedactedFields_coverSensitiveSchema(t *testing.T) {
+	p := Provider().(*schema.Provider)
+
+	checkRedactedFields(t, "provider", p.Schema, false)
+
+	for name, r := range p.ResourcesMap {
+		checkRedactedFields(t, name, r.Schema, false)
+	}
+
+	for name, r := range p.DataSourcesMap {
+		checkRedactedFields(t, name, r.Schema, false)
+	}
+}
+
+func checkRedactedFields(t *testing.T, path string, s map[string]*schema.Schema, parentRedacted bool) {
+	for k, v := range s {
+		isRedacted := parentRedacted || redactedFields[k]
+
+		if v.Sensitive && !isRedacted {
+			t.Errorf("expected the sensitive field %s.%s to be redacted from debug logs", path, k)
+		}
+
+		if r, ok := v.Elem.(*schema.Resource); ok {
+			checkRedactedFields(t, path+"."+k, r.Schema, isRedacted)
+		}
+	}
+}
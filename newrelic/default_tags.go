@@ -0,0 +1,142 @@
+package newrelic
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	newrelic "github.com/paultyng/go-newrelic/v4/api"
+)
+
+// entityTagsSchema is the tags attribute of resources that are New Relic One
+// entities, whose tags are set with the tagging API.
+func entityTagsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeMap,
+		Optional: true,
+		Elem:     &schema.Schema{Type: schema.TypeString},
+	}
+}
+
+// entityTagsAllSchema is the tags of a resource merged with the provider
+// default_tags. Planning it means a change to default_tags shows up as a diff
+// of every resource it applies to.
+func entityTagsAllSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeMap,
+		Computed: true,
+		Elem:     &schema.Schema{Type: schema.TypeString},
+	}
+}
+
+// withDefaultTags returns the provider default_tags merged with tags. The
+// resource's tags win on key collisions.
+func (p *ProviderConfig) withDefaultTags(tags map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(p.defaultTags)+len(tags))
+	for k, v := range p.defaultTags {
+		merged[k] = v
+	}
+
+	for k, v := range tags {
+		merged[k] = v
+	}
+
+	return merged
+}
+
+// customizeDiffEntityTags plans tags_all from tags and the provider
+// default_tags.
+func customizeDiffEntityTags(d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("tags") {
+		return d.SetNewComputed("tags_all")
+	}
+
+	merged := meta.(*ProviderConfig).withDefaultTags(d.Get("tags").(map[string]interface{}))
+
+	if o, _ := d.GetChange("tags_all"); reflect.DeepEqual(o, merged) {
+		return nil
+	}
+
+	return d.SetNew("tags_all", merged)
+}
+
+// expandEntityTagMap returns tags as entity tags with a single value each,
+// sorted by key.
+func expandEntityTagMap(tags map[string]interface{}) []entityTag {
+	expanded := make([]entityTag, 0, len(tags))
+	for k, v := range tags {
+		expanded = append(expanded, entityTag{Key: k, Values: []string{v.(string)}})
+	}
+
+	sort.Slice(expanded, func(i, j int) bool { return expanded[i].Key < expanded[j].Key })
+
+	return expanded
+}
+
+// applyEntityTags sets the tags in tags_all on the entity with guid, and
+// removes the keys dropped from tags_all since they were last applied. Other
+// tags on the entity are left alone. New entities are waited for until they're
+// indexed or ctx is done.
+func applyEntityTags(ctx context.Context, client *newrelic.Client, guid string, d *schema.ResourceData) error {
+	o, n := d.GetChange("tags_all")
+	tags := n.(map[string]interface{})
+
+	var removedKeys []string
+	for k := range o.(map[string]interface{}) {
+		if _, ok := tags[k]; !ok {
+			removedKeys = append(removedKeys, k)
+		}
+	}
+	sort.Strings(removedKeys)
+
+	if len(tags) == 0 && len(removedKeys) == 0 {
+		return nil
+	}
+
+	// New entities can't be tagged until they're indexed.
+	if d.IsNewResource() {
+		err := waitForCreated(ctx, d.Timeout(schema.TimeoutCreate), func() error {
+			_, err := getEntity(client, guid)
+			if err == errEntityNotFound {
+				return newrelic.ErrNotFound
+			}
+
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("Error waiting for entity %s to be indexed: %s", guid, err)
+		}
+	}
+
+	return setManagedEntityTags(client, guid, expandEntityTagMap(tags), removedKeys)
+}
+
+// readEntityTags refreshes tags_all from the entity with guid. Only the keys
+// already in tags_all are read, so tags added by other systems don't show up
+// as a diff.
+func readEntityTags(client *newrelic.Client, guid string, d *schema.ResourceData) error {
+	managed := d.Get("tags_all").(map[string]interface{})
+	if len(managed) == 0 {
+		return nil
+	}
+
+	log.Printf("[INFO] Reading New Relic entity tags %s", guid)
+
+	tags, err := getEntityTags(client, guid)
+	if err != nil {
+		return err
+	}
+
+	current := make(map[string]interface{}, len(managed))
+	for _, t := range tags {
+		if _, ok := managed[t.Key]; ok {
+			current[t.Key] = strings.Join(t.Values, ",")
+		}
+	}
+
+	return d.Set("tags_all", current)
+}
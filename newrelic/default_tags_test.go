@@ -0,0 +1,169 @@
+package newrelic
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/config"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestProviderConfigWithDefaultTags(t *testing.T) {
+	p := &ProviderConfig{defaultTags: map[string]interface{}{"team": "platform", "managed-by": "terraform"}}
+
+	merged := p.withDefaultTags(map[string]interface{}{"team": "payments", "tier": "1"})
+	expected := map[string]interface{}{"team": "payments", "managed-by": "terraform", "tier": "1"}
+
+	if !reflect.DeepEqual(merged, expected) {
+		t.Fatalf("expected the resource's tags to win over the default tags, got %v", merged)
+	}
+
+	if tags := (&ProviderConfig{}).withDefaultTags(nil); len(tags) != 0 {
+		t.Fatalf("expected no tags, got %v", tags)
+	}
+}
+
+func TestCustomizeDiffEntityTags(t *testing.T) {
+	r := resourceNewRelicWorkload()
+
+	rc, err := config.NewRawConfig(map[string]interface{}{
+		"account_id":   12345,
+		"name":         "foo",
+		"entity_guids": []interface{}{"MTIzNDV8QVBNfEFQUExJQ0FUSU9OfDQ1Ng"},
+		"tags":         map[string]interface{}{"team": "payments"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	meta := &ProviderConfig{defaultTags: map[string]interface{}{"team": "platform", "managed-by": "terraform"}}
+
+	diff, err := r.Diff(nil, terraform.NewResourceConfig(rc), meta)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for k, v := range map[string]string{"tags_all.team": "payments", "tags_all.managed-by": "terraform"} {
+		if attr := diff.Attributes[k]; attr == nil || attr.New != v {
+			t.Errorf("expected %s to be planned as %q, got %#v", k, v, attr)
+		}
+	}
+}
+
+func TestApplyEntityTags(t *testing.T) {
+	guid := "MTIzNDV8TlIxfFdPUktMT0FEfDEyMw"
+
+	var deleted []interface{}
+	var added []interface{}
+
+	meta, closeServer := testNotificationsProviderConfig(t, func(query string, variables map[string]interface{}) string {
+		switch {
+		case strings.Contains(query, "taggingDeleteTagFromEntity"):
+			deleted = variables["tagKeys"].([]interface{})
+		case strings.Contains(query, "taggingAddTagsToEntity"):
+			added = variables["tags"].([]interface{})
+		case strings.Contains(query, "tagsWithMetadata"):
+			return `{"data":{"actor":{"entity":{"tagsWithMetadata":[` +
+				`{"key":"account","values":[{"value":"foo","mutable":false}]},` +
+				`{"key":"team","values":[{"value":"payments","mutable":true}]},` +
+				`{"key":"owner","values":[{"value":"someone","mutable":true}]}]}}}}`
+		case strings.Contains(query, "workloadUpdate"):
+			return `{"data":{"workloadUpdate":{"guid":"` + guid + `"}}}`
+		default:
+			return `{"data":{"actor":{"account":{"workload":{"collection":{"guid":"` + guid + `","id":123,"name":"foo",` +
+				`"account":{"id":12345},"entities":[],"entitySearchQueries":[{"query":"type = 'HOST'"}]}}}}}}`
+		}
+
+		return `{"data":{}}`
+	})
+	defer closeServer()
+
+	r := resourceNewRelicWorkload()
+	state := &terraform.InstanceState{
+		ID: guid,
+		Attributes: map[string]string{
+			"id":                          guid,
+			"account_id":                  "12345",
+			"name":                        "foo",
+			"entity_search_query.#":       "1",
+			"entity_search_query.0.query": "type = 'HOST'",
+			"tags_all.%":                  "2",
+			"tags_all.team":               "platform",
+			"tags_all.removed":            "yes",
+		},
+	}
+
+	rc, err := config.NewRawConfig(map[string]interface{}{
+		"account_id":          12345,
+		"name":                "foo",
+		"entity_search_query": []interface{}{map[string]interface{}{"query": "type = 'HOST'"}},
+		"tags":                map[string]interface{}{"team": "payments"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	diff, err := r.Diff(state, terraform.NewResourceConfig(rc), meta)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	state, err = r.Apply(state, diff, meta)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Only the managed keys are cleared, including the one no longer set.
+	if expected := []interface{}{"team", "removed"}; !reflect.DeepEqual(deleted, expected) {
+		t.Fatalf("expected tags %v to be deleted, got %v", expected, deleted)
+	}
+
+	expected := []interface{}{map[string]interface{}{"key": "team", "values": []interface{}{"payments"}}}
+	if !reflect.DeepEqual(added, expected) {
+		t.Fatalf("expected tags %v to be added, got %v", expected, added)
+	}
+
+	// Tags set outside Terraform aren't read into tags_all.
+	if state.Attributes["tags_all.%"] != "1" || state.Attributes["tags_all.team"] != "payments" {
+		t.Fatalf("expected only the managed tags to be read, got %v", state.Attributes)
+	}
+}
+
+func TestValidateSyntheticsMonitorTags(t *testing.T) {
+	r := resourceNewRelicSyntheticsMonitor()
+
+	diff := func(meta *ProviderConfig) (*terraform.InstanceDiff, error) {
+		rc, err := config.NewRawConfig(map[string]interface{}{
+			"name":      "foo",
+			"type":      "SCRIPT_API",
+			"frequency": 5,
+			"status":    "ENABLED",
+			"locations": []interface{}{"MXxTWU5USHxQUklWQVRFX0xPQ0FUSU9OfGFiYw"},
+			"tags":      map[string]interface{}{"team": "payments"},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return r.Diff(nil, terraform.NewResourceConfig(rc), meta)
+	}
+
+	expected := "tags on Synthetics monitors require the provider account_id"
+	if _, err := diff(&ProviderConfig{}); err == nil || err.Error() != expected {
+		t.Fatalf("expected %q, got %q", expected, err)
+	}
+
+	d, err := diff(&ProviderConfig{accountID: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if attr := d.Attributes["tags_all.team"]; attr == nil || attr.New != "payments" {
+		t.Fatalf("expected tags_all.team to be planned, got %#v", attr)
+	}
+
+	if guid := (&ProviderConfig{accountID: 1}).syntheticsMonitorGUID("abc"); guid != "MXxTWU5USHxNT05JVE9SfGFiYw" {
+		t.Fatalf("expected the monitor's entity GUID, got %s", guid)
+	}
+}
@@ -4,6 +4,9 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	newrelic "github.com/paultyng/go-newrelic/v4/api"
 )
 
 func parseIDs(serializedID string, count int) ([]int, error) {
@@ -35,3 +38,116 @@ func serializeIDs(ids []int) string {
 
 	return strings.Join(idStrings, ":")
 }
+
+// parseAccountScopedID parses an "<account_id>:<id>" ID whose second part
+// isn't numeric, e.g. the UUID of a notification destination.
+func parseAccountScopedID(serializedID string) (int, string, error) {
+	rawIDs := strings.SplitN(serializedID, ":", 2)
+	if len(rawIDs) != 2 || rawIDs[1] == "" {
+		return 0, "", fmt.Errorf("Unable to parse ID %v", serializedID)
+	}
+
+	accountID, err := strconv.Atoi(rawIDs[0])
+	if err != nil {
+		return 0, "", fmt.Errorf("Unable to parse ID %v: %s", serializedID, err)
+	}
+
+	return accountID, rawIDs[1], nil
+}
+
+// onlyEnabledChanged reports whether enabled is the only argument of a
+// condition with a planned change, so that disabling a condition, e.g. during
+// an incident, can send the condition as it is in New Relic with only enabled
+// changed.
+func onlyEnabledChanged(d *schema.ResourceData, s map[string]*schema.Schema) bool {
+	if !d.HasChange("enabled") {
+		return false
+	}
+
+	for k := range s {
+		if k != "enabled" && d.HasChange(k) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// alertPolicyNotFoundError rewrites err, returned when creating or updating an
+// alert condition, if the condition's policy no longer exists. The API only
+// answers with an opaque 404 in that case, so other errors are returned
+// without looking the policy up.
+func alertPolicyNotFoundError(client *newrelic.Client, policyID int, err error) error {
+	if err != newrelic.ErrNotFound {
+		return err
+	}
+
+	if _, policyErr := client.GetAlertPolicy(policyID); policyErr == newrelic.ErrNotFound {
+		return fmt.Errorf("alert policy %d not found; it may have been deleted outside Terraform", policyID)
+	}
+
+	return err
+}
+
+// alertConditionPolicyIDSchema and alertConditionPolicyNameSchema return the
+// schemas for the policy of an alert condition, given either by ID or by name.
+// Both force a new condition: conditions are created in a policy and the API
+// ignores the policy_id of updates, so there's no way to move one.
+func alertConditionPolicyIDSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:          schema.TypeInt,
+		Optional:      true,
+		Computed:      true,
+		ForceNew:      true,
+		ConflictsWith: []string{"policy_name"},
+	}
+}
+
+func alertConditionPolicyNameSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:          schema.TypeString,
+		Optional:      true,
+		ForceNew:      true,
+		ConflictsWith: []string{"policy_id"},
+	}
+}
+
+// resolveAlertConditionPolicy sets policy_id to the ID of the policy named by
+// policy_name, when it's set, so that a condition can be created in a policy
+// that's looked up when it's applied rather than referenced by ID.
+//
+// Requiring one of the two is left to apply time, since an unset policy_id
+// can't be told apart from one referencing a policy that isn't created yet
+// when the plan is made.
+func resolveAlertConditionPolicy(client *newrelic.Client, d *schema.ResourceData) error {
+	name := d.Get("policy_name").(string)
+	if name == "" {
+		if d.Get("policy_id").(int) == 0 {
+			return fmt.Errorf("one of policy_id or policy_name must be set")
+		}
+
+		return nil
+	}
+
+	policies, err := client.ListAlertPolicies()
+	if err != nil {
+		return err
+	}
+
+	var found []newrelic.AlertPolicy
+	for _, policy := range policies {
+		if policy.Name == name {
+			found = append(found, policy)
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return fmt.Errorf("alert policy %q not found", name)
+	case 1:
+		d.Set("policy_id", found[0].ID)
+		return nil
+	default:
+		return fmt.Errorf("%d alert policies are named %q; use policy_id to choose one", len(found), name)
+	}
+}
@@ -1,6 +1,18 @@
 package newrelic
 
-import "testing"
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/config"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+	newrelic "github.com/paultyng/go-newrelic/v4/api"
+)
 
 func TestParseIDs_Basic(t *testing.T) {
 	ids, err := parseIDs("1:2", 2)
@@ -24,3 +36,248 @@ func TestSerializeIDs_Basic(t *testing.T) {
 		t.Fatal(id)
 	}
 }
+
+func TestParseAccountScopedID(t *testing.T) {
+	accountID, id, err := parseAccountScopedID("1:a6e2f1b4-7c1d-4d0e-9f3a-2b8c5d6e7f80")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if accountID != 1 || id != "a6e2f1b4-7c1d-4d0e-9f3a-2b8c5d6e7f80" {
+		t.Fatal(accountID, id)
+	}
+
+	for _, invalid := range []string{"", "1", "1:", "foo:bar"} {
+		if _, _, err := parseAccountScopedID(invalid); err == nil {
+			t.Fatalf("expected an error for %q", invalid)
+		}
+	}
+}
+
+func TestAlertPolicyNotFoundError(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"policies":[{"id":1,"name":"foo"}]}`))
+	}))
+	defer server.Close()
+
+	client := newrelic.New(newrelic.Config{BaseURL: server.URL})
+
+	if err := alertPolicyNotFoundError(&client, 1, newrelic.ErrNotFound); err != newrelic.ErrNotFound {
+		t.Fatalf("expected the original error for an existing policy, got %q", err)
+	}
+
+	err := alertPolicyNotFoundError(&client, 2, newrelic.ErrNotFound)
+	expected := "alert policy 2 not found; it may have been deleted outside Terraform"
+	if err == nil || err.Error() != expected {
+		t.Fatalf("expected %q, got %q", expected, err)
+	}
+
+	// Other errors, e.g. a rejected API key, don't look the policy up.
+	requests = 0
+	unauthorizedErr := errors.New("New Relic rejected the provider API key (401 Unauthorized)")
+	if err := alertPolicyNotFoundError(&client, 2, unauthorizedErr); err != unauthorizedErr {
+		t.Fatalf("expected the original error, got %q", err)
+	}
+
+	if requests != 0 {
+		t.Fatalf("expected no policy lookup, got %d requests", requests)
+	}
+}
+
+func TestResolveAlertConditionPolicy(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"policies":[{"id":1,"name":"foo"},{"id":2,"name":"foo bar"},{"id":3,"name":"bar"},{"id":4,"name":"bar"}]}`))
+	}))
+	defer server.Close()
+
+	client := newrelic.New(newrelic.Config{BaseURL: server.URL})
+	r := resourceNewRelicSyntheticsAlertCondition()
+
+	resolve := func(raw map[string]interface{}) (*schema.ResourceData, error) {
+		d := schema.TestResourceDataRaw(t, r.Schema, raw)
+		return d, resolveAlertConditionPolicy(&client, d)
+	}
+
+	// Names are matched exactly.
+	d, err := resolve(map[string]interface{}{"policy_name": "foo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if policyID := d.Get("policy_id").(int); policyID != 1 {
+		t.Fatalf("expected policy 1, got %d", policyID)
+	}
+
+	// A policy_id is used as it is.
+	d, err = resolve(map[string]interface{}{"policy_id": 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if policyID := d.Get("policy_id").(int); policyID != 5 {
+		t.Fatalf("expected policy 5, got %d", policyID)
+	}
+
+	expected := `alert policy "baz" not found`
+	if _, err := resolve(map[string]interface{}{"policy_name": "baz"}); err == nil || err.Error() != expected {
+		t.Fatalf("expected %q, got %q", expected, err)
+	}
+
+	expected = `2 alert policies are named "bar"; use policy_id to choose one`
+	if _, err := resolve(map[string]interface{}{"policy_name": "bar"}); err == nil || err.Error() != expected {
+		t.Fatalf("expected %q, got %q", expected, err)
+	}
+
+	expected = "one of policy_id or policy_name must be set"
+	if _, err := resolve(map[string]interface{}{}); err == nil || err.Error() != expected {
+		t.Fatalf("expected %q, got %q", expected, err)
+	}
+}
+
+// testNotFoundProviderConfig returns a ProviderConfig whose clients get 404
+// Not Found for every request, as if every resource had been deleted outside
+// Terraform.
+func testNotFoundProviderConfig(t *testing.T) (*ProviderConfig, func()) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error":{"title":"Resource not found"}}`))
+	}))
+
+	config := &Config{APIKey: "foo", APIURL: server.URL, SyntheticsAPIURL: server.URL + "/synthetics/api"}
+
+	client, err := config.Client()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	infraClient, err := config.ClientInfra()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	syntheticsClient, err := config.ClientSynthetics()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &ProviderConfig{
+		Client:      client,
+		InfraClient: infraClient,
+		Synthetics:  syntheticsClient,
+	}, server.Close
+}
+
+// testResourceReadNotFound checks that reading the resource with the given ID
+// removes it from state when the API returns 404 Not Found.
+func testResourceReadNotFound(t *testing.T, r *schema.Resource, id string) {
+	meta, closeServer := testNotFoundProviderConfig(t)
+	defer closeServer()
+
+	d := r.TestResourceData()
+	d.SetId(id)
+
+	if err := r.Read(d, meta); err != nil {
+		t.Fatalf("expected no error reading a deleted resource, got %s", err)
+	}
+
+	if d.Id() != "" {
+		t.Fatalf("expected the resource to be removed from state, got ID %q", d.Id())
+	}
+}
+
+// testResourceAttributesForceNew checks that changing any of the attributes
+// plans a new resource, for attributes the API can't update in place.
+func testResourceAttributesForceNew(t *testing.T, r *schema.Resource, attributes ...string) {
+	for _, k := range attributes {
+		if !r.Schema[k].ForceNew {
+			t.Errorf("expected changing %s to force a new resource", k)
+		}
+	}
+}
+
+// testConditionEnabledToggle reads condition, as returned by the API in a list
+// under listKey, applies raw with only enabled flipped, and checks that the
+// condition is sent back under itemKey unchanged apart from enabled and the
+// readOnly fields, and that no other attribute drifts.
+func testConditionEnabledToggle(t *testing.T, r *schema.Resource, listKey string, itemKey string, condition map[string]interface{}, raw map[string]interface{}, readOnly ...string) {
+	var sent map[string]interface{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if req.Method == "PUT" {
+			body := map[string]map[string]interface{}{}
+			json.NewDecoder(req.Body).Decode(&body)
+			sent = body[itemKey]
+
+			for k, v := range sent {
+				condition[k] = v
+			}
+
+			json.NewEncoder(w).Encode(map[string]interface{}{itemKey: condition})
+			return
+		}
+
+		json.NewEncoder(w).Encode(map[string]interface{}{listKey: []interface{}{condition}})
+	}))
+	defer server.Close()
+
+	client := newrelic.New(newrelic.Config{BaseURL: server.URL})
+	infraClient := newrelic.NewInfraClient(newrelic.Config{BaseURL: server.URL})
+	meta := &ProviderConfig{Client: &client, InfraClient: &infraClient}
+
+	d := r.TestResourceData()
+	d.SetId("123:456")
+
+	if err := r.Read(d, meta); err != nil {
+		t.Fatal(err)
+	}
+	state := d.State()
+
+	expected := make(map[string]interface{}, len(condition))
+	for k, v := range condition {
+		expected[k] = v
+	}
+	expected["enabled"] = !condition["enabled"].(bool)
+	for _, k := range readOnly {
+		delete(expected, k)
+	}
+
+	rc, err := config.NewRawConfig(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	diff, err := r.Diff(state, terraform.NewResourceConfig(rc), meta)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff == nil || len(diff.Attributes) != 1 || diff.Attributes["enabled"] == nil {
+		t.Fatalf("expected only enabled to change, got %#v", diff)
+	}
+
+	newState, err := r.Apply(state, diff, meta)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(sent, expected) {
+		t.Fatalf("expected the condition to be sent as %#v, got %#v", expected, sent)
+	}
+
+	for k, v := range state.Attributes {
+		if k != "enabled" && newState.Attributes[k] != v {
+			t.Errorf("expected %s to stay %q, got %q", k, v, newState.Attributes[k])
+		}
+	}
+
+	if newState.Attributes["enabled"] == state.Attributes["enabled"] {
+		t.Fatalf("expected enabled to change from %s", state.Attributes["enabled"])
+	}
+}
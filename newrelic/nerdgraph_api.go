@@ -0,0 +1,1186 @@
+package newrelic
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	newrelic "github.com/paultyng/go-newrelic/v4/api"
+)
+
+// Accounts, entities, entity tags, alert muting rules, notification
+// destinations and channels, APM expected errors, New Relic One dashboards,
+// workloads and NRQL queries are only available through NerdGraph, New Relic's
+// GraphQL API, so it is called here using the REST client's API key and HTTP
+// client.
+
+const nerdGraphURL = "https://api.newrelic.com/graphql"
+
+// nerdGraphURLFor returns the NerdGraph URL on the host of the client's REST
+// API, so that e.g. EU accounts use the EU NerdGraph endpoint.
+func nerdGraphURLFor(client *newrelic.Client) string {
+	u, err := url.Parse(client.RestyClient.HostURL)
+	if err != nil || u.Host == "" {
+		return nerdGraphURL
+	}
+
+	return u.Scheme + "://" + u.Host + "/graphql"
+}
+
+var (
+	// errEntityNotFound is returned when no entity exists for a GUID.
+	errEntityNotFound = errors.New("error: entity not found")
+
+	// errMutingRuleNotFound is returned when no alert muting rule exists
+	// for an ID.
+	errMutingRuleNotFound = errors.New("error: alert muting rule not found")
+
+	// errNotificationDestinationNotFound is returned when no notification
+	// destination exists for an ID.
+	errNotificationDestinationNotFound = errors.New("error: notification destination not found")
+
+	// errNotificationChannelNotFound is returned when no notification
+	// channel exists for an ID.
+	errNotificationChannelNotFound = errors.New("error: notification channel not found")
+)
+
+// account is a New Relic account the API key has access to.
+type account struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// entity represents a New Relic entity returned by NerdGraph.
+type entity struct {
+	GUID      string `json:"guid"`
+	Name      string `json:"name"`
+	Type      string `json:"type"`
+	Domain    string `json:"domain"`
+	AccountID int    `json:"accountId"`
+}
+
+// entityTag is a tag key and its values.
+type entityTag struct {
+	Key    string   `json:"key"`
+	Values []string `json:"values"`
+}
+
+// mutingRule represents a New Relic alert muting rule.
+type mutingRule struct {
+	ID          string              `json:"id,omitempty"`
+	Name        string              `json:"name"`
+	Description string              `json:"description"`
+	Enabled     bool                `json:"enabled"`
+	Condition   mutingRuleCondition `json:"condition"`
+	Schedule    *mutingRuleSchedule `json:"schedule"`
+}
+
+// mutingRuleCondition is the group of conditions incidents must match to be
+// muted.
+type mutingRuleCondition struct {
+	Operator   string                     `json:"operator"`
+	Conditions []mutingRuleAttributeMatch `json:"conditions"`
+}
+
+type mutingRuleAttributeMatch struct {
+	Attribute string   `json:"attribute"`
+	Operator  string   `json:"operator"`
+	Values    []string `json:"values"`
+}
+
+// mutingRuleSchedule limits a muting rule to a time window. Times are local
+// to TimeZone and have no offset, e.g. "2019-10-14T22:00:00".
+type mutingRuleSchedule struct {
+	StartTime string `json:"startTime,omitempty"`
+	EndTime   string `json:"endTime,omitempty"`
+	TimeZone  string `json:"timeZone"`
+	Repeat    string `json:"repeat,omitempty"`
+}
+
+// notificationProperty is a key and value setting of a notification
+// destination or channel, e.g. the url of a webhook destination.
+type notificationProperty struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// notificationDestination is where workflow notifications are sent, e.g. a
+// webhook URL or email addresses. Secrets of its auth aren't returned.
+type notificationDestination struct {
+	ID         string                       `json:"id"`
+	Name       string                       `json:"name"`
+	Type       string                       `json:"type"`
+	Active     bool                         `json:"active"`
+	Properties []notificationProperty       `json:"properties"`
+	Auth       *notificationDestinationAuth `json:"auth"`
+}
+
+type notificationDestinationAuth struct {
+	AuthType string `json:"authType"`
+	User     string `json:"user"`
+	Prefix   string `json:"prefix"`
+}
+
+// notificationDestinationInput creates or updates a notification
+// destination. Type can only be set on creation.
+type notificationDestinationInput struct {
+	Name       string                 `json:"name"`
+	Type       string                 `json:"type,omitempty"`
+	Active     *bool                  `json:"active,omitempty"`
+	Properties []notificationProperty `json:"properties"`
+	Auth       *notificationAuthInput `json:"auth,omitempty"`
+}
+
+type notificationAuthInput struct {
+	Type  string                      `json:"type"`
+	Basic *notificationBasicAuthInput `json:"basic,omitempty"`
+	Token *notificationTokenAuthInput `json:"token,omitempty"`
+}
+
+type notificationBasicAuthInput struct {
+	User     string `json:"user"`
+	Password string `json:"password"`
+}
+
+type notificationTokenAuthInput struct {
+	Prefix string `json:"prefix,omitempty"`
+	Token  string `json:"token"`
+}
+
+// notificationChannel formats the notifications of a product, e.g. workflows
+// (IINT), for a destination.
+type notificationChannel struct {
+	ID            string                 `json:"id"`
+	Name          string                 `json:"name"`
+	Type          string                 `json:"type"`
+	Product       string                 `json:"product"`
+	DestinationID string                 `json:"destinationId"`
+	Active        bool                   `json:"active"`
+	Properties    []notificationProperty `json:"properties"`
+}
+
+// notificationChannelInput creates or updates a notification channel. Type,
+// product and destination can only be set on creation.
+type notificationChannelInput struct {
+	Name          string                 `json:"name"`
+	Type          string                 `json:"type,omitempty"`
+	Product       string                 `json:"product,omitempty"`
+	DestinationID string                 `json:"destinationId,omitempty"`
+	Active        *bool                  `json:"active,omitempty"`
+	Properties    []notificationProperty `json:"properties"`
+}
+
+// notificationError is an error of a notification mutation, which is
+// returned in the response data rather than as a GraphQL error.
+type notificationError struct {
+	Description string `json:"description"`
+	Details     string `json:"details"`
+}
+
+// applicationErrorCollector holds the errors of an APM application that are
+// expected, so they don't count towards its error rate or trigger alerts.
+// Error codes are single HTTP status codes or ranges, e.g. "404" or
+// "500-599".
+type applicationErrorCollector struct {
+	ExpectedErrorClasses []string `json:"expectedErrorClasses"`
+	ExpectedErrorCodes   []string `json:"expectedErrorCodes"`
+}
+
+// oneDashboard is a New Relic One dashboard, an entity made of one or more
+// pages of widgets.
+type oneDashboard struct {
+	GUID        string             `json:"guid"`
+	AccountID   int                `json:"accountId"`
+	Name        string             `json:"name"`
+	Description string             `json:"description"`
+	Permissions string             `json:"permissions"`
+	Permalink   string             `json:"permalink"`
+	Pages       []oneDashboardPage `json:"pages"`
+}
+
+// oneDashboardInput creates or updates a New Relic One dashboard. Its pages
+// replace the existing pages; a page with a GUID updates that page.
+type oneDashboardInput struct {
+	Name        string             `json:"name"`
+	Description string             `json:"description"`
+	Permissions string             `json:"permissions"`
+	Pages       []oneDashboardPage `json:"pages"`
+}
+
+type oneDashboardPage struct {
+	GUID        string               `json:"guid,omitempty"`
+	Name        string               `json:"name"`
+	Description string               `json:"description"`
+	Widgets     []oneDashboardWidget `json:"widgets"`
+}
+
+// oneDashboardWidget is a widget of a dashboard page. Its visualization is
+// the ID of a visualization, e.g. "viz.line", configured by its raw
+// configuration.
+type oneDashboardWidget struct {
+	Title            string                          `json:"title"`
+	Layout           oneDashboardWidgetLayout        `json:"layout"`
+	Visualization    oneDashboardWidgetVisualization `json:"visualization"`
+	RawConfiguration oneDashboardWidgetConfiguration `json:"rawConfiguration"`
+}
+
+type oneDashboardWidgetLayout struct {
+	Column int `json:"column"`
+	Row    int `json:"row"`
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
+type oneDashboardWidgetVisualization struct {
+	ID string `json:"id"`
+}
+
+// oneDashboardWidgetConfiguration holds the NRQL queries of a widget, or the
+// text of a markdown widget.
+type oneDashboardWidgetConfiguration struct {
+	NrqlQueries []oneDashboardNrqlQuery `json:"nrqlQueries,omitempty"`
+	Text        string                  `json:"text,omitempty"`
+}
+
+type oneDashboardNrqlQuery struct {
+	AccountID int    `json:"accountId"`
+	Query     string `json:"query"`
+}
+
+// dashboardError is an error of a dashboard mutation, which is returned in
+// the response data rather than as a GraphQL error.
+type dashboardError struct {
+	Description string `json:"description"`
+	Type        string `json:"type"`
+}
+
+// workload is a collection of entities, those listed by GUID and those
+// matching its entity search queries.
+type workload struct {
+	GUID                string                      `json:"guid"`
+	ID                  int                         `json:"id"`
+	Name                string                      `json:"name"`
+	Permalink           string                      `json:"permalink"`
+	Account             workloadAccount             `json:"account"`
+	Entities            []workloadEntity            `json:"entities"`
+	EntitySearchQueries []workloadEntitySearchQuery `json:"entitySearchQueries"`
+}
+
+type workloadAccount struct {
+	ID int `json:"id"`
+}
+
+type workloadEntity struct {
+	GUID string `json:"guid"`
+}
+
+type workloadEntitySearchQuery struct {
+	Query string `json:"query"`
+}
+
+// workloadInput creates or updates a workload. Its entities and entity search
+// queries replace the existing ones.
+type workloadInput struct {
+	Name                string                      `json:"name"`
+	EntityGUIDs         []string                    `json:"entityGuids"`
+	EntitySearchQueries []workloadEntitySearchQuery `json:"entitySearchQueries"`
+}
+
+type nerdGraphError struct {
+	Message string `json:"message"`
+}
+
+// nerdGraphQueryError is returned when NerdGraph answers a request with
+// GraphQL errors, e.g. for an invalid NRQL query, as opposed to an error
+// performing the request.
+type nerdGraphQueryError struct {
+	Messages []string
+}
+
+func (e *nerdGraphQueryError) Error() string {
+	return fmt.Sprintf("error: NerdGraph request failed: %s", strings.Join(e.Messages, "; "))
+}
+
+func nerdGraphQuery(client *newrelic.Client, query string, variables map[string]interface{}, data interface{}) error {
+	req := struct {
+		Query     string                 `json:"query"`
+		Variables map[string]interface{} `json:"variables,omitempty"`
+	}{
+		Query:     query,
+		Variables: variables,
+	}
+
+	resp := struct {
+		Data   interface{}      `json:"data"`
+		Errors []nerdGraphError `json:"errors,omitempty"`
+	}{
+		Data: data,
+	}
+
+	apiResponse, err := client.RestyClient.R().
+		SetHeader("Content-Type", "application/json").
+		SetHeader("API-Key", client.RestyClient.Header.Get("X-Api-Key")).
+		SetBody(req).
+		SetResult(&resp).
+		Post(nerdGraphURLFor(client))
+	if err != nil {
+		return fmt.Errorf("error: could not perform NerdGraph request: %s", err)
+	}
+
+	if apiResponse.StatusCode()/100 != 2 {
+		return fmt.Errorf("error: invalid response from NerdGraph request with code %d. Message: %s", apiResponse.StatusCode(), apiResponse.String())
+	}
+
+	if len(resp.Errors) > 0 {
+		messages := make([]string, len(resp.Errors))
+		for i, e := range resp.Errors {
+			messages[i] = e.Message
+		}
+
+		return &nerdGraphQueryError{Messages: messages}
+	}
+
+	return nil
+}
+
+// getAccounts returns the accounts the client's API key has access to.
+func getAccounts(client *newrelic.Client) ([]account, error) {
+	data := struct {
+		Actor struct {
+			Accounts []account `json:"accounts"`
+		} `json:"actor"`
+	}{}
+
+	if err := nerdGraphQuery(client, `{ actor { accounts { id name } } }`, nil, &data); err != nil {
+		return nil, err
+	}
+
+	return data.Actor.Accounts, nil
+}
+
+func getEntity(client *newrelic.Client, guid string) (*entity, error) {
+	data := struct {
+		Actor struct {
+			Entity *entity `json:"entity"`
+		} `json:"actor"`
+	}{}
+
+	query := `query($guid: EntityGuid!) { actor { entity(guid: $guid) { guid name type domain accountId } } }`
+
+	if err := nerdGraphQuery(client, query, map[string]interface{}{"guid": guid}, &data); err != nil {
+		return nil, err
+	}
+
+	if data.Actor.Entity == nil {
+		return nil, errEntityNotFound
+	}
+
+	return data.Actor.Entity, nil
+}
+
+// getEntityTags returns the tags of an entity that can be changed. Tags set
+// by New Relic itself, such as account, are immutable and left out.
+func getEntityTags(client *newrelic.Client, guid string) ([]entityTag, error) {
+	data := struct {
+		Actor struct {
+			Entity *struct {
+				TagsWithMetadata []struct {
+					Key    string `json:"key"`
+					Values []struct {
+						Value   string `json:"value"`
+						Mutable bool   `json:"mutable"`
+					} `json:"values"`
+				} `json:"tagsWithMetadata"`
+			} `json:"entity"`
+		} `json:"actor"`
+	}{}
+
+	query := `query($guid: EntityGuid!) { actor { entity(guid: $guid) { tagsWithMetadata { key values { value mutable } } } } }`
+
+	if err := nerdGraphQuery(client, query, map[string]interface{}{"guid": guid}, &data); err != nil {
+		return nil, err
+	}
+
+	if data.Actor.Entity == nil {
+		return nil, errEntityNotFound
+	}
+
+	var tags []entityTag
+	for _, t := range data.Actor.Entity.TagsWithMetadata {
+		tag := entityTag{Key: t.Key}
+		for _, v := range t.Values {
+			if v.Mutable {
+				tag.Values = append(tag.Values, v.Value)
+			}
+		}
+
+		if len(tag.Values) > 0 {
+			tags = append(tags, tag)
+		}
+	}
+
+	return tags, nil
+}
+
+// taggingMutation runs one of the tagging mutations, which report failures
+// in their result rather than as GraphQL errors.
+func taggingMutation(client *newrelic.Client, name string, query string, variables map[string]interface{}) error {
+	data := map[string]*struct {
+		Errors []nerdGraphError `json:"errors"`
+	}{}
+
+	if err := nerdGraphQuery(client, query, variables, &data); err != nil {
+		return err
+	}
+
+	if result := data[name]; result != nil && len(result.Errors) > 0 {
+		messages := make([]string, len(result.Errors))
+		for i, e := range result.Errors {
+			messages[i] = e.Message
+		}
+
+		return fmt.Errorf("error: %s failed: %s", name, strings.Join(messages, "; "))
+	}
+
+	return nil
+}
+
+func addEntityTags(client *newrelic.Client, guid string, tags []entityTag) error {
+	query := `mutation($guid: EntityGuid!, $tags: [TaggingTagInput!]!) { taggingAddTagsToEntity(guid: $guid, tags: $tags) { errors { message } } }`
+
+	return taggingMutation(client, "taggingAddTagsToEntity", query, map[string]interface{}{"guid": guid, "tags": tags})
+}
+
+func replaceEntityTags(client *newrelic.Client, guid string, tags []entityTag) error {
+	query := `mutation($guid: EntityGuid!, $tags: [TaggingTagInput!]!) { taggingReplaceTagsOnEntity(guid: $guid, tags: $tags) { errors { message } } }`
+
+	return taggingMutation(client, "taggingReplaceTagsOnEntity", query, map[string]interface{}{"guid": guid, "tags": tags})
+}
+
+func deleteEntityTags(client *newrelic.Client, guid string, keys []string) error {
+	query := `mutation($guid: EntityGuid!, $tagKeys: [String!]!) { taggingDeleteTagFromEntity(guid: $guid, tagKeys: $tagKeys) { errors { message } } }`
+
+	return taggingMutation(client, "taggingDeleteTagFromEntity", query, map[string]interface{}{"guid": guid, "tagKeys": keys})
+}
+
+func getApplicationErrorCollector(client *newrelic.Client, guid string) (*applicationErrorCollector, error) {
+	data := struct {
+		Actor struct {
+			Entity *struct {
+				ApmSettings *struct {
+					ErrorCollector applicationErrorCollector `json:"errorCollector"`
+				} `json:"apmSettings"`
+			} `json:"entity"`
+		} `json:"actor"`
+	}{}
+
+	query := `query($guid: EntityGuid!) { actor { entity(guid: $guid) { ... on ApmApplicationEntity { apmSettings { errorCollector { expectedErrorClasses expectedErrorCodes } } } } } }`
+
+	if err := nerdGraphQuery(client, query, map[string]interface{}{"guid": guid}, &data); err != nil {
+		return nil, err
+	}
+
+	if data.Actor.Entity == nil {
+		return nil, errEntityNotFound
+	}
+
+	if data.Actor.Entity.ApmSettings == nil {
+		return nil, fmt.Errorf("error: entity %s is not an APM application", guid)
+	}
+
+	return &data.Actor.Entity.ApmSettings.ErrorCollector, nil
+}
+
+func updateApplicationErrorCollector(client *newrelic.Client, guid string, errorCollector applicationErrorCollector) error {
+	query := `mutation($guid: EntityGuid!, $settings: AgentApplicationSettingsUpdateInput!) { agentApplicationSettingsUpdate(guid: $guid, settings: $settings) { guid } }`
+
+	settings := map[string]interface{}{"errorCollector": errorCollector}
+
+	return nerdGraphQuery(client, query, map[string]interface{}{"guid": guid, "settings": settings}, nil)
+}
+
+const mutingRuleFields = `id name description enabled condition { operator conditions { attribute operator values } } schedule { startTime endTime timeZone repeat }`
+
+func getMutingRule(client *newrelic.Client, accountID int, id int) (*mutingRule, error) {
+	data := struct {
+		Actor struct {
+			Account struct {
+				Alerts struct {
+					MutingRule *mutingRule `json:"mutingRule"`
+				} `json:"alerts"`
+			} `json:"account"`
+		} `json:"actor"`
+	}{}
+
+	query := `query($accountId: Int!, $id: ID!) { actor { account(id: $accountId) { alerts { mutingRule(id: $id) { ` + mutingRuleFields + ` } } } } }`
+
+	if err := nerdGraphQuery(client, query, map[string]interface{}{"accountId": accountID, "id": strconv.Itoa(id)}, &data); err != nil {
+		return nil, err
+	}
+
+	if data.Actor.Account.Alerts.MutingRule == nil {
+		return nil, errMutingRuleNotFound
+	}
+
+	return data.Actor.Account.Alerts.MutingRule, nil
+}
+
+func createMutingRule(client *newrelic.Client, accountID int, rule mutingRule) (*mutingRule, error) {
+	data := struct {
+		MutingRule *mutingRule `json:"alertsMutingRuleCreate"`
+	}{}
+
+	query := `mutation($accountId: Int!, $rule: AlertsMutingRuleInput!) { alertsMutingRuleCreate(accountId: $accountId, rule: $rule) { ` + mutingRuleFields + ` } }`
+
+	if err := nerdGraphQuery(client, query, map[string]interface{}{"accountId": accountID, "rule": rule}, &data); err != nil {
+		return nil, err
+	}
+
+	if data.MutingRule == nil {
+		return nil, fmt.Errorf("error: alert muting rule %s was not created", rule.Name)
+	}
+
+	return data.MutingRule, nil
+}
+
+func updateMutingRule(client *newrelic.Client, accountID int, id int, rule mutingRule) error {
+	query := `mutation($accountId: Int!, $id: ID!, $rule: AlertsMutingRuleUpdateInput!) { alertsMutingRuleUpdate(accountId: $accountId, id: $id, rule: $rule) { id } }`
+
+	return nerdGraphQuery(client, query, map[string]interface{}{"accountId": accountID, "id": strconv.Itoa(id), "rule": rule}, nil)
+}
+
+func deleteMutingRule(client *newrelic.Client, accountID int, id int) error {
+	query := `mutation($accountId: Int!, $id: ID!) { alertsMutingRuleDelete(accountId: $accountId, id: $id) { id } }`
+
+	return nerdGraphQuery(client, query, map[string]interface{}{"accountId": accountID, "id": strconv.Itoa(id)}, nil)
+}
+
+const (
+	notificationDestinationFields = `id name type active properties { key value } auth { ... on AiNotificationsBasicAuth { authType user } ... on AiNotificationsTokenAuth { authType prefix } }`
+	notificationChannelFields     = `id name type product destinationId active properties { key value }`
+	notificationErrorsFields      = `errors { ... on AiNotificationsResponseError { description details } ... on AiNotificationsDataValidationError { details } }`
+)
+
+// notificationErrors returns the errors of a notification mutation as a
+// single error, or nil if there are none.
+func notificationErrors(name string, errs []notificationError) error {
+	if len(errs) == 0 {
+		return nil
+	}
+
+	messages := make([]string, len(errs))
+	for i, e := range errs {
+		messages[i] = strings.TrimSpace(strings.Join([]string{e.Description, e.Details}, " "))
+	}
+
+	return fmt.Errorf("error: %s failed: %s", name, strings.Join(messages, "; "))
+}
+
+func getNotificationDestination(client *newrelic.Client, accountID int, id string) (*notificationDestination, error) {
+	data := struct {
+		Actor struct {
+			Account struct {
+				AiNotifications struct {
+					Destinations struct {
+						Entities []notificationDestination `json:"entities"`
+					} `json:"destinations"`
+				} `json:"aiNotifications"`
+			} `json:"account"`
+		} `json:"actor"`
+	}{}
+
+	query := `query($accountId: Int!, $id: ID!) { actor { account(id: $accountId) { aiNotifications { destinations(filters: {id: $id}) { entities { ` + notificationDestinationFields + ` } } } } } }`
+
+	if err := nerdGraphQuery(client, query, map[string]interface{}{"accountId": accountID, "id": id}, &data); err != nil {
+		return nil, err
+	}
+
+	for _, destination := range data.Actor.Account.AiNotifications.Destinations.Entities {
+		if destination.ID == id {
+			return &destination, nil
+		}
+	}
+
+	return nil, errNotificationDestinationNotFound
+}
+
+func createNotificationDestination(client *newrelic.Client, accountID int, destination notificationDestinationInput) (*notificationDestination, error) {
+	data := struct {
+		Response struct {
+			Destination *notificationDestination `json:"destination"`
+			Errors      []notificationError      `json:"errors"`
+		} `json:"aiNotificationsCreateDestination"`
+	}{}
+
+	query := `mutation($accountId: Int!, $destination: AiNotificationsDestinationInput!) { aiNotificationsCreateDestination(accountId: $accountId, destination: $destination) { destination { ` + notificationDestinationFields + ` } ` + notificationErrorsFields + ` } }`
+
+	if err := nerdGraphQuery(client, query, map[string]interface{}{"accountId": accountID, "destination": destination}, &data); err != nil {
+		return nil, err
+	}
+
+	if err := notificationErrors("aiNotificationsCreateDestination", data.Response.Errors); err != nil {
+		return nil, err
+	}
+
+	if data.Response.Destination == nil {
+		return nil, fmt.Errorf("error: notification destination %s was not created", destination.Name)
+	}
+
+	return data.Response.Destination, nil
+}
+
+func updateNotificationDestination(client *newrelic.Client, accountID int, id string, destination notificationDestinationInput) error {
+	data := struct {
+		Response struct {
+			Errors []notificationError `json:"errors"`
+		} `json:"aiNotificationsUpdateDestination"`
+	}{}
+
+	query := `mutation($accountId: Int!, $id: ID!, $destination: AiNotificationsDestinationUpdate!) { aiNotificationsUpdateDestination(accountId: $accountId, destinationId: $id, destination: $destination) { destination { id } ` + notificationErrorsFields + ` } }`
+
+	if err := nerdGraphQuery(client, query, map[string]interface{}{"accountId": accountID, "id": id, "destination": destination}, &data); err != nil {
+		return err
+	}
+
+	return notificationErrors("aiNotificationsUpdateDestination", data.Response.Errors)
+}
+
+func deleteNotificationDestination(client *newrelic.Client, accountID int, id string) error {
+	data := struct {
+		Response struct {
+			Errors []notificationError `json:"errors"`
+		} `json:"aiNotificationsDeleteDestination"`
+	}{}
+
+	query := `mutation($accountId: Int!, $id: ID!) { aiNotificationsDeleteDestination(accountId: $accountId, destinationId: $id) { ids ` + notificationErrorsFields + ` } }`
+
+	if err := nerdGraphQuery(client, query, map[string]interface{}{"accountId": accountID, "id": id}, &data); err != nil {
+		return err
+	}
+
+	return notificationErrors("aiNotificationsDeleteDestination", data.Response.Errors)
+}
+
+func getNotificationChannel(client *newrelic.Client, accountID int, id string) (*notificationChannel, error) {
+	data := struct {
+		Actor struct {
+			Account struct {
+				AiNotifications struct {
+					Channels struct {
+						Entities []notificationChannel `json:"entities"`
+					} `json:"channels"`
+				} `json:"aiNotifications"`
+			} `json:"account"`
+		} `json:"actor"`
+	}{}
+
+	query := `query($accountId: Int!, $id: ID!) { actor { account(id: $accountId) { aiNotifications { channels(filters: {id: $id}) { entities { ` + notificationChannelFields + ` } } } } } }`
+
+	if err := nerdGraphQuery(client, query, map[string]interface{}{"accountId": accountID, "id": id}, &data); err != nil {
+		return nil, err
+	}
+
+	for _, channel := range data.Actor.Account.AiNotifications.Channels.Entities {
+		if channel.ID == id {
+			return &channel, nil
+		}
+	}
+
+	return nil, errNotificationChannelNotFound
+}
+
+func createNotificationChannel(client *newrelic.Client, accountID int, channel notificationChannelInput) (*notificationChannel, error) {
+	data := struct {
+		Response struct {
+			Channel *notificationChannel `json:"channel"`
+			Errors  []notificationError  `json:"errors"`
+		} `json:"aiNotificationsCreateChannel"`
+	}{}
+
+	query := `mutation($accountId: Int!, $channel: AiNotificationsChannelInput!) { aiNotificationsCreateChannel(accountId: $accountId, channel: $channel) { channel { ` + notificationChannelFields + ` } ` + notificationErrorsFields + ` } }`
+
+	if err := nerdGraphQuery(client, query, map[string]interface{}{"accountId": accountID, "channel": channel}, &data); err != nil {
+		return nil, err
+	}
+
+	if err := notificationErrors("aiNotificationsCreateChannel", data.Response.Errors); err != nil {
+		return nil, err
+	}
+
+	if data.Response.Channel == nil {
+		return nil, fmt.Errorf("error: notification channel %s was not created", channel.Name)
+	}
+
+	return data.Response.Channel, nil
+}
+
+func updateNotificationChannel(client *newrelic.Client, accountID int, id string, channel notificationChannelInput) error {
+	data := struct {
+		Response struct {
+			Errors []notificationError `json:"errors"`
+		} `json:"aiNotificationsUpdateChannel"`
+	}{}
+
+	query := `mutation($accountId: Int!, $id: ID!, $channel: AiNotificationsChannelUpdate!) { aiNotificationsUpdateChannel(accountId: $accountId, channelId: $id, channel: $channel) { channel { id } ` + notificationErrorsFields + ` } }`
+
+	if err := nerdGraphQuery(client, query, map[string]interface{}{"accountId": accountID, "id": id, "channel": channel}, &data); err != nil {
+		return err
+	}
+
+	return notificationErrors("aiNotificationsUpdateChannel", data.Response.Errors)
+}
+
+func deleteNotificationChannel(client *newrelic.Client, accountID int, id string) error {
+	data := struct {
+		Response struct {
+			Errors []notificationError `json:"errors"`
+		} `json:"aiNotificationsDeleteChannel"`
+	}{}
+
+	query := `mutation($accountId: Int!, $id: ID!) { aiNotificationsDeleteChannel(accountId: $accountId, channelId: $id) { ids ` + notificationErrorsFields + ` } }`
+
+	if err := nerdGraphQuery(client, query, map[string]interface{}{"accountId": accountID, "id": id}, &data); err != nil {
+		return err
+	}
+
+	return notificationErrors("aiNotificationsDeleteChannel", data.Response.Errors)
+}
+
+const oneDashboardFields = `guid accountId name description permissions permalink pages { guid name description widgets { title layout { column row width height } visualization { id } rawConfiguration } }`
+
+// dashboardErrors returns the errors of a dashboard mutation as a single
+// error, or nil if there are none.
+func dashboardErrors(name string, errs []dashboardError) error {
+	if len(errs) == 0 {
+		return nil
+	}
+
+	messages := make([]string, len(errs))
+	for i, e := range errs {
+		messages[i] = fmt.Sprintf("%s (%s)", e.Description, e.Type)
+	}
+
+	return fmt.Errorf("error: %s failed: %s", name, strings.Join(messages, "; "))
+}
+
+func getOneDashboard(client *newrelic.Client, guid string) (*oneDashboard, error) {
+	data := struct {
+		Actor struct {
+			Entity *oneDashboard `json:"entity"`
+		} `json:"actor"`
+	}{}
+
+	query := `query($guid: EntityGuid!) { actor { entity(guid: $guid) { ... on DashboardEntity { ` + oneDashboardFields + ` } } } }`
+
+	if err := nerdGraphQuery(client, query, map[string]interface{}{"guid": guid}, &data); err != nil {
+		return nil, err
+	}
+
+	if data.Actor.Entity == nil {
+		return nil, errEntityNotFound
+	}
+
+	// Only the fields of dashboards are queried, so other entities have none.
+	if data.Actor.Entity.GUID == "" {
+		return nil, fmt.Errorf("error: entity %s is not a dashboard", guid)
+	}
+
+	return data.Actor.Entity, nil
+}
+
+// createOneDashboard creates a dashboard in the account and returns its GUID.
+func createOneDashboard(client *newrelic.Client, accountID int, dashboard oneDashboardInput) (string, error) {
+	data := struct {
+		Response struct {
+			EntityResult *struct {
+				GUID string `json:"guid"`
+			} `json:"entityResult"`
+			Errors []dashboardError `json:"errors"`
+		} `json:"dashboardCreate"`
+	}{}
+
+	query := `mutation($accountId: Int!, $dashboard: DashboardInput!) { dashboardCreate(accountId: $accountId, dashboard: $dashboard) { entityResult { guid } errors { description type } } }`
+
+	if err := nerdGraphQuery(client, query, map[string]interface{}{"accountId": accountID, "dashboard": dashboard}, &data); err != nil {
+		return "", err
+	}
+
+	if err := dashboardErrors("dashboardCreate", data.Response.Errors); err != nil {
+		return "", err
+	}
+
+	if data.Response.EntityResult == nil || data.Response.EntityResult.GUID == "" {
+		return "", fmt.Errorf("error: dashboard %s was not created", dashboard.Name)
+	}
+
+	return data.Response.EntityResult.GUID, nil
+}
+
+func updateOneDashboard(client *newrelic.Client, guid string, dashboard oneDashboardInput) error {
+	data := struct {
+		Response struct {
+			Errors []dashboardError `json:"errors"`
+		} `json:"dashboardUpdate"`
+	}{}
+
+	query := `mutation($guid: EntityGuid!, $dashboard: DashboardInput!) { dashboardUpdate(guid: $guid, dashboard: $dashboard) { entityResult { guid } errors { description type } } }`
+
+	if err := nerdGraphQuery(client, query, map[string]interface{}{"guid": guid, "dashboard": dashboard}, &data); err != nil {
+		return err
+	}
+
+	return dashboardErrors("dashboardUpdate", data.Response.Errors)
+}
+
+// deleteOneDashboard deletes a dashboard. Deleting a dashboard that no longer
+// exists succeeds.
+func deleteOneDashboard(client *newrelic.Client, guid string) error {
+	data := struct {
+		Response struct {
+			Errors []dashboardError `json:"errors"`
+		} `json:"dashboardDelete"`
+	}{}
+
+	query := `mutation($guid: EntityGuid!) { dashboardDelete(guid: $guid) { status errors { description type } } }`
+
+	if err := nerdGraphQuery(client, query, map[string]interface{}{"guid": guid}, &data); err != nil {
+		return err
+	}
+
+	errs := []dashboardError{}
+	for _, e := range data.Response.Errors {
+		if e.Type != "DASHBOARD_NOT_FOUND" {
+			errs = append(errs, e)
+		}
+	}
+
+	return dashboardErrors("dashboardDelete", errs)
+}
+
+const workloadFields = `guid id name permalink account { id } entities { guid } entitySearchQueries { query }`
+
+// getWorkload returns a workload of the account, which is the account encoded
+// in its GUID.
+func getWorkload(client *newrelic.Client, accountID int, guid string) (*workload, error) {
+	data := struct {
+		Actor struct {
+			Account struct {
+				Workload struct {
+					Collection *workload `json:"collection"`
+				} `json:"workload"`
+			} `json:"account"`
+		} `json:"actor"`
+	}{}
+
+	query := `query($accountId: Int!, $guid: EntityGuid!) { actor { account(id: $accountId) { workload { collection(guid: $guid) { ` + workloadFields + ` } } } } }`
+
+	if err := nerdGraphQuery(client, query, map[string]interface{}{"accountId": accountID, "guid": guid}, &data); err != nil {
+		return nil, err
+	}
+
+	if data.Actor.Account.Workload.Collection == nil {
+		return nil, errEntityNotFound
+	}
+
+	return data.Actor.Account.Workload.Collection, nil
+}
+
+func createWorkload(client *newrelic.Client, accountID int, input workloadInput) (*workload, error) {
+	data := struct {
+		Workload *workload `json:"workloadCreate"`
+	}{}
+
+	query := `mutation($accountId: Int!, $workload: WorkloadCreateInput!) { workloadCreate(accountId: $accountId, workload: $workload) { ` + workloadFields + ` } }`
+
+	if err := nerdGraphQuery(client, query, map[string]interface{}{"accountId": accountID, "workload": input}, &data); err != nil {
+		return nil, err
+	}
+
+	if data.Workload == nil || data.Workload.GUID == "" {
+		return nil, fmt.Errorf("error: workload %s was not created", input.Name)
+	}
+
+	return data.Workload, nil
+}
+
+func updateWorkload(client *newrelic.Client, guid string, input workloadInput) error {
+	query := `mutation($guid: EntityGuid!, $workload: WorkloadUpdateInput!) { workloadUpdate(guid: $guid, workload: $workload) { guid } }`
+
+	return nerdGraphQuery(client, query, map[string]interface{}{"guid": guid, "workload": input}, nil)
+}
+
+func deleteWorkload(client *newrelic.Client, guid string) error {
+	query := `mutation($guid: EntityGuid!) { workloadDelete(guid: $guid) { guid } }`
+
+	return nerdGraphQuery(client, query, map[string]interface{}{"guid": guid}, nil)
+}
+
+// isEntityGUID reports whether guid has the shape of an entity GUID, the
+// base64 encoding of "<account id>|<domain>|<type>|<id>".
+func isEntityGUID(guid string) bool {
+	return entityGUIDParts(guid) != nil
+}
+
+// entityGUIDParts returns the account ID, domain, type and ID encoded in an
+// entity GUID, or nil if guid isn't one.
+func entityGUIDParts(guid string) []string {
+	decoded, err := base64.RawStdEncoding.DecodeString(strings.TrimRight(guid, "="))
+	if err != nil {
+		return nil
+	}
+
+	parts := strings.Split(string(decoded), "|")
+	if len(parts) != 4 {
+		return nil
+	}
+
+	return parts
+}
+
+// isSyntheticsPrivateLocationGUID reports whether guid is the entity GUID of
+// a Synthetics private location.
+func isSyntheticsPrivateLocationGUID(guid string) bool {
+	parts := entityGUIDParts(guid)
+
+	return parts != nil && parts[1] == "SYNTH" && parts[2] == "PRIVATE_LOCATION"
+}
+
+var nrqlLimitRegexp = regexp.MustCompile(`(?i)\bLIMIT\s+(\d+|MAX)\b`)
+
+// nrqlWithLimitZero returns query with its LIMIT clause, if any, set to 0 so
+// that running it only checks its syntax.
+func nrqlWithLimitZero(query string) string {
+	query = strings.TrimRight(strings.TrimSpace(query), ";")
+
+	if nrqlLimitRegexp.MatchString(query) {
+		return nrqlLimitRegexp.ReplaceAllString(query, "LIMIT 0")
+	}
+
+	return query + " LIMIT 0"
+}
+
+// nrqlKeywords are the reserved NRQL keywords, which unlike event types and
+// attribute names are case insensitive, and can't be used as attribute names
+// without backquotes.
+var nrqlKeywords = map[string]bool{
+	"and": true, "as": true, "asc": true, "by": true, "compare": true,
+	"desc": true, "extrapolate": true, "facet": true, "false": true,
+	"from": true, "in": true, "is": true, "like": true, "limit": true,
+	"not": true, "null": true, "or": true, "order": true, "select": true,
+	"since": true, "slide": true, "timeseries": true, "true": true,
+	"until": true, "where": true, "with": true,
+}
+
+// nrqlTimeUnits are the units of the durations of SINCE, UNTIL, COMPARE WITH,
+// TIMESERIES and SLIDE BY clauses, e.g. day in SINCE 1 day ago.
+var nrqlTimeUnits = map[string]bool{
+	"second": true, "seconds": true, "minute": true, "minutes": true,
+	"hour": true, "hours": true, "day": true, "days": true, "week": true,
+	"weeks": true, "month": true, "months": true, "year": true, "years": true,
+}
+
+// isNrqlContextKeyword reports whether word, lowercased, is a keyword after
+// the word prev. Such words, e.g. max in LIMIT MAX or day in SINCE 1 day ago,
+// are valid attribute names everywhere else.
+func isNrqlContextKeyword(word, prev string) bool {
+	switch {
+	case nrqlTimeUnits[word] || word == "offset":
+		return isNrqlNumber(prev)
+	case word == "ago":
+		return nrqlTimeUnits[prev]
+	case word == "max":
+		return prev == "limit" || prev == "timeseries"
+	case word == "auto":
+		return prev == "timeseries" || prev == "by"
+	case word == "timezone":
+		return prev == "with"
+	}
+
+	return false
+}
+
+func isNrqlNumber(word string) bool {
+	return word != "" && strings.Trim(word, "0123456789.") == ""
+}
+
+func isNrqlWordChar(c byte) bool {
+	return c == '_' || c == '.' || c == ':' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// normalizeNrql returns query with its whitespace collapsed, and its keywords
+// and function names lowercased, so that equivalent queries are equal.
+// Attribute names are case sensitive, so words are only lowercased where the
+// grammar puts a keyword or a function call, which is a word followed by "("
+// that doesn't follow an attribute name. String literals and backquoted names
+// are kept as they are, since they're case and whitespace sensitive.
+func normalizeNrql(query string) string {
+	var b strings.Builder
+	lastIsWord := false
+
+	// prev is the previous word, lowercased if it's a keyword, and
+	// prevIsName whether it's an event type, attribute name or number.
+	prev, prevIsName := "", false
+
+	for i := 0; i < len(query); {
+		c := query[i]
+
+		switch {
+		case c == '\'' || c == '"' || c == '`':
+			j := i + 1
+			for j < len(query) && query[j] != c {
+				if query[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			if j < len(query) {
+				j++
+			}
+			if j > len(query) {
+				j = len(query)
+			}
+
+			b.WriteString(query[i:j])
+			lastIsWord = false
+			prev, prevIsName = "", c == '`'
+			i = j
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case isNrqlWordChar(c):
+			j := i
+			for j < len(query) && isNrqlWordChar(query[j]) {
+				j++
+			}
+
+			word, lower := query[i:j], strings.ToLower(query[i:j])
+			isCall := strings.HasPrefix(strings.TrimLeft(query[j:], " \t\n\r"), "(") && !prevIsName
+
+			isName := false
+			if nrqlKeywords[lower] || isNrqlContextKeyword(lower, prev) || isCall {
+				word = lower
+			} else {
+				isName = true
+			}
+
+			// Whitespace is only significant between two words.
+			if lastIsWord {
+				b.WriteByte(' ')
+			}
+
+			b.WriteString(word)
+			lastIsWord = true
+			prev, prevIsName = word, isName
+			i = j
+		default:
+			b.WriteByte(c)
+			lastIsWord = false
+			prev, prevIsName = "", c == ')'
+			i++
+		}
+	}
+
+	return strings.TrimRight(b.String(), ";")
+}
+
+// validateNrql runs query with LIMIT 0 against the account and returns the
+// error reported by New Relic, e.g. for a syntax error.
+func validateNrql(client *newrelic.Client, accountID int, query string) error {
+	_, err := runNrql(client, accountID, nrqlWithLimitZero(query))
+	return err
+}
+
+var nrqlFromRegexp = regexp.MustCompile("(?i)\\bFROM\\s+(`[^`]+`|[\\w:.]+)((?:\\s*,\\s*(?:`[^`]+`|[\\w:.]+))*)")
+
+// nrqlEventTypes returns the event types in the FROM clause of query, e.g.
+// ["Transaction", "PageView"] for "SELECT count(*) FROM Transaction, PageView".
+func nrqlEventTypes(query string) []string {
+	match := nrqlFromRegexp.FindStringSubmatch(query)
+	if match == nil {
+		return nil
+	}
+
+	eventTypes := []string{strings.Trim(match[1], "`")}
+	for _, eventType := range strings.Split(match[2], ",") {
+		if eventType = strings.Trim(strings.TrimSpace(eventType), "`"); eventType != "" {
+			eventTypes = append(eventTypes, eventType)
+		}
+	}
+
+	return eventTypes
+}
+
+// unknownNrqlEventTypes returns the event types in the FROM clause of query
+// that the account hasn't reported in the last week, which are usually typos.
+func unknownNrqlEventTypes(client *newrelic.Client, accountID int, query string) ([]string, error) {
+	eventTypes := nrqlEventTypes(query)
+	if len(eventTypes) == 0 {
+		return nil, nil
+	}
+
+	results, err := runNrql(client, accountID, "SHOW EVENT TYPES SINCE 1 week ago")
+	if err != nil {
+		return nil, err
+	}
+
+	known := map[string]bool{}
+	for _, result := range results {
+		reported, _ := result["eventTypes"].([]interface{})
+		for _, eventType := range reported {
+			if eventType, ok := eventType.(string); ok {
+				known[eventType] = true
+			}
+		}
+	}
+
+	unknown := []string{}
+	for _, eventType := range eventTypes {
+		if !known[eventType] {
+			unknown = append(unknown, eventType)
+		}
+	}
+
+	return unknown, nil
+}
+
+// runNrql runs query against the account and returns its results, one map
+// per row, e.g. [{"count": 42}] for "SELECT count(*) FROM Transaction".
+func runNrql(client *newrelic.Client, accountID int, query string) ([]map[string]interface{}, error) {
+	gql := `query($accountId: Int!, $query: Nrql!) {
+  actor {
+    account(id: $accountId) {
+      nrql(query: $query) {
+        results
+      }
+    }
+  }
+}`
+
+	data := struct {
+		Actor struct {
+			Account struct {
+				Nrql *struct {
+					Results []map[string]interface{} `json:"results"`
+				} `json:"nrql"`
+			} `json:"account"`
+		} `json:"actor"`
+	}{}
+
+	vars := map[string]interface{}{
+		"accountId": accountID,
+		"query":     query,
+	}
+
+	if err := nerdGraphQuery(client, gql, vars, &data); err != nil {
+		return nil, err
+	}
+
+	if data.Actor.Account.Nrql == nil {
+		return nil, nil
+	}
+
+	return data.Actor.Account.Nrql.Results, nil
+}
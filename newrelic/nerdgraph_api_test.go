@@ -0,0 +1,86 @@
+package newrelic
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	newrelic "github.com/paultyng/go-newrelic/v4/api"
+)
+
+func TestNrqlWithLimitZero(t *testing.T) {
+	cases := map[string]string{
+		"SELECT count(*) FROM Transaction":               "SELECT count(*) FROM Transaction LIMIT 0",
+		"SELECT count(*) FROM Transaction;\n":            "SELECT count(*) FROM Transaction LIMIT 0",
+		"SELECT * FROM Transaction LIMIT 100":            "SELECT * FROM Transaction LIMIT 0",
+		"SELECT * FROM Transaction limit max FACET host": "SELECT * FROM Transaction LIMIT 0 FACET host",
+		"SELECT uniques(limitReached) FROM Transaction":  "SELECT uniques(limitReached) FROM Transaction LIMIT 0",
+	}
+
+	for query, expected := range cases {
+		if got := nrqlWithLimitZero(query); got != expected {
+			t.Errorf("expected %q for %q, got %q", expected, query, got)
+		}
+	}
+}
+
+func TestIsSyntheticsPrivateLocationGUID(t *testing.T) {
+	cases := map[string]bool{
+		"MTIzNDV8U1lOVEh8UFJJVkFURV9MT0NBVElPTnxteS1taW5pb24": true,
+		"MTIzNDV8U1lOVEh8TU9OSVRPUnxhYmM":                     false,
+		"AWS_US_EAST_1":                                       false,
+	}
+
+	for guid, expected := range cases {
+		if got := isSyntheticsPrivateLocationGUID(guid); got != expected {
+			t.Errorf("expected %v for %q, got %v", expected, guid, got)
+		}
+	}
+}
+
+func TestNrqlEventTypes(t *testing.T) {
+	cases := map[string][]string{
+		"SELECT count(*) FROM Transaction WHERE appName = 'web'":     {"Transaction"},
+		"select count(*) from Transaction, PageView since 1 day ago": {"Transaction", "PageView"},
+		"SELECT average(value) FROM `My Custom Event` FACET host":    {"My Custom Event"},
+		"SELECT count(*)": nil,
+	}
+
+	for query, expected := range cases {
+		if got := nrqlEventTypes(query); !reflect.DeepEqual(got, expected) {
+			t.Errorf("expected %v for %q, got %v", expected, query, got)
+		}
+	}
+}
+
+func TestUnknownNrqlEventTypes(t *testing.T) {
+	var received struct {
+		Variables struct {
+			Query string `json:"query"`
+		} `json:"variables"`
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&received)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":{"actor":{"account":{"nrql":{"results":[{"eventTypes":["PageView","Transaction"]}]}}}}}`))
+	}))
+	defer server.Close()
+
+	client := newrelic.New(newrelic.Config{BaseURL: server.URL})
+
+	unknown, err := unknownNrqlEventTypes(&client, 123, "SELECT count(*) FROM Transaction, Transacton")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(unknown, []string{"Transacton"}) {
+		t.Fatalf("expected Transacton to be unknown, got %v", unknown)
+	}
+
+	if received.Variables.Query != "SHOW EVENT TYPES SINCE 1 week ago" {
+		t.Fatalf("expected the event types to be listed, got %q", received.Variables.Query)
+	}
+}
@@ -2,63 +2,251 @@ package newrelic
 
 import (
 	"fmt"
+	"io/ioutil"
 	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
 
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
 	"github.com/hashicorp/terraform/terraform"
 )
 
+// regionURLs are the default API URLs of each New Relic region, and the
+// domain of its UI.
+var regionURLs = map[string]struct {
+	api        string
+	infra      string
+	synthetics string
+	ui         string
+}{
+	"US": {
+		api:        "https://api.newrelic.com/v2",
+		infra:      "https://infra-api.newrelic.com/v2",
+		synthetics: syntheticsAPIURL,
+		ui:         "newrelic.com",
+	},
+	"EU": {
+		api:        "https://api.eu.newrelic.com/v2",
+		infra:      "https://infra-api.eu.newrelic.com/v2",
+		synthetics: "https://synthetics.eu.newrelic.com/synthetics/api",
+		ui:         "eu.newrelic.com",
+	},
+}
+
+func urlOrDefault(u string, defaultURL string) string {
+	if u == "" {
+		return defaultURL
+	}
+
+	return u
+}
+
 // Provider represents a resource provider in Terraform
 func Provider() terraform.ResourceProvider {
-	return &schema.Provider{
+	provider := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"api_key": {
-				Type:        schema.TypeString,
-				Required:    true,
-				DefaultFunc: schema.EnvDefaultFunc("NEWRELIC_API_KEY", nil),
-				Sensitive:   true,
+				Type:          schema.TypeString,
+				Optional:      true,
+				DefaultFunc:   schema.EnvDefaultFunc("NEWRELIC_API_KEY", nil),
+				Sensitive:     true,
+				ConflictsWith: []string{"api_key_file"},
+			},
+			"api_key_file": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"api_key"},
+			},
+			"region": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("NEWRELIC_REGION", "US"),
+				ValidateFunc: validation.StringInSlice([]string{"US", "EU"}, false),
 			},
 			"api_url": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				DefaultFunc: schema.EnvDefaultFunc("NEWRELIC_API_URL", "https://api.newrelic.com/v2"),
+				DefaultFunc: schema.EnvDefaultFunc("NEWRELIC_API_URL", nil),
 			},
 			"infra_api_url": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				DefaultFunc: schema.EnvDefaultFunc("NEWRELIC_INFRA_API_URL", "https://infra-api.newrelic.com/v2"),
+				DefaultFunc: schema.EnvDefaultFunc("NEWRELIC_INFRA_API_URL", nil),
+			},
+			"synthetics_api_url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("NEWRELIC_SYNTHETICS_API_URL", nil),
+			},
+			"max_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      defaultMaxRetries,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"min_retry_delay": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      defaultMinRetryDelay.String(),
+				ValidateFunc: validateDuration,
+			},
+			"enable_request_compression": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"debug": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("NEWRELIC_DEBUG", false),
+			},
+			"parallelism": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      defaultParallelism,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"proxy_url": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"cacert_file": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"insecure_skip_verify": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"prevent_destroy_with_conditions": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"unique_policy_names": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"account_id": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  envIntDefaultFunc("NEWRELIC_ACCOUNT_ID", "NEW_RELIC_ACCOUNT_ID"),
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"validate_nrql": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"skip_validation": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("NEWRELIC_SKIP_VALIDATION", false),
+			},
+			"default_tags": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
 			},
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
-			"newrelic_alert_channel":      dataSourceNewRelicAlertChannel(),
-			"newrelic_alert_policy":       dataSourceNewRelicAlertPolicy(),
-			"newrelic_application":        dataSourceNewRelicApplication(),
-			"newrelic_key_transaction":    dataSourceNewRelicKeyTransaction(),
-			"newrelic_synthetics_monitor": dataSourceNewRelicSyntheticsMonitor(),
+			"newrelic_account":              dataSourceNewRelicAccount(),
+			"newrelic_alert_channel":        dataSourceNewRelicAlertChannel(),
+			"newrelic_alert_condition":      dataSourceNewRelicAlertCondition(),
+			"newrelic_alert_policy":         dataSourceNewRelicAlertPolicy(),
+			"newrelic_alert_policies":       dataSourceNewRelicAlertPolicies(),
+			"newrelic_application":          dataSourceNewRelicApplication(),
+			"newrelic_dashboard":            dataSourceNewRelicDashboard(),
+			"newrelic_entity":               dataSourceNewRelicEntity(),
+			"newrelic_key_transaction":      dataSourceNewRelicKeyTransaction(),
+			"newrelic_nrql_query":           dataSourceNewRelicNrqlQuery(),
+			"newrelic_synthetics_locations": dataSourceNewRelicSyntheticsLocations(),
+			"newrelic_synthetics_monitor":   dataSourceNewRelicSyntheticsMonitor(),
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
-			"newrelic_alert_channel":              resourceNewRelicAlertChannel(),
-			"newrelic_alert_condition":            resourceNewRelicAlertCondition(),
-			"newrelic_alert_policy_channel":       resourceNewRelicAlertPolicyChannel(),
-			"newrelic_alert_policy":               resourceNewRelicAlertPolicy(),
-			"newrelic_dashboard":                  resourceNewRelicDashboard(),
-			"newrelic_infra_alert_condition":      resourceNewRelicInfraAlertCondition(),
-			"newrelic_nrql_alert_condition":       resourceNewRelicNrqlAlertCondition(),
-			"newrelic_synthetics_alert_condition": resourceNewRelicSyntheticsAlertCondition(),
-			"newrelic_synthetics_monitor":         resourceNewRelicSyntheticsMonitor(),
-			"newrelic_synthetics_monitor_script":  resourceNewRelicSyntheticsMonitorScript(),
+			"newrelic_alert_channel":                resourceNewRelicAlertChannel(),
+			"newrelic_alert_condition":              resourceNewRelicAlertCondition(),
+			"newrelic_alert_muting_rule":            resourceNewRelicAlertMutingRule(),
+			"newrelic_alert_policy_channel":         resourceNewRelicAlertPolicyChannel(),
+			"newrelic_alert_policy":                 resourceNewRelicAlertPolicy(),
+			"newrelic_application_alert_preset":     resourceNewRelicApplicationAlertPreset(),
+			"newrelic_application_expected_errors":  resourceNewRelicApplicationExpectedErrors(),
+			"newrelic_application_settings":         resourceNewRelicApplicationSettings(),
+			"newrelic_dashboard":                    resourceNewRelicDashboard(),
+			"newrelic_dashboard_json":               resourceNewRelicDashboardJSON(),
+			"newrelic_entity_tags":                  resourceNewRelicEntityTags(),
+			"newrelic_infra_alert_condition":        resourceNewRelicInfraAlertCondition(),
+			"newrelic_notification_channel":         resourceNewRelicNotificationChannel(),
+			"newrelic_notification_destination":     resourceNewRelicNotificationDestination(),
+			"newrelic_nrql_alert_condition":         resourceNewRelicNrqlAlertCondition(),
+			"newrelic_one_dashboard":                resourceNewRelicOneDashboard(),
+			"newrelic_synthetics_alert_condition":   resourceNewRelicSyntheticsAlertCondition(),
+			"newrelic_synthetics_monitor":           resourceNewRelicSyntheticsMonitor(),
+			"newrelic_synthetics_monitor_script":    resourceNewRelicSyntheticsMonitorScript(),
+			"newrelic_synthetics_secure_credential": resourceNewRelicSyntheticsSecureCredential(),
+			"newrelic_workload":                     resourceNewRelicWorkload(),
 		},
+	}
+
+	provider.ConfigureFunc = func(data *schema.ResourceData) (interface{}, error) {
+		meta, err := providerConfigure(data)
+		if err != nil {
+			return nil, err
+		}
+
+		meta.(*ProviderConfig).stopCtx = provider.StopContext()
 
-		ConfigureFunc: providerConfigure,
+		return meta, nil
 	}
+
+	return provider
 }
 
 func providerConfigure(data *schema.ResourceData) (interface{}, error) {
+	minRetryDelay, err := time.ParseDuration(data.Get("min_retry_delay").(string))
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing min_retry_delay: %s", err)
+	}
+
+	apiKey := data.Get("api_key").(string)
+	if path, ok := data.GetOk("api_key_file"); ok {
+		apiKey, err = readAPIKeyFile(path.(string))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if apiKey == "" {
+		return nil, fmt.Errorf("One of api_key, api_key_file or the NEWRELIC_API_KEY environment variable must be set")
+	}
+
+	urls := regionURLs[data.Get("region").(string)]
+
 	config := Config{
-		APIKey: data.Get("api_key").(string),
-		APIURL: data.Get("api_url").(string),
+		APIKey:           apiKey,
+		APIURL:           urlOrDefault(data.Get("api_url").(string), urls.api),
+		SyntheticsAPIURL: urlOrDefault(data.Get("synthetics_api_url").(string), urls.synthetics),
+		MaxRetries:       data.Get("max_retries").(int),
+		MinRetryDelay:    minRetryDelay,
+
+		EnableRequestCompression: data.Get("enable_request_compression").(bool),
+
+		ProxyURL:           data.Get("proxy_url").(string),
+		CACertFile:         data.Get("cacert_file").(string),
+		InsecureSkipVerify: data.Get("insecure_skip_verify").(bool),
+
+		Parallelism:  data.Get("parallelism").(int),
+		requestSlots: make(chan struct{}, data.Get("parallelism").(int)),
+
+		Debug: data.Get("debug").(bool),
 	}
 	log.Println("[INFO] Initializing New Relic client")
 
@@ -74,10 +262,8 @@ func providerConfigure(data *schema.ResourceData) (interface{}, error) {
 		return nil, fmt.Errorf("Error initializing New Relic synthetics client: %s", err)
 	}
 
-	infraConfig := Config{
-		APIKey: data.Get("api_key").(string),
-		APIURL: data.Get("infra_api_url").(string),
-	}
+	infraConfig := config
+	infraConfig.APIURL = urlOrDefault(data.Get("infra_api_url").(string), urls.infra)
 	log.Println("[INFO] Initializing New Relic Infra client")
 
 	clientInfra, err := infraConfig.ClientInfra()
@@ -89,7 +275,62 @@ func providerConfigure(data *schema.ResourceData) (interface{}, error) {
 		Client:      client,
 		InfraClient: clientInfra,
 		Synthetics:  clientSynthetics,
+		config:      config,
+		infraConfig: infraConfig,
+
+		preventDestroyWithConditions: data.Get("prevent_destroy_with_conditions").(bool),
+		uniquePolicyNames:            data.Get("unique_policy_names").(bool),
+		accountID:                    data.Get("account_id").(int),
+		uiDomain:                     urls.ui,
+		validateNrql:                 data.Get("validate_nrql").(bool),
+		defaultTags:                  data.Get("default_tags").(map[string]interface{}),
+	}
+
+	if !data.Get("skip_validation").(bool) {
+		log.Println("[INFO] Validating New Relic credentials")
+
+		if err := providerConfig.validateCredentials(data.Get("region").(string)); err != nil {
+			return nil, err
+		}
 	}
 
 	return &providerConfig, nil
 }
+
+// readAPIKeyFile reads an API key from a file, ignoring trailing whitespace
+// such as the newline written by echo.
+func readAPIKeyFile(path string) (string, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("Error reading api_key_file: %s", err)
+	}
+
+	apiKey := strings.TrimRightFunc(string(b), unicode.IsSpace)
+	if apiKey == "" {
+		return "", fmt.Errorf("api_key_file %s is empty", path)
+	}
+
+	return apiKey, nil
+}
+
+// envIntDefaultFunc is like schema.MultiEnvDefaultFunc for integer
+// attributes: the first of keys that is set is used.
+func envIntDefaultFunc(keys ...string) schema.SchemaDefaultFunc {
+	return func() (interface{}, error) {
+		for _, key := range keys {
+			v := os.Getenv(key)
+			if v == "" {
+				continue
+			}
+
+			i, err := strconv.Atoi(v)
+			if err != nil {
+				return nil, fmt.Errorf("Error parsing %s: %s", key, err)
+			}
+
+			return i, nil
+		}
+
+		return nil, nil
+	}
+}
@@ -2,7 +2,11 @@ package newrelic
 
 import (
 	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -40,6 +44,236 @@ func TestProviderImpl(t *testing.T) {
 	var _ terraform.ResourceProvider = Provider()
 }
 
+func TestReadAPIKeyFile(t *testing.T) {
+	f, err := ioutil.TempFile("", "newrelic-api-key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.WriteString("abc123\n"); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	apiKey, err := readAPIKeyFile(f.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if apiKey != "abc123" {
+		t.Fatalf("expected abc123, got %q", apiKey)
+	}
+}
+
+func TestReadAPIKeyFile_empty(t *testing.T) {
+	f, err := ioutil.TempFile("", "newrelic-api-key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.WriteString(" \n"); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	if _, err := readAPIKeyFile(f.Name()); err == nil {
+		t.Fatal("expected an error for an empty api_key_file")
+	}
+}
+
+func TestReadAPIKeyFile_missing(t *testing.T) {
+	if _, err := readAPIKeyFile("/nonexistent/newrelic-api-key"); err == nil {
+		t.Fatal("expected an error for a missing api_key_file")
+	}
+}
+
+func TestProviderConfigure_region(t *testing.T) {
+	for _, key := range []string{"NEWRELIC_REGION", "NEWRELIC_API_URL", "NEWRELIC_INFRA_API_URL", "NEWRELIC_SYNTHETICS_API_URL"} {
+		if os.Getenv(key) != "" {
+			t.Skipf("Environment variable %s is set", key)
+		}
+	}
+
+	cases := []struct {
+		raw       map[string]interface{}
+		apiURL    string
+		infraURL  string
+		synthURL  string
+		graphQURL string
+	}{
+		{
+			raw:       map[string]interface{}{"api_key": "foo", "skip_validation": true},
+			apiURL:    "https://api.newrelic.com/v2",
+			infraURL:  "https://infra-api.newrelic.com/v2",
+			synthURL:  "https://synthetics.newrelic.com/synthetics/api",
+			graphQURL: "https://api.newrelic.com/graphql",
+		},
+		{
+			raw:       map[string]interface{}{"api_key": "foo", "skip_validation": true, "region": "EU"},
+			apiURL:    "https://api.eu.newrelic.com/v2",
+			infraURL:  "https://infra-api.eu.newrelic.com/v2",
+			synthURL:  "https://synthetics.eu.newrelic.com/synthetics/api",
+			graphQURL: "https://api.eu.newrelic.com/graphql",
+		},
+		{
+			raw:       map[string]interface{}{"api_key": "foo", "skip_validation": true, "region": "EU", "api_url": "https://proxy.example.com/v2"},
+			apiURL:    "https://proxy.example.com/v2",
+			infraURL:  "https://infra-api.eu.newrelic.com/v2",
+			synthURL:  "https://synthetics.eu.newrelic.com/synthetics/api",
+			graphQURL: "https://proxy.example.com/graphql",
+		},
+	}
+
+	for _, c := range cases {
+		d := schema.TestResourceDataRaw(t, Provider().(*schema.Provider).Schema, c.raw)
+
+		meta, err := providerConfigure(d)
+		if err != nil {
+			t.Fatal(err)
+		}
+		p := meta.(*ProviderConfig)
+
+		if p.config.APIURL != c.apiURL || p.infraConfig.APIURL != c.infraURL || p.config.SyntheticsAPIURL != c.synthURL {
+			t.Fatalf("expected %s, %s and %s for %v, got %s, %s and %s", c.apiURL, c.infraURL, c.synthURL, c.raw,
+				p.config.APIURL, p.infraConfig.APIURL, p.config.SyntheticsAPIURL)
+		}
+
+		if u := nerdGraphURLFor(p.Client); u != c.graphQURL {
+			t.Fatalf("expected NerdGraph URL %s for %v, got %s", c.graphQURL, c.raw, u)
+		}
+	}
+}
+
+func TestProviderConfigure_validation(t *testing.T) {
+	status := http.StatusOK
+	requests := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		w.Write([]byte(`{"applications":[]}`))
+	}))
+	defer server.Close()
+
+	configure := func(raw map[string]interface{}) error {
+		raw["api_key"] = "foo"
+		raw["api_url"] = server.URL
+		raw["max_retries"] = 0
+
+		_, err := providerConfigure(schema.TestResourceDataRaw(t, Provider().(*schema.Provider).Schema, raw))
+		return err
+	}
+
+	if err := configure(map[string]interface{}{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if requests != 1 {
+		t.Fatalf("expected a single request, got %d", requests)
+	}
+
+	status = http.StatusUnauthorized
+	if err := configure(map[string]interface{}{"region": "EU"}); err == nil || !strings.Contains(err.Error(), "New Relic rejected the provider API key") || !strings.Contains(err.Error(), "in the EU region") {
+		t.Fatalf("expected the API key to be rejected, got %q", err)
+	}
+
+	// Nothing is requested when validation is skipped, e.g. to plan offline.
+	requests = 0
+	if err := configure(map[string]interface{}{"skip_validation": true}); err != nil || requests != 0 {
+		t.Fatalf("expected no request, got %d and %v", requests, err)
+	}
+
+	server.Close()
+	if err := configure(map[string]interface{}{}); err == nil || !strings.HasPrefix(err.Error(), "Error connecting to the New Relic API at "+server.URL) {
+		t.Fatalf("expected a connection error, got %q", err)
+	}
+}
+
+func TestProviderConfigPermalink(t *testing.T) {
+	for _, key := range []string{"NEWRELIC_REGION", "NEWRELIC_ACCOUNT_ID", "NEW_RELIC_ACCOUNT_ID"} {
+		if os.Getenv(key) != "" {
+			t.Skipf("Environment variable %s is set", key)
+		}
+	}
+
+	policy := func(p *ProviderConfig, raw map[string]interface{}) string {
+		d := schema.TestResourceDataRaw(t, resourceNewRelicAlertPolicy().Schema, raw)
+		return p.permalink(d, "alerts", "policies/123")
+	}
+
+	for _, c := range []struct {
+		raw       map[string]interface{}
+		permalink string
+	}{
+		{
+			raw:       map[string]interface{}{"api_key": "foo", "skip_validation": true, "account_id": 1},
+			permalink: "https://alerts.newrelic.com/accounts/1/policies/123",
+		},
+		{
+			raw:       map[string]interface{}{"api_key": "foo", "skip_validation": true, "account_id": 1, "region": "EU"},
+			permalink: "https://alerts.eu.newrelic.com/accounts/1/policies/123",
+		},
+		{
+			raw: map[string]interface{}{"api_key": "foo", "skip_validation": true},
+		},
+	} {
+		d := schema.TestResourceDataRaw(t, Provider().(*schema.Provider).Schema, c.raw)
+
+		meta, err := providerConfigure(d)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if permalink := policy(meta.(*ProviderConfig), map[string]interface{}{"name": "foo"}); permalink != c.permalink {
+			t.Fatalf("expected %q for %v, got %q", c.permalink, c.raw, permalink)
+		}
+
+		// The account of a resource-level API key isn't known.
+		if permalink := policy(meta.(*ProviderConfig), map[string]interface{}{"name": "foo", "api_key": "bar"}); permalink != "" {
+			t.Fatalf("expected no permalink for a resource-level API key, got %q", permalink)
+		}
+	}
+}
+
+func TestProviderConfigure_accountIDEnv(t *testing.T) {
+	for _, key := range []string{"NEWRELIC_ACCOUNT_ID", "NEW_RELIC_ACCOUNT_ID"} {
+		if os.Getenv(key) != "" {
+			t.Skipf("Environment variable %s is set", key)
+		}
+	}
+
+	accountID := func() (interface{}, error) {
+		return Provider().(*schema.Provider).Schema["account_id"].DefaultFunc()
+	}
+
+	if v, err := accountID(); err != nil || v != nil {
+		t.Fatalf("expected no default account_id, got %v and %v", v, err)
+	}
+
+	os.Setenv("NEW_RELIC_ACCOUNT_ID", "123")
+	defer os.Unsetenv("NEW_RELIC_ACCOUNT_ID")
+
+	if v, err := accountID(); err != nil || v != 123 {
+		t.Fatalf("expected account_id 123 from NEW_RELIC_ACCOUNT_ID, got %v and %v", v, err)
+	}
+
+	// NEWRELIC_ACCOUNT_ID, like the provider's other variables, comes first.
+	os.Setenv("NEWRELIC_ACCOUNT_ID", "456")
+	defer os.Unsetenv("NEWRELIC_ACCOUNT_ID")
+
+	if v, err := accountID(); err != nil || v != 456 {
+		t.Fatalf("expected account_id 456 from NEWRELIC_ACCOUNT_ID, got %v and %v", v, err)
+	}
+
+	os.Setenv("NEWRELIC_ACCOUNT_ID", "foo")
+	if _, err := accountID(); err == nil {
+		t.Fatal("expected an error for a non-numeric NEWRELIC_ACCOUNT_ID")
+	}
+}
+
 func testAccPreCheck(t *testing.T) {
 	if v := os.Getenv("NEWRELIC_API_KEY"); v == "" {
 		t.Log(v)
@@ -71,3 +305,16 @@ func testAccPreCheck(t *testing.T) {
 		t.Fatal("NEWRELIC_LICENSE_KEY must be set for acceptance tests")
 	}
 }
+
+// testAccAccountID returns the account ID used by tests that need one, and
+// skips the test when it isn't set.
+func testAccAccountID(t *testing.T) string {
+	for _, key := range []string{"NEWRELIC_ACCOUNT_ID", "NEW_RELIC_ACCOUNT_ID"} {
+		if accountID := os.Getenv(key); accountID != "" {
+			return accountID
+		}
+	}
+
+	t.Skip("Environment variable NEWRELIC_ACCOUNT_ID is not set")
+	return ""
+}
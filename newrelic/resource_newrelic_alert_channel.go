@@ -1,9 +1,16 @@
 package newrelic
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"log"
+	"net/mail"
+	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/helper/validation"
@@ -28,10 +35,12 @@ var alertChannelTypes = map[string][]string{
 	"opsgenie": {
 		"api_key",
 		"recipients",
+		"region",
 		"tags",
 		"teams",
 	},
 	"pagerduty": {
+		"api_key",
 		"service_key",
 	},
 	"slack": {
@@ -51,11 +60,34 @@ var alertChannelTypes = map[string][]string{
 		"auth_username",
 		"base_url",
 		"headers",
+		"payload_file",
 		"payload_type",
 		"payload",
 	},
 }
 
+// alertChannelRequiredKeys are the configuration keys each channel type
+// can't be created without.
+var alertChannelRequiredKeys = map[string][]string{
+	"campfire":  {"room", "subdomain", "token"},
+	"email":     {"recipients"},
+	"hipchat":   {"auth_token", "room_id"},
+	"opsgenie":  {"api_key"},
+	"pagerduty": {"service_key"},
+	"slack":     {"url"},
+	"user":      {"user_id"},
+	"victorops": {"key", "route_key"},
+	"webhook":   {"base_url"},
+}
+
+// alertChannelSecretKeys are the configuration keys of each channel type that
+// the API never returns.
+var alertChannelSecretKeys = map[string][]string{
+	"opsgenie":  {"api_key"},
+	"pagerduty": {"service_key", "api_key"},
+	"victorops": {"key"},
+}
+
 func resourceNewRelicAlertChannel() *schema.Resource {
 	validAlertChannelTypes := make([]string, 0, len(alertChannelTypes))
 	for k := range alertChannelTypes {
@@ -65,12 +97,21 @@ func resourceNewRelicAlertChannel() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceNewRelicAlertChannelCreate,
 		Read:   resourceNewRelicAlertChannelRead,
-		// Update: Not currently supported in API
+		// The API can't update channels, only user_email changes in place.
+		Update: resourceNewRelicAlertChannelUpdate,
 		Delete: resourceNewRelicAlertChannelDelete,
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		SchemaVersion: 1,
+		MigrateState:  resourceNewRelicAlertChannelMigrateState,
 		Schema: map[string]*schema.Schema{
+			"api_key": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				ForceNew:  true,
+				Sensitive: true,
+			},
 			"name": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -82,17 +123,282 @@ func resourceNewRelicAlertChannel() *schema.Resource {
 				ForceNew:     true,
 				ValidateFunc: validation.StringInSlice(validAlertChannelTypes, false),
 			},
+			"user_email": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateAlertChannelUserEmail,
+			},
+			"payload_file_sha256": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 			"configuration": {
-				Type:     schema.TypeMap,
-				Required: true,
-				ForceNew: true,
+				Type:          schema.TypeMap,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"config"},
 				//TODO: ValidateFunc: (use list of keys from map above)
 				Sensitive: true,
 			},
+			"config": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				ForceNew:      true,
+				MaxItems:      1,
+				ConflictsWith: []string{"configuration"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"base_url": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+						"payload_type": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ForceNew:     true,
+							ValidateFunc: validation.StringInSlice([]string{"application/json", "application/x-www-form-urlencoded"}, false),
+						},
+						"payload": {
+							Type:          schema.TypeMap,
+							Optional:      true,
+							ForceNew:      true,
+							ConflictsWith: []string{"config.0.payload_file"},
+						},
+						"payload_file": {
+							Type:          schema.TypeString,
+							Optional:      true,
+							ForceNew:      true,
+							ConflictsWith: []string{"config.0.payload"},
+						},
+						"headers": {
+							Type:             schema.TypeMap,
+							Optional:         true,
+							ForceNew:         true,
+							DiffSuppressFunc: suppressWriteOnlyWebhookHeaders,
+						},
+						"auth_username": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+						"auth_password": {
+							Type:      schema.TypeString,
+							Optional:  true,
+							ForceNew:  true,
+							Sensitive: true,
+						},
+						"recipients": {
+							Type:          schema.TypeList,
+							Elem:          &schema.Schema{Type: schema.TypeString},
+							Optional:      true,
+							ForceNew:      true,
+							ConflictsWith: []string{"config.0.recipients_string"},
+						},
+						"recipients_string": {
+							Type:          schema.TypeString,
+							Optional:      true,
+							ForceNew:      true,
+							ConflictsWith: []string{"config.0.recipients"},
+						},
+						"include_json_attachment": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							ForceNew: true,
+							Default:  false,
+						},
+						"service_key": {
+							Type:      schema.TypeString,
+							Optional:  true,
+							ForceNew:  true,
+							Sensitive: true,
+						},
+						"api_key": {
+							Type:      schema.TypeString,
+							Optional:  true,
+							ForceNew:  true,
+							Sensitive: true,
+						},
+						"url": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ForceNew:     true,
+							Sensitive:    true,
+							ValidateFunc: validateSlackWebhookURL,
+						},
+						"channel": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+						"teams": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+						"tags": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+						"region": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ForceNew:     true,
+							ValidateFunc: validation.StringInSlice([]string{"US", "EU"}, false),
+						},
+						"key": {
+							Type:      schema.TypeString,
+							Optional:  true,
+							ForceNew:  true,
+							Sensitive: true,
+						},
+						"route_key": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+					},
+				},
+			},
 		},
+		CustomizeDiff: validateAlertChannelConfig,
 	}
 }
 
+// validateAlertChannelConfig requires one of configuration or config, and
+// only allows the config block, which models the nested payload and headers
+// of webhooks and the typed settings of email, OpsGenie, PagerDuty, Slack and
+// VictorOps channels, for those channel types. The keys each channel type
+// requires must be set, and config can't set keys of other channel types.
+func validateAlertChannelConfig(d *schema.ResourceDiff, meta interface{}) error {
+	_, hasConfiguration := d.GetOk("configuration")
+	_, hasConfig := d.GetOk("config")
+	channelType := d.Get("type").(string)
+
+	if !hasConfiguration && !hasConfig && d.NewValueKnown("configuration") && d.NewValueKnown("config") {
+		return fmt.Errorf("one of configuration or config must be set")
+	}
+
+	if hasConfig {
+		switch channelType {
+		case "webhook":
+			if d.NewValueKnown("config.0.base_url") && d.Get("config.0.base_url").(string) == "" {
+				return fmt.Errorf("config.0.base_url is required for webhook alert channels")
+			}
+			if err := diffAlertChannelPayloadFile(d); err != nil {
+				return err
+			}
+		case "email":
+			if !d.NewValueKnown("config.0.recipients") || !d.NewValueKnown("config.0.recipients_string") {
+				break
+			}
+			config, _ := d.Get("config").([]interface{})[0].(map[string]interface{})
+			recipients := expandAlertChannelRecipients(config)
+			if recipients == "" {
+				return fmt.Errorf("config.0.recipients or config.0.recipients_string is required for email alert channels")
+			}
+			if _, es := validateEmailList(recipients, "config.0.recipients"); len(es) > 0 {
+				return es[0]
+			}
+		case "opsgenie":
+			if d.NewValueKnown("config.0.api_key") && strings.TrimSpace(d.Get("config.0.api_key").(string)) == "" {
+				return fmt.Errorf("config.0.api_key is required for opsgenie alert channels")
+			}
+		case "pagerduty":
+			if d.NewValueKnown("config.0.service_key") && strings.TrimSpace(d.Get("config.0.service_key").(string)) == "" {
+				return fmt.Errorf("config.0.service_key is required for pagerduty alert channels")
+			}
+		case "slack":
+			if d.NewValueKnown("config.0.url") && d.Get("config.0.url").(string) == "" {
+				return fmt.Errorf("config.0.url is required for slack alert channels")
+			}
+		case "victorops":
+			for _, k := range []string{"config.0.key", "config.0.route_key"} {
+				if d.NewValueKnown(k) && strings.TrimSpace(d.Get(k).(string)) == "" {
+					return fmt.Errorf("%s is required for victorops alert channels", k)
+				}
+			}
+		default:
+			return fmt.Errorf("config is only supported for webhook, email, opsgenie, pagerduty, slack and victorops alert channels, use configuration instead")
+		}
+
+		if err := validateAlertChannelConfigKeys(d, channelType); err != nil {
+			return err
+		}
+	}
+
+	if hasConfiguration && d.NewValueKnown("configuration") {
+		configuration := d.Get("configuration").(map[string]interface{})
+
+		for _, k := range alertChannelRequiredKeys[channelType] {
+			if v, _ := configuration[k].(string); strings.TrimSpace(v) == "" {
+				return fmt.Errorf("configuration.%s is required for %s alert channels", k, channelType)
+			}
+		}
+
+		switch channelType {
+		case "email":
+			if _, es := validateEmailList(configuration["recipients"].(string), "configuration.recipients"); len(es) > 0 {
+				return es[0]
+			}
+		case "slack":
+			if _, es := validateSlackWebhookURL(configuration["url"].(string), "configuration.url"); len(es) > 0 {
+				return es[0]
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateAlertChannelUserEmail accepts the email address of the user a
+// channel is attributed to. The alert channels API has no owner, so the
+// address can only be kept in state, which is surfaced as a warning.
+func validateAlertChannelUserEmail(i interface{}, k string) (ws []string, es []error) {
+	v, ok := i.(string)
+	if !ok {
+		es = append(es, fmt.Errorf("expected type of %s to be string", k))
+		return
+	}
+
+	if addr, err := mail.ParseAddress(v); err != nil || addr.Address != v {
+		es = append(es, fmt.Errorf("expected %s to be an email address, got %q", k, v))
+		return
+	}
+
+	ws = append(ws, fmt.Sprintf("%s is only recorded in the Terraform state: the New Relic alert channels API doesn't support setting who owns a channel, so New Relic attributes it to the user of the API key", k))
+	return
+}
+
+// validateAlertChannelConfigKeys rejects the keys of the config block that
+// don't apply to the channel type, such as a url on an email channel.
+func validateAlertChannelConfigKeys(d *schema.ResourceDiff, channelType string) error {
+	supported := map[string]bool{}
+	for _, k := range alertChannelTypes[channelType] {
+		supported[k] = true
+	}
+
+	// recipients_string is the other way of setting recipients.
+	supported["recipients_string"] = supported["recipients"]
+
+	unsupported := []string{}
+	for k := range resourceNewRelicAlertChannel().Schema["config"].Elem.(*schema.Resource).Schema {
+		if !supported[k] {
+			unsupported = append(unsupported, k)
+		}
+	}
+	sort.Strings(unsupported)
+
+	for _, k := range unsupported {
+		if _, ok := d.GetOk("config.0." + k); ok {
+			return fmt.Errorf("config.0.%s is not supported for %s alert channels", k, channelType)
+		}
+	}
+
+	return nil
+}
+
 func buildAlertChannelStruct(d *schema.ResourceData) *newrelic.AlertChannel {
 	channel := newrelic.AlertChannel{
 		Name:          d.Get("name").(string),
@@ -100,16 +406,363 @@ func buildAlertChannelStruct(d *schema.ResourceData) *newrelic.AlertChannel {
 		Configuration: d.Get("configuration").(map[string]interface{}),
 	}
 
+	if attr, ok := d.GetOk("config"); ok {
+		config := attr.([]interface{})[0].(map[string]interface{})
+
+		switch channel.Type {
+		case "email":
+			channel.Configuration = expandAlertChannelEmailConfig(config)
+		case "opsgenie":
+			channel.Configuration = expandAlertChannelOpsGenieConfig(config)
+		case "pagerduty":
+			channel.Configuration = expandAlertChannelPagerDutyConfig(config)
+		case "slack":
+			channel.Configuration = expandAlertChannelSlackConfig(config)
+		case "victorops":
+			channel.Configuration = expandAlertChannelVictorOpsConfig(config)
+		default:
+			channel.Configuration = expandAlertChannelWebhookConfig(config)
+		}
+	}
+
 	return &channel
 }
 
+func expandAlertChannelWebhookConfig(config map[string]interface{}) map[string]interface{} {
+	configuration := map[string]interface{}{
+		"base_url": config["base_url"],
+	}
+
+	for _, k := range []string{"payload_type", "auth_username", "auth_password"} {
+		if v, ok := config[k].(string); ok && v != "" {
+			configuration[k] = v
+		}
+	}
+
+	for _, k := range []string{"payload", "headers"} {
+		if v, ok := config[k].(map[string]interface{}); ok && len(v) > 0 {
+			configuration[k] = v
+		}
+	}
+
+	return configuration
+}
+
+// readAlertChannelPayloadFile reads the webhook payload template at path and
+// returns it along with the SHA256 checksum of the file. The template must be
+// a JSON object, and its New Relic variables such as $CONDITION_NAME are sent
+// as they are for New Relic to substitute.
+func readAlertChannelPayloadFile(path string) (map[string]interface{}, string, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, "", fmt.Errorf("Error reading config.0.payload_file: %s", err)
+	}
+
+	var payload map[string]interface{}
+	if err := json.Unmarshal(b, &payload); err != nil {
+		return nil, "", fmt.Errorf("config.0.payload_file %s must contain a JSON object: %s", path, err)
+	}
+
+	sum := sha256.Sum256(b)
+	return payload, hex.EncodeToString(sum[:]), nil
+}
+
+// diffAlertChannelPayloadFile checks the payload template of a webhook and
+// tracks its contents in payload_file_sha256. The API can't update channels,
+// so a change to the contents of the file replaces the channel.
+func diffAlertChannelPayloadFile(d *schema.ResourceDiff) error {
+	if !d.NewValueKnown("config.0.payload_file") {
+		return d.SetNewComputed("payload_file_sha256")
+	}
+
+	var sum string
+	if path := d.Get("config.0.payload_file").(string); path != "" {
+		var err error
+		if _, sum, err = readAlertChannelPayloadFile(path); err != nil {
+			return err
+		}
+	}
+
+	old := d.Get("payload_file_sha256").(string)
+	if sum == old {
+		return nil
+	}
+
+	if err := d.SetNew("payload_file_sha256", sum); err != nil {
+		return err
+	}
+
+	// Channels recorded without a checksum only start tracking it.
+	if old == "" {
+		return nil
+	}
+
+	return d.ForceNew("payload_file_sha256")
+}
+
+// flattenAlertChannelWebhookConfig converts the channel configuration returned
+// by the API into the config block. The API never returns auth_password, so
+// the value from the current state is kept. The same goes for the values of
+// headers the API returns without one, such as authorization headers. The
+// payload of a channel whose payload is read from payload_file isn't read
+// back, since it's managed through the file.
+func flattenAlertChannelWebhookConfig(configuration map[string]interface{}, d *schema.ResourceData) []interface{} {
+	config := map[string]interface{}{
+		"auth_password": d.Get("config.0.auth_password").(string),
+		"payload_file":  d.Get("config.0.payload_file").(string),
+	}
+
+	for _, k := range []string{"base_url", "payload_type", "auth_username"} {
+		if v, ok := configuration[k]; ok && v != nil {
+			config[k] = fmt.Sprint(v)
+		}
+	}
+
+	if v, ok := configuration["payload"].(map[string]interface{}); ok && config["payload_file"] == "" {
+		m := make(map[string]interface{}, len(v))
+		for mk, mv := range v {
+			m[mk] = fmt.Sprint(mv)
+		}
+		config["payload"] = m
+	}
+
+	if v, ok := configuration["headers"].(map[string]interface{}); ok {
+		headers := d.Get("config.0.headers").(map[string]interface{})
+
+		m := make(map[string]interface{}, len(v))
+		for mk, mv := range v {
+			if mv == nil || mv == "" {
+				m[mk], _ = headers[mk].(string)
+				continue
+			}
+			m[mk] = fmt.Sprint(mv)
+		}
+		config["headers"] = m
+	}
+
+	return []interface{}{config}
+}
+
+// suppressWriteOnlyWebhookHeaders hides the diff of a header whose value the
+// API didn't return, which is the case for headers read back on import, as
+// long as the header is still declared.
+func suppressWriteOnlyWebhookHeaders(k, old, new string, d *schema.ResourceData) bool {
+	if strings.HasSuffix(k, ".%") || old != "" || new == "" {
+		return false
+	}
+
+	o, _ := d.GetChange("config.0.headers")
+	_, ok := o.(map[string]interface{})[strings.TrimPrefix(k, "config.0.headers.")]
+
+	return ok
+}
+
+// expandAlertChannelRecipients returns the recipients of the config block in
+// the comma separated form the API expects.
+func expandAlertChannelRecipients(config map[string]interface{}) string {
+	if recipients, ok := config["recipients"].([]interface{}); ok && len(recipients) > 0 {
+		vs := make([]string, 0, len(recipients))
+		for _, r := range recipients {
+			if r, ok := r.(string); ok {
+				vs = append(vs, r)
+			}
+		}
+		return strings.Join(vs, ",")
+	}
+
+	v, _ := config["recipients_string"].(string)
+	return v
+}
+
+// flattenAlertChannelRecipients sets the comma separated recipients returned
+// by the API in config, as recipients_string when that's how they're
+// configured and split into recipients otherwise.
+func flattenAlertChannelRecipients(config map[string]interface{}, recipients interface{}, d *schema.ResourceData) {
+	if recipients == nil {
+		return
+	}
+
+	if d.Get("config.0.recipients_string").(string) != "" {
+		config["recipients_string"] = fmt.Sprint(recipients)
+		return
+	}
+
+	vs := []interface{}{}
+	for _, r := range strings.Split(fmt.Sprint(recipients), ",") {
+		if r = strings.TrimSpace(r); r != "" {
+			vs = append(vs, r)
+		}
+	}
+	config["recipients"] = vs
+}
+
+func expandAlertChannelEmailConfig(config map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"recipients":              expandAlertChannelRecipients(config),
+		"include_json_attachment": strconv.FormatBool(config["include_json_attachment"].(bool)),
+	}
+}
+
+// flattenAlertChannelEmailConfig converts the configuration of an email
+// channel into the config block. The API may return include_json_attachment
+// as a boolean or as a string such as "1" or "true".
+func flattenAlertChannelEmailConfig(configuration map[string]interface{}, d *schema.ResourceData) []interface{} {
+	config := map[string]interface{}{
+		"include_json_attachment": false,
+	}
+
+	flattenAlertChannelRecipients(config, configuration["recipients"], d)
+
+	if v, ok := configuration["include_json_attachment"]; ok && v != nil {
+		if b, err := strconv.ParseBool(fmt.Sprint(v)); err == nil {
+			config["include_json_attachment"] = b
+		}
+	}
+
+	return []interface{}{config}
+}
+
+func expandAlertChannelPagerDutyConfig(config map[string]interface{}) map[string]interface{} {
+	configuration := map[string]interface{}{
+		"service_key": config["service_key"],
+	}
+
+	if v, ok := config["api_key"].(string); ok && v != "" {
+		configuration["api_key"] = v
+	}
+
+	return configuration
+}
+
+// flattenAlertChannelPagerDutyConfig returns the config block of a PagerDuty
+// channel. The API never returns the service or API key, so both are kept
+// from the current state.
+func flattenAlertChannelPagerDutyConfig(d *schema.ResourceData) []interface{} {
+	return []interface{}{
+		map[string]interface{}{
+			"service_key":             d.Get("config.0.service_key").(string),
+			"api_key":                 d.Get("config.0.api_key").(string),
+			"include_json_attachment": false,
+		},
+	}
+}
+
+func expandAlertChannelSlackConfig(config map[string]interface{}) map[string]interface{} {
+	configuration := map[string]interface{}{
+		"url": config["url"],
+	}
+
+	if v, ok := config["channel"].(string); ok && v != "" {
+		configuration["channel"] = v
+	}
+
+	return configuration
+}
+
+// flattenAlertChannelSlackConfig returns the config block of a Slack channel.
+// The webhook URL is kept from the current state when the API omits it.
+func flattenAlertChannelSlackConfig(configuration map[string]interface{}, d *schema.ResourceData) []interface{} {
+	config := map[string]interface{}{
+		"url":                     d.Get("config.0.url").(string),
+		"include_json_attachment": false,
+	}
+
+	if v, ok := configuration["url"]; ok && v != nil && v != "" {
+		config["url"] = fmt.Sprint(v)
+	}
+
+	if v, ok := configuration["channel"]; ok && v != nil {
+		config["channel"] = fmt.Sprint(v)
+	}
+
+	return []interface{}{config}
+}
+
+func expandAlertChannelOpsGenieConfig(config map[string]interface{}) map[string]interface{} {
+	configuration := map[string]interface{}{
+		"api_key": config["api_key"],
+	}
+
+	for _, k := range []string{"teams", "tags", "region"} {
+		if v, ok := config[k].(string); ok && v != "" {
+			configuration[k] = v
+		}
+	}
+
+	if v := expandAlertChannelRecipients(config); v != "" {
+		configuration["recipients"] = v
+	}
+
+	return configuration
+}
+
+// flattenAlertChannelOpsGenieConfig returns the config block of an OpsGenie
+// channel. The API never returns the API key, so it is kept from the current
+// state.
+func flattenAlertChannelOpsGenieConfig(configuration map[string]interface{}, d *schema.ResourceData) []interface{} {
+	config := map[string]interface{}{
+		"api_key":                 d.Get("config.0.api_key").(string),
+		"include_json_attachment": false,
+	}
+
+	for _, k := range []string{"teams", "tags", "region"} {
+		if v, ok := configuration[k]; ok && v != nil {
+			config[k] = fmt.Sprint(v)
+		}
+	}
+
+	flattenAlertChannelRecipients(config, configuration["recipients"], d)
+
+	return []interface{}{config}
+}
+
+func expandAlertChannelVictorOpsConfig(config map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"key":       config["key"],
+		"route_key": config["route_key"],
+	}
+}
+
+// flattenAlertChannelVictorOpsConfig returns the config block of a VictorOps
+// channel. The API never returns the key, so it is kept from the current
+// state.
+func flattenAlertChannelVictorOpsConfig(configuration map[string]interface{}, d *schema.ResourceData) []interface{} {
+	config := map[string]interface{}{
+		"key":                     d.Get("config.0.key").(string),
+		"route_key":               d.Get("config.0.route_key").(string),
+		"include_json_attachment": false,
+	}
+
+	if v, ok := configuration["route_key"]; ok && v != nil {
+		config["route_key"] = fmt.Sprint(v)
+	}
+
+	return []interface{}{config}
+}
+
 func resourceNewRelicAlertChannelCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).Client
+	client, err := meta.(*ProviderConfig).clientFor(d)
+	if err != nil {
+		return err
+	}
+
 	channel := buildAlertChannelStruct(d)
 
+	if path, ok := d.GetOk("config.0.payload_file"); ok && channel.Type == "webhook" {
+		payload, sum, err := readAlertChannelPayloadFile(path.(string))
+		if err != nil {
+			return err
+		}
+
+		// The API only accepts the payload as an object, so the template
+		// is sent as the payload rather than as a string.
+		channel.Configuration["payload"] = payload
+		d.Set("payload_file_sha256", sum)
+	}
+
 	log.Printf("[INFO] Creating New Relic alert channel %s", channel.Name)
 
-	channel, err := client.CreateAlertChannel(*channel)
+	channel, err = client.CreateAlertChannel(*channel)
 	if err != nil {
 		return err
 	}
@@ -120,7 +773,10 @@ func resourceNewRelicAlertChannelCreate(d *schema.ResourceData, meta interface{}
 }
 
 func resourceNewRelicAlertChannelRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).Client
+	client, err := meta.(*ProviderConfig).clientFor(d)
+	if err != nil {
+		return err
+	}
 
 	id, err := strconv.ParseInt(d.Id(), 10, 32)
 	if err != nil {
@@ -141,6 +797,50 @@ func resourceNewRelicAlertChannelRead(d *schema.ResourceData, meta interface{})
 
 	d.Set("name", channel.Name)
 	d.Set("type", channel.Type)
+
+	// Webhooks configured with the config block, or imported, are read back
+	// into the config block since their payload and headers are nested.
+	_, hasConfig := d.GetOk("config")
+	_, hasConfiguration := d.GetOk("configuration")
+	if hasConfig || (channel.Type == "webhook" && !hasConfiguration) {
+		var config []interface{}
+
+		switch channel.Type {
+		case "email":
+			config = flattenAlertChannelEmailConfig(channel.Configuration, d)
+		case "opsgenie":
+			config = flattenAlertChannelOpsGenieConfig(channel.Configuration, d)
+		case "pagerduty":
+			config = flattenAlertChannelPagerDutyConfig(d)
+		case "slack":
+			config = flattenAlertChannelSlackConfig(channel.Configuration, d)
+		case "victorops":
+			config = flattenAlertChannelVictorOpsConfig(channel.Configuration, d)
+		default:
+			config = flattenAlertChannelWebhookConfig(channel.Configuration, d)
+		}
+
+		if err := d.Set("config", config); err != nil {
+			return fmt.Errorf("[DEBUG] Error setting Alert Channel Config: %#v", err)
+		}
+
+		return nil
+	}
+
+	// PagerDuty, OpsGenie and VictorOps keys aren't returned by the API, keep
+	// them from the state to avoid a diff.
+	if keys, ok := alertChannelSecretKeys[channel.Type]; ok {
+		configuration := d.Get("configuration").(map[string]interface{})
+		for _, k := range keys {
+			if v, ok := configuration[k]; ok && channel.Configuration[k] == nil {
+				if channel.Configuration == nil {
+					channel.Configuration = map[string]interface{}{}
+				}
+				channel.Configuration[k] = v
+			}
+		}
+	}
+
 	if err := d.Set("configuration", channel.Configuration); err != nil {
 		return fmt.Errorf("[DEBUG] Error setting Alert Channel Configuration: %#v", err)
 	}
@@ -148,8 +848,17 @@ func resourceNewRelicAlertChannelRead(d *schema.ResourceData, meta interface{})
 	return nil
 }
 
+// resourceNewRelicAlertChannelUpdate applies a change of user_email, which is
+// only kept in state, so there's nothing to send to the API.
+func resourceNewRelicAlertChannelUpdate(d *schema.ResourceData, meta interface{}) error {
+	return resourceNewRelicAlertChannelRead(d, meta)
+}
+
 func resourceNewRelicAlertChannelDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).Client
+	client, err := meta.(*ProviderConfig).clientFor(d)
+	if err != nil {
+		return err
+	}
 
 	id, err := strconv.ParseInt(d.Id(), 10, 32)
 	if err != nil {
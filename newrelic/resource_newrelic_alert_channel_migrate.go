@@ -0,0 +1,47 @@
+package newrelic
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func resourceNewRelicAlertChannelMigrateState(v int, is *terraform.InstanceState, meta interface{}) (*terraform.InstanceState, error) {
+	switch v {
+	case 0:
+		log.Println("[INFO] Found New Relic alert channel state v0; migrating to v1")
+		return migrateAlertChannelStateV0toV1(is)
+	default:
+		return is, fmt.Errorf("Unexpected schema version: %d", v)
+	}
+}
+
+// migrateAlertChannelStateV0toV1 splits the comma separated recipients of the
+// config block, a string in v0, into the recipients list.
+func migrateAlertChannelStateV0toV1(is *terraform.InstanceState) (*terraform.InstanceState, error) {
+	if is.Empty() {
+		log.Println("[DEBUG] Empty InstanceState; nothing to migrate.")
+		return is, nil
+	}
+
+	recipients, ok := is.Attributes["config.0.recipients"]
+	if !ok {
+		return is, nil
+	}
+
+	delete(is.Attributes, "config.0.recipients")
+
+	n := 0
+	for _, r := range strings.Split(recipients, ",") {
+		if r = strings.TrimSpace(r); r != "" {
+			is.Attributes[fmt.Sprintf("config.0.recipients.%d", n)] = r
+			n++
+		}
+	}
+	is.Attributes["config.0.recipients.#"] = strconv.Itoa(n)
+
+	return is, nil
+}
@@ -0,0 +1,55 @@
+package newrelic
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestResourceNewRelicAlertChannelMigrateState(t *testing.T) {
+	cases := map[string]struct {
+		attributes map[string]string
+		expected   map[string]string
+	}{
+		"recipients": {
+			attributes: map[string]string{
+				"type":                "email",
+				"config.#":            "1",
+				"config.0.recipients": "foo@example.com, bar@example.com",
+			},
+			expected: map[string]string{
+				"type":                  "email",
+				"config.#":              "1",
+				"config.0.recipients.#": "2",
+				"config.0.recipients.0": "foo@example.com",
+				"config.0.recipients.1": "bar@example.com",
+			},
+		},
+		"no recipients": {
+			attributes: map[string]string{
+				"type":                     "email",
+				"configuration.%":          "1",
+				"configuration.recipients": "foo@example.com",
+			},
+			expected: map[string]string{
+				"type":                     "email",
+				"configuration.%":          "1",
+				"configuration.recipients": "foo@example.com",
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		is := &terraform.InstanceState{ID: "123", Attributes: tc.attributes}
+
+		is, err := resourceNewRelicAlertChannelMigrateState(0, is, nil)
+		if err != nil {
+			t.Fatalf("%s: %s", name, err)
+		}
+
+		if !reflect.DeepEqual(is.Attributes, tc.expected) {
+			t.Fatalf("%s: expected %v, got %v", name, tc.expected, is.Attributes)
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 	"strconv"
+	"time"
 
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/helper/validation"
@@ -82,12 +83,13 @@ func resourceNewRelicAlertCondition() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(1 * time.Minute),
+		},
 		Schema: map[string]*schema.Schema{
-			"policy_id": {
-				Type:     schema.TypeInt,
-				Required: true,
-				ForceNew: true,
-			},
+			"api_key":     apiKeySchema(),
+			"policy_id":   alertConditionPolicyIDSchema(),
+			"policy_name": alertConditionPolicyNameSchema(),
 			"name": {
 				Type:         schema.TypeString,
 				Required:     true,
@@ -101,6 +103,7 @@ func resourceNewRelicAlertCondition() *schema.Resource {
 			"type": {
 				Type:         schema.TypeString,
 				Required:     true,
+				ForceNew:     true,
 				ValidateFunc: validation.StringInSlice(validAlertConditionTypes, false),
 			},
 			"entities": {
@@ -109,18 +112,21 @@ func resourceNewRelicAlertCondition() *schema.Resource {
 				Required: true,
 				MinItems: 1,
 			},
+			// Validated against the condition's type, see
+			// validateAlertConditionMetric.
 			"metric": {
 				Type:     schema.TypeString,
 				Required: true,
-				//TODO: ValidateFunc from map
 			},
 			"runbook_url": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateRunbookURL,
 			},
 			"condition_scope": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"application", "instance"}, false),
 			},
 			"violation_close_timer": {
 				Type:         schema.TypeInt,
@@ -144,7 +150,7 @@ func resourceNewRelicAlertCondition() *schema.Resource {
 							Type:         schema.TypeString,
 							Optional:     true,
 							Default:      "equal",
-							ValidateFunc: validation.StringInSlice([]string{"above", "below", "equal"}, false),
+							ValidateFunc: validateThresholdOperator,
 						},
 						"priority": {
 							Type:         schema.TypeString,
@@ -167,17 +173,126 @@ func resourceNewRelicAlertCondition() *schema.Resource {
 				Required: true,
 				MinItems: 1,
 			},
+			"user_defined": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				MaxItems:      1,
+				ConflictsWith: []string{"user_defined_metric", "user_defined_value_function"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"metric": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"value_function": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice(alertConditionValueFunctions, false),
+						},
+					},
+				},
+			},
 			"user_defined_metric": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"user_defined"},
 			},
 			"user_defined_value_function": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				ValidateFunc: validation.StringInSlice([]string{"average", "min", "max", "total", "sample_size"}, false),
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"user_defined"},
+				ValidateFunc:  validation.StringInSlice(alertConditionValueFunctions, false),
 			},
 		},
+		CustomizeDiff: validateAlertCondition,
+	}
+}
+
+var alertConditionValueFunctions = []string{"average", "min", "max", "total", "sample_size"}
+
+func validateAlertCondition(d *schema.ResourceDiff, meta interface{}) error {
+	if err := validateAlertConditionMetric(d); err != nil {
+		return err
 	}
+
+	if err := validateAlertConditionViolationCloseTimer(d, meta); err != nil {
+		return err
+	}
+
+	return validateAlertConditionUserDefined(d, meta)
+}
+
+// validateAlertConditionMetric ensures metric is one of the metrics of the
+// condition's type, which selects the product of its entities, e.g. mobile
+// applications for mobile_metric conditions.
+func validateAlertConditionMetric(d *schema.ResourceDiff) error {
+	if !d.NewValueKnown("type") || !d.NewValueKnown("metric") {
+		return nil
+	}
+
+	conditionType := d.Get("type").(string)
+	metric := d.Get("metric").(string)
+
+	metrics, ok := alertConditionTypes[conditionType]
+	if !ok {
+		return nil
+	}
+
+	for _, m := range metrics {
+		if m == metric {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("metric %s is not supported by %s conditions, expected one of %v", metric, conditionType, metrics)
+}
+
+// validateAlertConditionUserDefined requires a custom metric, given either in
+// the user_defined block or user_defined_metric and
+// user_defined_value_function, exactly when metric is user_defined.
+func validateAlertConditionUserDefined(d *schema.ResourceDiff, meta interface{}) error {
+	for _, k := range []string{"metric", "user_defined", "user_defined_metric", "user_defined_value_function"} {
+		if !d.NewValueKnown(k) {
+			return nil
+		}
+	}
+
+	_, hasBlock := d.GetOk("user_defined")
+	_, hasMetric := d.GetOk("user_defined_metric")
+	_, hasValueFunction := d.GetOk("user_defined_value_function")
+
+	if d.Get("metric").(string) != "user_defined" {
+		if hasBlock || hasMetric || hasValueFunction {
+			return fmt.Errorf("user_defined can only be set when metric is user_defined, got metric %s", d.Get("metric"))
+		}
+
+		return nil
+	}
+
+	if !hasBlock && !(hasMetric && hasValueFunction) {
+		return fmt.Errorf("user_defined is required when metric is user_defined")
+	}
+
+	return nil
+}
+
+// validateAlertConditionViolationCloseTimer only allows violation_close_timer
+// on instance scoped conditions, since application scoped violations are not
+// closed by the timer.
+func validateAlertConditionViolationCloseTimer(d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("violation_close_timer") || !d.NewValueKnown("condition_scope") {
+		return nil
+	}
+
+	if _, ok := d.GetOk("violation_close_timer"); !ok {
+		return nil
+	}
+
+	if d.Get("condition_scope").(string) != "instance" {
+		return fmt.Errorf("violation_close_timer requires condition_scope to be instance")
+	}
+
+	return nil
 }
 
 func buildAlertConditionStruct(d *schema.ResourceData) *newrelic.AlertCondition {
@@ -216,11 +331,17 @@ func buildAlertConditionStruct(d *schema.ResourceData) *newrelic.AlertCondition
 		GCMetric:            d.Get("gc_metric").(string),
 	}
 
-	if attr, ok := d.GetOk("runbook_url"); ok {
-		condition.RunbookURL = attr.(string)
-	}
+	// Always sent so that removing the URL or setting it to "" clears it.
+	condition.RunbookURL = d.Get("runbook_url").(string)
 
-	if attrM, ok := d.GetOk("user_defined_metric"); ok {
+	if attr, ok := d.GetOk("user_defined"); ok {
+		userDefined := attr.([]interface{})[0].(map[string]interface{})
+
+		condition.UserDefined = newrelic.AlertConditionUserDefined{
+			Metric:        userDefined["metric"].(string),
+			ValueFunction: userDefined["value_function"].(string),
+		}
+	} else if attrM, ok := d.GetOk("user_defined_metric"); ok {
 		if attrVF, ok := d.GetOk("user_defined_value_function"); ok {
 			condition.UserDefined = newrelic.AlertConditionUserDefined{
 				Metric:        attrM.(string),
@@ -258,8 +379,30 @@ func readAlertConditionStruct(condition *newrelic.AlertCondition, d *schema.Reso
 	d.Set("condition_scope", condition.Scope)
 	d.Set("violation_close_timer", condition.ViolationCloseTimer)
 	d.Set("gc_metric", condition.GCMetric)
-	d.Set("user_defined_metric", condition.UserDefined.Metric)
-	d.Set("user_defined_value_function", condition.UserDefined.ValueFunction)
+
+	// Custom metrics configured with the user_defined block, or imported, are
+	// read back into the block.
+	_, hasUserDefined := d.GetOk("user_defined")
+	_, hasUserDefinedMetric := d.GetOk("user_defined_metric")
+	if hasUserDefined || !hasUserDefinedMetric {
+		var userDefined []interface{}
+		if condition.UserDefined.Metric != "" {
+			userDefined = []interface{}{
+				map[string]interface{}{
+					"metric":         condition.UserDefined.Metric,
+					"value_function": condition.UserDefined.ValueFunction,
+				},
+			}
+		}
+
+		if err := d.Set("user_defined", userDefined); err != nil {
+			return fmt.Errorf("[DEBUG] Error setting alert condition user_defined: %#v", err)
+		}
+	} else {
+		d.Set("user_defined_metric", condition.UserDefined.Metric)
+		d.Set("user_defined_value_function", condition.UserDefined.ValueFunction)
+	}
+
 	if err := d.Set("entities", entities); err != nil {
 		return fmt.Errorf("[DEBUG] Error setting alert condition entities: %#v", err)
 	}
@@ -285,23 +428,42 @@ func readAlertConditionStruct(condition *newrelic.AlertCondition, d *schema.Reso
 }
 
 func resourceNewRelicAlertConditionCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).Client
+	client, err := meta.(*ProviderConfig).clientFor(d)
+	if err != nil {
+		return err
+	}
+
+	if err := resolveAlertConditionPolicy(client, d); err != nil {
+		return err
+	}
+
 	condition := buildAlertConditionStruct(d)
 
 	log.Printf("[INFO] Creating New Relic alert condition %s", condition.Name)
 
-	condition, err := client.CreateAlertCondition(*condition)
+	condition, err = createAlertCondition(client, *condition)
 	if err != nil {
-		return err
+		return alertPolicyNotFoundError(client, d.Get("policy_id").(int), err)
 	}
 
 	d.SetId(serializeIDs([]int{condition.PolicyID, condition.ID}))
 
+	err = waitForCreated(meta.(*ProviderConfig).stopContext(), d.Timeout(schema.TimeoutCreate), func() error {
+		_, err := client.GetAlertCondition(condition.PolicyID, condition.ID)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("Error waiting for New Relic alert condition %d to be created: %s", condition.ID, err)
+	}
+
 	return nil
 }
 
 func resourceNewRelicAlertConditionRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).Client
+	client, err := meta.(*ProviderConfig).clientFor(d)
+	if err != nil {
+		return err
+	}
 
 	log.Printf("[INFO] Reading New Relic alert condition %s", d.Id())
 
@@ -327,7 +489,11 @@ func resourceNewRelicAlertConditionRead(d *schema.ResourceData, meta interface{}
 }
 
 func resourceNewRelicAlertConditionUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).Client
+	client, err := meta.(*ProviderConfig).clientFor(d)
+	if err != nil {
+		return err
+	}
+
 	condition := buildAlertConditionStruct(d)
 
 	ids, err := parseIDs(d.Id(), 2)
@@ -338,21 +504,34 @@ func resourceNewRelicAlertConditionUpdate(d *schema.ResourceData, meta interface
 	policyID := ids[0]
 	id := ids[1]
 
+	if onlyEnabledChanged(d, resourceNewRelicAlertCondition().Schema) {
+		log.Printf("[INFO] Reading New Relic alert condition %d to update enabled", id)
+
+		if condition, err = client.GetAlertCondition(policyID, id); err != nil {
+			return err
+		}
+
+		condition.Enabled = d.Get("enabled").(bool)
+	}
+
 	condition.PolicyID = policyID
 	condition.ID = id
 
 	log.Printf("[INFO] Updating New Relic alert condition %d", id)
 
-	updatedCondition, err := client.UpdateAlertCondition(*condition)
+	updatedCondition, err := updateAlertCondition(client, *condition)
 	if err != nil {
-		return err
+		return alertPolicyNotFoundError(client, policyID, err)
 	}
 
 	return readAlertConditionStruct(updatedCondition, d)
 }
 
 func resourceNewRelicAlertConditionDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).Client
+	client, err := meta.(*ProviderConfig).clientFor(d)
+	if err != nil {
+		return err
+	}
 
 	ids, err := parseIDs(d.Id(), 2)
 	if err != nil {
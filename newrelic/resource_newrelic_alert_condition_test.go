@@ -7,7 +7,9 @@ import (
 
 	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
+	newrelic "github.com/paultyng/go-newrelic/v4/api"
 )
 
 func TestAccNewRelicAlertCondition_Basic(t *testing.T) {
@@ -149,6 +151,231 @@ func TestAccNewRelicAlertCondition_nameGreaterThan64Char(t *testing.T) {
 	})
 }
 
+func TestAccNewRelicAlertCondition_instanceScope(t *testing.T) {
+	rName := acctest.RandString(5)
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNewRelicAlertConditionDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckNewRelicAlertConditionConfigScope(rName, "instance", 24),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicAlertConditionExists("newrelic_alert_condition.foo"),
+					resource.TestCheckResourceAttr(
+						"newrelic_alert_condition.foo", "condition_scope", "instance"),
+					resource.TestCheckResourceAttr(
+						"newrelic_alert_condition.foo", "violation_close_timer", "24"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccNewRelicAlertCondition_invalidConditionScope(t *testing.T) {
+	expectedErrorMsg, _ := regexp.Compile("expected condition_scope to be one of \\[application instance\\], got host")
+	resource.Test(t, resource.TestCase{
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config:      testAccCheckNewRelicAlertConditionConfigScope(acctest.RandString(5), "host", 0),
+				ExpectError: expectedErrorMsg,
+			},
+		},
+	})
+}
+
+func TestAccNewRelicAlertCondition_violationCloseTimerApplicationScope(t *testing.T) {
+	expectedErrorMsg, _ := regexp.Compile("violation_close_timer requires condition_scope to be instance")
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config:      testAccCheckNewRelicAlertConditionConfigScope(acctest.RandString(5), "application", 24),
+				ExpectError: expectedErrorMsg,
+			},
+		},
+	})
+}
+
+func TestAccNewRelicAlertCondition_userDefined(t *testing.T) {
+	resourceName := "newrelic_alert_condition.foo"
+	rName := acctest.RandString(5)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNewRelicAlertConditionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckNewRelicAlertConditionConfigUserDefined(rName, "user_defined", `
+  user_defined {
+    metric         = "Custom/Orders/Placed"
+    value_function = "total"
+  }
+`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicAlertConditionExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "user_defined.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "user_defined.0.metric", "Custom/Orders/Placed"),
+					resource.TestCheckResourceAttr(resourceName, "user_defined.0.value_function", "total"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccCheckNewRelicAlertConditionConfigUserDefined(rName, "user_defined", `
+  user_defined_metric         = "Custom/Orders/Placed"
+  user_defined_value_function = "average"
+`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicAlertConditionExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "user_defined_metric", "Custom/Orders/Placed"),
+					resource.TestCheckResourceAttr(resourceName, "user_defined_value_function", "average"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccNewRelicAlertCondition_userDefinedWithoutUserDefinedMetric(t *testing.T) {
+	expectedErrorMsg, _ := regexp.Compile("user_defined can only be set when metric is user_defined, got metric apdex")
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckNewRelicAlertConditionConfigUserDefined(acctest.RandString(5), "apdex", `
+  user_defined {
+    metric         = "Custom/Orders/Placed"
+    value_function = "total"
+  }
+`),
+				ExpectError: expectedErrorMsg,
+			},
+		},
+	})
+}
+
+func TestAccNewRelicAlertCondition_userDefinedMissing(t *testing.T) {
+	expectedErrorMsg, _ := regexp.Compile("user_defined is required when metric is user_defined")
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCheckNewRelicAlertConditionConfigUserDefined(acctest.RandString(5), "user_defined", ""),
+				ExpectError: expectedErrorMsg,
+			},
+		},
+	})
+}
+
+func TestAccNewRelicAlertCondition_invalidMetric(t *testing.T) {
+	expectedErrorMsg, _ := regexp.Compile("metric end_user_apdex is not supported by apm_app_metric conditions")
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCheckNewRelicAlertConditionConfigUserDefined(acctest.RandString(5), "end_user_apdex", ""),
+				ExpectError: expectedErrorMsg,
+			},
+		},
+	})
+}
+
+func TestReadAlertConditionStruct_userDefined(t *testing.T) {
+	condition := &newrelic.AlertCondition{
+		Metric: "user_defined",
+		UserDefined: newrelic.AlertConditionUserDefined{
+			Metric:        "Custom/Orders/Placed",
+			ValueFunction: "total",
+		},
+	}
+
+	r := resourceNewRelicAlertCondition()
+
+	// Imported conditions are read into the user_defined block.
+	d := r.TestResourceData()
+	d.SetId("123:456")
+
+	if err := readAlertConditionStruct(condition, d); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := buildAlertConditionStruct(d).UserDefined; got != condition.UserDefined {
+		t.Fatalf("expected %#v, got %#v", condition.UserDefined, got)
+	}
+
+	if _, ok := d.GetOk("user_defined_metric"); ok {
+		t.Fatal("expected user_defined_metric to be unset")
+	}
+
+	// Conditions configured with user_defined_metric keep using it.
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"metric":                      "user_defined",
+		"user_defined_metric":         "Custom/Orders/Old",
+		"user_defined_value_function": "average",
+	})
+	d.SetId("123:456")
+
+	if err := readAlertConditionStruct(condition, d); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := buildAlertConditionStruct(d).UserDefined; got != condition.UserDefined {
+		t.Fatalf("expected %#v, got %#v", condition.UserDefined, got)
+	}
+
+	if n := len(d.Get("user_defined").([]interface{})); n != 0 {
+		t.Fatalf("expected the user_defined block to be unset, got %d", n)
+	}
+}
+
+func TestResourceNewRelicAlertConditionUpdate_enabledOnly(t *testing.T) {
+	condition := map[string]interface{}{
+		"id":              456.0,
+		"type":            "apm_app_metric",
+		"name":            "foo",
+		"enabled":         true,
+		"entities":        []interface{}{"789"},
+		"metric":          "apdex",
+		"runbook_url":     "https://foo.example.com",
+		"condition_scope": "application",
+		"user_defined":    map[string]interface{}{},
+		"terms": []interface{}{
+			map[string]interface{}{"duration": "5", "operator": "below", "priority": "critical", "threshold": "0.75", "time_function": "all"},
+		},
+	}
+
+	testConditionEnabledToggle(t, resourceNewRelicAlertCondition(), "conditions", "condition", condition, map[string]interface{}{
+		"policy_id":       123,
+		"name":            "foo",
+		"enabled":         false,
+		"type":            "apm_app_metric",
+		"entities":        []interface{}{789},
+		"metric":          "apdex",
+		"runbook_url":     "https://foo.example.com",
+		"condition_scope": "application",
+		"term": []interface{}{
+			map[string]interface{}{"duration": 5, "operator": "below", "priority": "critical", "threshold": 0.75, "time_function": "all"},
+		},
+	})
+}
+
+func TestResourceNewRelicAlertCondition_immutableAttributes(t *testing.T) {
+	testResourceAttributesForceNew(t, resourceNewRelicAlertCondition(), "policy_id", "policy_name", "type")
+}
+
+func TestResourceNewRelicAlertConditionRead_notFound(t *testing.T) {
+	testResourceReadNotFound(t, resourceNewRelicAlertCondition(), "123:456")
+}
+
 func testAccCheckNewRelicAlertConditionDestroy(s *terraform.State) error {
 	client := testAccProvider.Meta().(*ProviderConfig).Client
 	for _, r := range s.RootModule().Resources {
@@ -301,3 +528,69 @@ resource "newrelic_alert_condition" "foo" {
 }
 `, rName, testAccExpectedApplicationName)
 }
+
+func testAccCheckNewRelicAlertConditionConfigScope(rName string, scope string, violationCloseTimer int) string {
+	timer := ""
+	if violationCloseTimer > 0 {
+		timer = fmt.Sprintf("violation_close_timer = %d", violationCloseTimer)
+	}
+
+	return fmt.Sprintf(`
+data "newrelic_application" "app" {
+	name = "%[2]s"
+}
+
+resource "newrelic_alert_policy" "foo" {
+  name = "tf-test-%[1]s"
+}
+
+resource "newrelic_alert_condition" "foo" {
+  policy_id = "${newrelic_alert_policy.foo.id}"
+
+  name            = "tf-test-%[1]s"
+  type            = "apm_app_metric"
+  entities        = ["${data.newrelic_application.app.id}"]
+  metric          = "apdex"
+  condition_scope = "%[3]s"
+  %[4]s
+
+  term {
+    duration      = 5
+    operator      = "below"
+    priority      = "critical"
+    threshold     = "0.75"
+    time_function = "all"
+  }
+}
+`, rName, testAccExpectedApplicationName, scope, timer)
+}
+
+func testAccCheckNewRelicAlertConditionConfigUserDefined(rName string, metric string, userDefined string) string {
+	return fmt.Sprintf(`
+data "newrelic_application" "app" {
+	name = "%[2]s"
+}
+
+resource "newrelic_alert_policy" "foo" {
+  name = "tf-test-%[1]s"
+}
+
+resource "newrelic_alert_condition" "foo" {
+  policy_id = "${newrelic_alert_policy.foo.id}"
+
+  name     = "tf-test-%[1]s"
+  type     = "apm_app_metric"
+  entities = ["${data.newrelic_application.app.id}"]
+  metric   = "%[3]s"
+  %[4]s
+
+  term {
+    duration      = 5
+    operator      = "above"
+    priority      = "critical"
+    threshold     = "100"
+    time_function = "all"
+  }
+}
+`, rName, testAccExpectedApplicationName, metric, userDefined)
+}
@@ -0,0 +1,299 @@
+package newrelic
+
+import (
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+func resourceNewRelicAlertMutingRule() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceNewRelicAlertMutingRuleCreate,
+		Read:   resourceNewRelicAlertMutingRuleRead,
+		Update: resourceNewRelicAlertMutingRuleUpdate,
+		Delete: resourceNewRelicAlertMutingRuleDelete,
+		Importer: &schema.ResourceImporter{
+			State: importAlertMutingRule,
+		},
+		Schema: map[string]*schema.Schema{
+			"api_key": apiKeySchema(),
+			"account_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"condition_operator": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "AND",
+				ValidateFunc: validation.StringInSlice([]string{"AND", "OR"}, false),
+			},
+			"condition": {
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"attribute": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"operator": {
+							Type:     schema.TypeString,
+							Required: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								"ANY",
+								"CONTAINS",
+								"ENDS_WITH",
+								"EQUALS",
+								"IN",
+								"IS_BLANK",
+								"IS_NOT_BLANK",
+								"NOT_CONTAINS",
+								"NOT_ENDS_WITH",
+								"NOT_EQUALS",
+								"NOT_IN",
+								"NOT_STARTS_WITH",
+								"STARTS_WITH",
+							}, false),
+						},
+						"values": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+			"schedule": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"start_time": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validateNaiveDateTime,
+						},
+						"end_time": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validateNaiveDateTime,
+						},
+						"time_zone": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"repeat": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringInSlice([]string{"DAILY", "WEEKLY", "MONTHLY"}, false),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func importAlertMutingRule(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	ids, err := parseIDs(d.Id(), 2)
+	if err != nil {
+		return nil, err
+	}
+
+	d.Set("account_id", ids[0])
+	return []*schema.ResourceData{d}, nil
+}
+
+func buildAlertMutingRuleStruct(d *schema.ResourceData) *mutingRule {
+	rule := mutingRule{
+		Name:        d.Get("name").(string),
+		Enabled:     d.Get("enabled").(bool),
+		Description: d.Get("description").(string),
+		Condition: mutingRuleCondition{
+			Operator: d.Get("condition_operator").(string),
+		},
+	}
+
+	for _, c := range d.Get("condition").([]interface{}) {
+		condition := c.(map[string]interface{})
+
+		match := mutingRuleAttributeMatch{
+			Attribute: condition["attribute"].(string),
+			Operator:  condition["operator"].(string),
+			Values:    []string{},
+		}
+		for _, v := range condition["values"].([]interface{}) {
+			match.Values = append(match.Values, v.(string))
+		}
+
+		rule.Condition.Conditions = append(rule.Condition.Conditions, match)
+	}
+
+	if attr, ok := d.GetOk("schedule"); ok {
+		schedule := attr.([]interface{})[0].(map[string]interface{})
+
+		rule.Schedule = &mutingRuleSchedule{
+			StartTime: schedule["start_time"].(string),
+			EndTime:   schedule["end_time"].(string),
+			TimeZone:  schedule["time_zone"].(string),
+			Repeat:    schedule["repeat"].(string),
+		}
+	}
+
+	return &rule
+}
+
+func readAlertMutingRuleStruct(rule *mutingRule, d *schema.ResourceData) error {
+	d.Set("name", rule.Name)
+	d.Set("enabled", rule.Enabled)
+	d.Set("description", rule.Description)
+	d.Set("condition_operator", rule.Condition.Operator)
+
+	var conditions []map[string]interface{}
+	for _, c := range rule.Condition.Conditions {
+		conditions = append(conditions, map[string]interface{}{
+			"attribute": c.Attribute,
+			"operator":  c.Operator,
+			"values":    c.Values,
+		})
+	}
+
+	if err := d.Set("condition", conditions); err != nil {
+		return err
+	}
+
+	var schedule []map[string]interface{}
+	if rule.Schedule != nil {
+		schedule = append(schedule, map[string]interface{}{
+			"start_time": rule.Schedule.StartTime,
+			"end_time":   rule.Schedule.EndTime,
+			"time_zone":  rule.Schedule.TimeZone,
+			"repeat":     rule.Schedule.Repeat,
+		})
+	}
+
+	return d.Set("schedule", schedule)
+}
+
+func resourceNewRelicAlertMutingRuleCreate(d *schema.ResourceData, meta interface{}) error {
+	client, err := meta.(*ProviderConfig).clientFor(d)
+	if err != nil {
+		return err
+	}
+
+	accountID := d.Get("account_id").(int)
+	rule := buildAlertMutingRuleStruct(d)
+
+	log.Printf("[INFO] Creating New Relic alert muting rule %s", rule.Name)
+
+	rule, err = createMutingRule(client, accountID, *rule)
+	if err != nil {
+		return err
+	}
+
+	id, err := strconv.Atoi(rule.ID)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(serializeIDs([]int{accountID, id}))
+
+	return resourceNewRelicAlertMutingRuleRead(d, meta)
+}
+
+func resourceNewRelicAlertMutingRuleRead(d *schema.ResourceData, meta interface{}) error {
+	client, err := meta.(*ProviderConfig).clientFor(d)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Reading New Relic alert muting rule %s", d.Id())
+
+	ids, err := parseIDs(d.Id(), 2)
+	if err != nil {
+		return err
+	}
+
+	accountID := ids[0]
+	id := ids[1]
+
+	rule, err := getMutingRule(client, accountID, id)
+	if err != nil {
+		if err == errMutingRuleNotFound {
+			d.SetId("")
+			return nil
+		}
+
+		return err
+	}
+
+	d.Set("account_id", accountID)
+
+	return readAlertMutingRuleStruct(rule, d)
+}
+
+func resourceNewRelicAlertMutingRuleUpdate(d *schema.ResourceData, meta interface{}) error {
+	client, err := meta.(*ProviderConfig).clientFor(d)
+	if err != nil {
+		return err
+	}
+
+	ids, err := parseIDs(d.Id(), 2)
+	if err != nil {
+		return err
+	}
+
+	accountID := ids[0]
+	id := ids[1]
+
+	rule := buildAlertMutingRuleStruct(d)
+
+	log.Printf("[INFO] Updating New Relic alert muting rule %d", id)
+
+	if err := updateMutingRule(client, accountID, id, *rule); err != nil {
+		return err
+	}
+
+	return resourceNewRelicAlertMutingRuleRead(d, meta)
+}
+
+func resourceNewRelicAlertMutingRuleDelete(d *schema.ResourceData, meta interface{}) error {
+	client, err := meta.(*ProviderConfig).clientFor(d)
+	if err != nil {
+		return err
+	}
+
+	ids, err := parseIDs(d.Id(), 2)
+	if err != nil {
+		return err
+	}
+
+	accountID := ids[0]
+	id := ids[1]
+
+	log.Printf("[INFO] Deleting New Relic alert muting rule %d", id)
+
+	if err := deleteMutingRule(client, accountID, id); err != nil {
+		return err
+	}
+
+	return nil
+}
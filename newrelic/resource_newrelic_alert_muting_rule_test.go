@@ -0,0 +1,142 @@
+package newrelic
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccNewRelicAlertMutingRule_Basic(t *testing.T) {
+	accountID := testAccAccountID(t)
+	rName := acctest.RandString(5)
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNewRelicAlertMutingRuleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckNewRelicAlertMutingRuleConfig(accountID, rName, ""),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicAlertMutingRuleExists("newrelic_alert_muting_rule.foo"),
+					resource.TestCheckResourceAttr(
+						"newrelic_alert_muting_rule.foo", "name", fmt.Sprintf("tf-test-%s", rName)),
+					resource.TestCheckResourceAttr(
+						"newrelic_alert_muting_rule.foo", "condition.#", "1"),
+					resource.TestCheckResourceAttr(
+						"newrelic_alert_muting_rule.foo", "condition.0.values.0", "tf-deploys"),
+					resource.TestCheckResourceAttr(
+						"newrelic_alert_muting_rule.foo", "schedule.#", "0"),
+				),
+			},
+			{
+				Config: testAccCheckNewRelicAlertMutingRuleConfig(accountID, rName, `
+  schedule {
+    start_time = "2030-01-01T22:00:00"
+    end_time   = "2030-01-01T23:00:00"
+    time_zone  = "America/Los_Angeles"
+    repeat     = "WEEKLY"
+  }`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicAlertMutingRuleExists("newrelic_alert_muting_rule.foo"),
+					resource.TestCheckResourceAttr(
+						"newrelic_alert_muting_rule.foo", "schedule.0.time_zone", "America/Los_Angeles"),
+					resource.TestCheckResourceAttr(
+						"newrelic_alert_muting_rule.foo", "schedule.0.repeat", "WEEKLY"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccNewRelicAlertMutingRule_import(t *testing.T) {
+	accountID := testAccAccountID(t)
+	resourceName := "newrelic_alert_muting_rule.foo"
+	rName := acctest.RandString(5)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNewRelicAlertMutingRuleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckNewRelicAlertMutingRuleConfig(accountID, rName, ""),
+			},
+
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckNewRelicAlertMutingRuleDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*ProviderConfig).Client
+	for _, r := range s.RootModule().Resources {
+		if r.Type != "newrelic_alert_muting_rule" {
+			continue
+		}
+
+		ids, err := parseIDs(r.Primary.ID, 2)
+		if err != nil {
+			return err
+		}
+
+		_, err = getMutingRule(client, ids[0], ids[1])
+		if err == nil {
+			return fmt.Errorf("Alert muting rule still exists")
+		}
+	}
+	return nil
+}
+
+func testAccCheckNewRelicAlertMutingRuleExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No alert muting rule ID is set")
+		}
+
+		client := testAccProvider.Meta().(*ProviderConfig).Client
+
+		ids, err := parseIDs(rs.Primary.ID, 2)
+		if err != nil {
+			return err
+		}
+
+		found, err := getMutingRule(client, ids[0], ids[1])
+		if err != nil {
+			return err
+		}
+
+		if found.ID != fmt.Sprint(ids[1]) {
+			return fmt.Errorf("Alert muting rule not found: %v - %v", rs.Primary.ID, found)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckNewRelicAlertMutingRuleConfig(accountID string, rName string, schedule string) string {
+	return fmt.Sprintf(`
+resource "newrelic_alert_muting_rule" "foo" {
+  account_id  = %[1]s
+  name        = "tf-test-%[2]s"
+  description = "Mute alerts during deploys"
+
+  condition {
+    attribute = "tag.team"
+    operator  = "EQUALS"
+    values    = ["tf-deploys"]
+  }
+%[3]s
+}
+`, accountID, rName, schedule)
+}
@@ -1,8 +1,11 @@
 package newrelic
 
 import (
+	"fmt"
 	"log"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform/helper/schema"
@@ -19,7 +22,11 @@ func resourceNewRelicAlertPolicy() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(1 * time.Minute),
+		},
 		Schema: map[string]*schema.Schema{
+			"api_key": apiKeySchema(),
 			"name": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -30,6 +37,12 @@ func resourceNewRelicAlertPolicy() *schema.Resource {
 				Default:      "PER_POLICY",
 				ValidateFunc: validation.StringInSlice([]string{"PER_POLICY", "PER_CONDITION", "PER_CONDITION_AND_TARGET"}, false),
 			},
+			"channel_ids": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+				Set:      schema.HashInt,
+			},
 			"created_at": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -38,6 +51,10 @@ func resourceNewRelicAlertPolicy() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"permalink": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 		},
 	}
 }
@@ -55,18 +72,129 @@ func buildAlertPolicyStruct(d *schema.ResourceData) *newrelic.AlertPolicy {
 }
 
 func resourceNewRelicAlertPolicyCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).Client
+	client, err := meta.(*ProviderConfig).clientFor(d)
+	if err != nil {
+		return err
+	}
+
 	policy := buildAlertPolicyStruct(d)
 
+	if meta.(*ProviderConfig).uniquePolicyNames {
+		if err := checkAlertPolicyNameUnique(client, policy.Name, 0); err != nil {
+			return err
+		}
+	}
+
 	log.Printf("[INFO] Creating New Relic alert policy %s", policy.Name)
 
-	policy, err := client.CreateAlertPolicy(*policy)
+	policy, err = client.CreateAlertPolicy(*policy)
 	if err != nil {
 		return err
 	}
 
 	d.SetId(strconv.Itoa(policy.ID))
 
+	// Conditions created right after the policy fail if it isn't readable
+	// yet.
+	err = waitForCreated(meta.(*ProviderConfig).stopContext(), d.Timeout(schema.TimeoutCreate), func() error {
+		_, err := client.GetAlertPolicy(policy.ID)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("Error waiting for New Relic alert policy %d to be created: %s", policy.ID, err)
+	}
+
+	if attr, ok := d.GetOk("channel_ids"); ok {
+		if err := updateAlertPolicyChannelIDs(client, meta, policy.ID, &schema.Set{F: schema.HashInt}, attr.(*schema.Set)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// updateAlertPolicyChannelIDs attaches the channels of n missing from o to the
+// policy, and detaches those of o missing from n. The cached channels are
+// dropped after each change, so that links are read back.
+func updateAlertPolicyChannelIDs(client *newrelic.Client, meta interface{}, policyID int, o, n *schema.Set) error {
+	added := expandChannelIDs(n.Difference(o))
+	removed := expandChannelIDs(o.Difference(n))
+
+	if len(added) > 0 {
+		log.Printf("[INFO] Adding New Relic alert channels %v to policy %d", added, policyID)
+
+		if err := client.UpdateAlertPolicyChannels(policyID, added); err != nil {
+			return err
+		}
+
+		meta.(*ProviderConfig).forgetAlertChannels(client)
+	}
+
+	for _, channelID := range removed {
+		log.Printf("[INFO] Removing New Relic alert channel %d from policy %d", channelID, policyID)
+
+		if err := client.DeleteAlertPolicyChannel(policyID, channelID); err != nil && err != newrelic.ErrNotFound {
+			return err
+		}
+
+		meta.(*ProviderConfig).forgetAlertChannels(client)
+	}
+
+	return nil
+}
+
+// readAlertPolicyChannelIDs returns the IDs of the channels attached to the
+// policy, which the API only links from the channels. The channels are shared
+// with newrelic_alert_policy_channel through the provider's cache.
+func readAlertPolicyChannelIDs(client *newrelic.Client, meta interface{}, policyID int) ([]int, error) {
+	log.Printf("[INFO] Reading New Relic alert channels of policy %d", policyID)
+
+	channels, err := meta.(*ProviderConfig).listAlertChannels(client)
+	if err != nil {
+		return nil, err
+	}
+
+	channelIDs := []int{}
+	for _, c := range channels {
+		for _, id := range c.Links.PolicyIDs {
+			if id == policyID {
+				channelIDs = append(channelIDs, c.ID)
+				break
+			}
+		}
+	}
+
+	return channelIDs, nil
+}
+
+func expandChannelIDs(s *schema.Set) []int {
+	channelIDs := make([]int, 0, s.Len())
+	for _, id := range s.List() {
+		channelIDs = append(channelIDs, id.(int))
+	}
+
+	sort.Ints(channelIDs)
+
+	return channelIDs
+}
+
+// checkAlertPolicyNameUnique fails if a policy other than the one with
+// the given ID is named name, since the API allows duplicate names but the
+// newrelic_alert_policy data source can't tell them apart.
+func checkAlertPolicyNameUnique(client *newrelic.Client, name string, id int) error {
+	log.Printf("[INFO] Listing New Relic alert policies named %s", name)
+
+	policies, err := client.ListAlertPolicies()
+	if err != nil {
+		return err
+	}
+
+	for _, p := range policies {
+		if p.Name == name && p.ID != id {
+			return fmt.Errorf("Alert policy %q already exists with ID %d; import it with terraform import or unset unique_policy_names", name, p.ID)
+		}
+	}
+
 	return nil
 }
 
@@ -79,7 +207,10 @@ func unixMillis(msec int64) time.Time {
 }
 
 func resourceNewRelicAlertPolicyRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).Client
+	client, err := meta.(*ProviderConfig).clientFor(d)
+	if err != nil {
+		return err
+	}
 
 	id, err := strconv.ParseInt(d.Id(), 10, 32)
 	if err != nil {
@@ -90,6 +221,8 @@ func resourceNewRelicAlertPolicyRead(d *schema.ResourceData, meta interface{}) e
 
 	policy, err := client.GetAlertPolicy(int(id))
 	if err != nil {
+		// Archived policies aren't listed either, so they're removed from
+		// state like deleted ones and planned to be created again.
 		if err == newrelic.ErrNotFound {
 			d.SetId("")
 			return nil
@@ -108,12 +241,28 @@ func resourceNewRelicAlertPolicyRead(d *schema.ResourceData, meta interface{}) e
 	d.Set("incident_preference", policy.IncidentPreference)
 	d.Set("created_at", created)
 	d.Set("updated_at", updated)
+	d.Set("permalink", meta.(*ProviderConfig).permalink(d, "alerts", fmt.Sprintf("policies/%d", id)))
+
+	// Channels are only read once managed here, so that policies whose
+	// channels are attached with newrelic_alert_policy_channel show no diff.
+	if d.Get("channel_ids").(*schema.Set).Len() > 0 {
+		channelIDs, err := readAlertPolicyChannelIDs(client, meta, int(id))
+		if err != nil {
+			return err
+		}
+
+		d.Set("channel_ids", channelIDs)
+	}
 
 	return nil
 }
 
 func resourceNewRelicAlertPolicyUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).Client
+	client, err := meta.(*ProviderConfig).clientFor(d)
+	if err != nil {
+		return err
+	}
+
 	policy := buildAlertPolicyStruct(d)
 
 	id, err := strconv.ParseInt(d.Id(), 10, 32)
@@ -122,26 +271,53 @@ func resourceNewRelicAlertPolicyUpdate(d *schema.ResourceData, meta interface{})
 	}
 	policy.ID = int(id)
 
+	if meta.(*ProviderConfig).uniquePolicyNames && d.HasChange("name") {
+		if err := checkAlertPolicyNameUnique(client, policy.Name, policy.ID); err != nil {
+			return err
+		}
+	}
+
 	log.Printf("[INFO] Updating New Relic alert policy %d", id)
 	respPolicy, err := client.UpdateAlertPolicy(*policy)
 	if err != nil {
 		return err
 	}
 
-	d.Set("created_at", respPolicy.CreatedAt)
-	d.Set("updated_at", respPolicy.UpdatedAt)
+	d.Set("created_at", unixMillis(respPolicy.CreatedAt).Format(time.RFC3339))
+	d.Set("updated_at", unixMillis(respPolicy.UpdatedAt).Format(time.RFC3339))
+
+	if d.HasChange("channel_ids") {
+		o, n := d.GetChange("channel_ids")
+		if err := updateAlertPolicyChannelIDs(client, meta, policy.ID, o.(*schema.Set), n.(*schema.Set)); err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
 
 func resourceNewRelicAlertPolicyDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).Client
+	client, err := meta.(*ProviderConfig).clientFor(d)
+	if err != nil {
+		return err
+	}
 
 	id, err := strconv.ParseInt(d.Id(), 10, 32)
 	if err != nil {
 		return err
 	}
 
+	if meta.(*ProviderConfig).preventDestroyWithConditions {
+		conditions, err := listAlertPolicyConditions(d, meta, int(id))
+		if err != nil {
+			return err
+		}
+
+		if len(conditions) > 0 {
+			return fmt.Errorf("Alert policy %d still has conditions %s; delete them first or unset prevent_destroy_with_conditions", id, strings.Join(conditions, ", "))
+		}
+	}
+
 	log.Printf("[INFO] Deleting New Relic alert policy %v", id)
 
 	if err := client.DeleteAlertPolicy(int(id)); err != nil {
@@ -150,3 +326,63 @@ func resourceNewRelicAlertPolicyDelete(d *schema.ResourceData, meta interface{})
 
 	return nil
 }
+
+// listAlertPolicyConditions returns a description of every condition of any
+// type attached to the policy.
+func listAlertPolicyConditions(d *schema.ResourceData, meta interface{}, policyID int) ([]string, error) {
+	client, err := meta.(*ProviderConfig).clientFor(d)
+	if err != nil {
+		return nil, err
+	}
+
+	infraClient, err := meta.(*ProviderConfig).infraClientFor(d)
+	if err != nil {
+		return nil, err
+	}
+
+	var conditions []string
+
+	log.Printf("[INFO] Listing New Relic alert conditions for policy %d", policyID)
+
+	apmConditions, err := client.ListAlertConditions(policyID)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range apmConditions {
+		conditions = append(conditions, fmt.Sprintf("%q (%d)", c.Name, c.ID))
+	}
+
+	nrqlConditions, err := client.ListAlertNrqlConditions(policyID)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range nrqlConditions {
+		conditions = append(conditions, fmt.Sprintf("%q (%d)", c.Name, c.ID))
+	}
+
+	syntheticsConditions, err := client.ListAlertSyntheticsConditions(policyID)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range syntheticsConditions {
+		conditions = append(conditions, fmt.Sprintf("%q (%d)", c.Name, c.ID))
+	}
+
+	pluginsConditions, err := client.ListAlertPluginsConditions(policyID)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range pluginsConditions {
+		conditions = append(conditions, fmt.Sprintf("%q (%d)", c.Name, c.ID))
+	}
+
+	infraConditions, err := infraClient.ListAlertInfraConditions(policyID)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range infraConditions {
+		conditions = append(conditions, fmt.Sprintf("%q (%d)", c.Name, c.ID))
+	}
+
+	return conditions, nil
+}
@@ -1,6 +1,7 @@
 package newrelic
 
 import (
+	"fmt"
 	"log"
 
 	"github.com/hashicorp/terraform/helper/schema"
@@ -26,13 +27,46 @@ func policyChannelExists(client *newrelic.Client, policyID int, channelID int) (
 	return false, nil
 }
 
+// policyChannelLinked is policyChannelExists for reads, looking the channel
+// up in the provider's cached channel list so that refreshing every link
+// lists the channels once.
+func policyChannelLinked(client *newrelic.Client, meta interface{}, policyID int, channelID int) (bool, error) {
+	channels, err := meta.(*ProviderConfig).listAlertChannels(client)
+	if err != nil {
+		return false, err
+	}
+
+	for _, channel := range channels {
+		if channel.ID != channelID {
+			continue
+		}
+
+		for _, id := range channel.Links.PolicyIDs {
+			if id == policyID {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
 func resourceNewRelicAlertPolicyChannel() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceNewRelicAlertPolicyChannelCreate,
 		Read:   resourceNewRelicAlertPolicyChannelRead,
 		// Update: Not currently supported in API
 		Delete: resourceNewRelicAlertPolicyChannelDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceNewRelicAlertPolicyChannelImport,
+		},
 		Schema: map[string]*schema.Schema{
+			"api_key": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				ForceNew:  true,
+				Sensitive: true,
+			},
 			"policy_id": {
 				Type:     schema.TypeInt,
 				Required: true,
@@ -47,9 +81,47 @@ func resourceNewRelicAlertPolicyChannel() *schema.Resource {
 	}
 }
 
-func resourceNewRelicAlertPolicyChannelCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceNewRelicAlertPolicyChannelImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	client := meta.(*ProviderConfig).Client
 
+	ids, err := parseIDs(d.Id(), 2)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid alert policy channel ID %q, expected <policy_id>:<channel_id>: %s", d.Id(), err)
+	}
+
+	policyID := ids[0]
+	channelID := ids[1]
+
+	log.Printf("[INFO] Importing New Relic alert policy channel %s", d.Id())
+
+	if _, err := client.GetAlertPolicy(policyID); err != nil {
+		if err == newrelic.ErrNotFound {
+			return nil, fmt.Errorf("New Relic alert policy %d not found", policyID)
+		}
+
+		return nil, err
+	}
+
+	if _, err := client.GetAlertChannel(channelID); err != nil {
+		if err == newrelic.ErrNotFound {
+			return nil, fmt.Errorf("New Relic alert channel %d not found", channelID)
+		}
+
+		return nil, err
+	}
+
+	d.Set("policy_id", policyID)
+	d.Set("channel_id", channelID)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceNewRelicAlertPolicyChannelCreate(d *schema.ResourceData, meta interface{}) error {
+	client, err := meta.(*ProviderConfig).clientFor(d)
+	if err != nil {
+		return err
+	}
+
 	policyID := d.Get("policy_id").(int)
 	channelID := d.Get("channel_id").(int)
 
@@ -67,6 +139,8 @@ func resourceNewRelicAlertPolicyChannelCreate(d *schema.ResourceData, meta inter
 		if err != nil {
 			return err
 		}
+
+		meta.(*ProviderConfig).forgetAlertChannels(client)
 	}
 
 	d.SetId(serializedID)
@@ -75,7 +149,10 @@ func resourceNewRelicAlertPolicyChannelCreate(d *schema.ResourceData, meta inter
 }
 
 func resourceNewRelicAlertPolicyChannelRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).Client
+	client, err := meta.(*ProviderConfig).clientFor(d)
+	if err != nil {
+		return err
+	}
 
 	ids, err := parseIDs(d.Id(), 2)
 	if err != nil {
@@ -87,7 +164,7 @@ func resourceNewRelicAlertPolicyChannelRead(d *schema.ResourceData, meta interfa
 
 	log.Printf("[INFO] Reading New Relic alert policy channel %s", d.Id())
 
-	exists, err := policyChannelExists(client, policyID, channelID)
+	exists, err := policyChannelLinked(client, meta, policyID, channelID)
 	if err != nil {
 		return err
 	}
@@ -104,7 +181,10 @@ func resourceNewRelicAlertPolicyChannelRead(d *schema.ResourceData, meta interfa
 }
 
 func resourceNewRelicAlertPolicyChannelDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).Client
+	client, err := meta.(*ProviderConfig).clientFor(d)
+	if err != nil {
+		return err
+	}
 
 	ids, err := parseIDs(d.Id(), 2)
 	if err != nil {
@@ -129,6 +209,8 @@ func resourceNewRelicAlertPolicyChannelDelete(d *schema.ResourceData, meta inter
 			}
 			return err
 		}
+
+		meta.(*ProviderConfig).forgetAlertChannels(client)
 	}
 
 	return nil
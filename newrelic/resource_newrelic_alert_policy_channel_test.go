@@ -2,11 +2,15 @@ package newrelic
 
 import (
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
 	"testing"
 
 	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
+	newrelic "github.com/paultyng/go-newrelic/v4/api"
 )
 
 func TestAccNewRelicAlertPolicyChannel_Basic(t *testing.T) {
@@ -32,6 +36,98 @@ func TestAccNewRelicAlertPolicyChannel_Basic(t *testing.T) {
 	})
 }
 
+func TestResourceNewRelicAlertPolicyChannelRead_cached(t *testing.T) {
+	var mu sync.Mutex
+	lists := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.URL.Path == "/alerts_channels.json" && r.Method == "GET" {
+			mu.Lock()
+			lists++
+			mu.Unlock()
+
+			w.Write([]byte(`{"channels":[` +
+				`{"id":1,"name":"foo","type":"email","links":{"policy_ids":[10]}},` +
+				`{"id":2,"name":"bar","type":"email","links":{"policy_ids":[10,20]}},` +
+				`{"id":3,"name":"baz","type":"email","links":{"policy_ids":[]}}]}`))
+			return
+		}
+
+		w.Write([]byte(`{"policy":{"id":10,"channel_ids":[1,2,3]}}`))
+	}))
+	defer server.Close()
+
+	client := newrelic.New(newrelic.Config{APIKey: "foo", BaseURL: server.URL})
+	meta := &ProviderConfig{Client: &client}
+	r := resourceNewRelicAlertPolicyChannel()
+
+	// Links are refreshed concurrently, like Terraform does.
+	ids := []string{"10:1", "10:2", "20:2", "10:3"}
+	results := make([]string, len(ids))
+
+	var wg sync.WaitGroup
+	for i, id := range ids {
+		wg.Add(1)
+		go func(i int, id string) {
+			defer wg.Done()
+
+			d := r.TestResourceData()
+			d.SetId(id)
+			if err := r.Read(d, meta); err != nil {
+				t.Error(err)
+			}
+			results[i] = d.Id()
+		}(i, id)
+	}
+	wg.Wait()
+
+	if lists != 1 {
+		t.Fatalf("expected the channels to be listed once, got %d calls", lists)
+	}
+
+	if expected := []string{"10:1", "10:2", "20:2", ""}; fmt.Sprint(results) != fmt.Sprint(expected) {
+		t.Fatalf("expected links %v to be found, got %v", expected, results)
+	}
+
+	// Attaching a channel drops the cached channels, so the link is read.
+	d := r.TestResourceData()
+	d.Set("policy_id", 10)
+	d.Set("channel_id", 3)
+	if err := r.Create(d, meta); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := r.Read(d, meta); err != nil {
+		t.Fatal(err)
+	}
+
+	if lists != 3 {
+		t.Fatalf("expected the channels to be listed again after a create, got %d calls", lists)
+	}
+}
+
+func TestAccNewRelicAlertPolicyChannel_import(t *testing.T) {
+	resourceName := "newrelic_alert_policy_channel.foo"
+	rName := acctest.RandString(5)
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNewRelicAlertPolicyChannelDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckNewRelicAlertPolicyChannelConfig(rName),
+			},
+
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
 func testAccCheckNewRelicAlertPolicyChannelDestroy(s *terraform.State) error {
 	client := testAccProvider.Meta().(*ProviderConfig).Client
 	for _, r := range s.RootModule().Resources {
@@ -1,15 +1,25 @@
 package newrelic
 
 import (
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
 	"strconv"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/terraform/config"
 	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
+	newrelic "github.com/paultyng/go-newrelic/v4/api"
 )
 
+var testAccRFC3339Regexp = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(Z|[+-]\d{2}:\d{2})$`)
+
 func TestAccNewRelicAlertPolicy_Basic(t *testing.T) {
 	rName := acctest.RandString(5)
 	resource.Test(t, resource.TestCase{
@@ -35,6 +45,10 @@ func TestAccNewRelicAlertPolicy_Basic(t *testing.T) {
 						"newrelic_alert_policy.foo", "name", fmt.Sprintf("tf-test-updated-%s", rName)),
 					resource.TestCheckResourceAttr(
 						"newrelic_alert_policy.foo", "incident_preference", "PER_CONDITION"),
+					resource.TestMatchResourceAttr(
+						"newrelic_alert_policy.foo", "created_at", testAccRFC3339Regexp),
+					resource.TestMatchResourceAttr(
+						"newrelic_alert_policy.foo", "updated_at", testAccRFC3339Regexp),
 				),
 			},
 		},
@@ -63,6 +77,376 @@ func TestAccNewRelicAlertPolicy_import(t *testing.T) {
 	})
 }
 
+func TestAccNewRelicAlertPolicy_invalidIncidentPreference(t *testing.T) {
+	expectedErrorMsg, _ := regexp.Compile("expected incident_preference to be one of \\[PER_POLICY PER_CONDITION PER_CONDITION_AND_TARGET\\]")
+	resource.Test(t, resource.TestCase{
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCheckNewRelicAlertPolicyConfigIncidentPreference(acctest.RandString(5), "PER_CONDITION_AND_TARGET "),
+				ExpectError: expectedErrorMsg,
+			},
+		},
+	})
+}
+
+func TestAccNewRelicAlertPolicy_preventDestroyWithConditions(t *testing.T) {
+	rName := acctest.RandString(5)
+	expectedErrorMsg, _ := regexp.Compile("still has conditions")
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNewRelicAlertPolicyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckNewRelicAlertPolicyConfigPreventDestroy(rName, true, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicAlertPolicyExists("newrelic_alert_policy.foo"),
+					testAccCreateNewRelicNrqlAlertConditionOutOfBand("newrelic_alert_policy.foo", rName),
+				),
+			},
+			{
+				Config:      testAccCheckNewRelicAlertPolicyConfigPreventDestroy(rName, true, false),
+				ExpectError: expectedErrorMsg,
+			},
+			// Deleting the policy also deletes the out of band condition.
+			{
+				Config: testAccCheckNewRelicAlertPolicyConfigPreventDestroy(rName, false, false),
+			},
+		},
+	})
+}
+
+func TestAccNewRelicAlertPolicy_uniquePolicyNames(t *testing.T) {
+	rName := acctest.RandString(5)
+	expectedErrorMsg, _ := regexp.Compile(fmt.Sprintf("Alert policy \"tf-test-%s\" already exists with ID \\d+", rName))
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNewRelicAlertPolicyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckNewRelicAlertPolicyConfigUniqueNames(rName, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicAlertPolicyExists("newrelic_alert_policy.foo"),
+					testAccCheckNewRelicAlertPolicyExists("newrelic_alert_policy.bar"),
+				),
+			},
+			{
+				Config:      testAccCheckNewRelicAlertPolicyConfigUniqueNames(rName, true) + testAccCheckNewRelicAlertPolicyConfigDuplicate(rName, "baz"),
+				ExpectError: expectedErrorMsg,
+			},
+		},
+	})
+}
+
+func TestResourceNewRelicAlertPolicyCreate_uniquePolicyNames(t *testing.T) {
+	var created bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.Method == "POST" {
+			created = true
+			w.Write([]byte(`{"policy":{"id":2,"name":"foo"}}`))
+			return
+		}
+
+		// The created policy is listed too, so that it's found once created.
+		if created {
+			w.Write([]byte(`{"policies":[{"id":1,"name":"foo"},{"id":2,"name":"foo"},{"id":3,"name":"bar"}]}`))
+			return
+		}
+
+		w.Write([]byte(`{"policies":[{"id":1,"name":"foo"},{"id":3,"name":"bar"}]}`))
+	}))
+	defer server.Close()
+
+	client := newrelic.New(newrelic.Config{BaseURL: server.URL})
+	r := resourceNewRelicAlertPolicy()
+
+	// Duplicate names are allowed by default.
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"name": "foo"})
+
+	if err := r.Create(d, &ProviderConfig{Client: &client}); err != nil {
+		t.Fatal(err)
+	}
+
+	if !created || d.Id() != "2" {
+		t.Fatalf("expected policy 2 to be created, got ID %q", d.Id())
+	}
+
+	created = false
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"name": "foo"})
+
+	err := r.Create(d, &ProviderConfig{Client: &client, uniquePolicyNames: true})
+
+	expected := `Alert policy "foo" already exists with ID 1; import it with terraform import or unset unique_policy_names`
+	if err == nil || err.Error() != expected {
+		t.Fatalf("expected %q, got %q", expected, err)
+	}
+
+	if created {
+		t.Fatal("expected no policy to be created")
+	}
+}
+
+func TestResourceNewRelicAlertPolicyCreate_delayedVisibility(t *testing.T) {
+	defer testFastCreatePolling()()
+
+	reads := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.Method == "POST" {
+			w.Write([]byte(`{"policy":{"id":2,"name":"foo"}}`))
+			return
+		}
+
+		// The created policy is only listed from the 3rd read on.
+		reads++
+		if reads < 3 {
+			w.Write([]byte(`{"policies":[]}`))
+			return
+		}
+
+		w.Write([]byte(`{"policies":[{"id":2,"name":"foo"}]}`))
+	}))
+	defer server.Close()
+
+	client := newrelic.New(newrelic.Config{BaseURL: server.URL})
+	r := resourceNewRelicAlertPolicy()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"name": "foo"})
+
+	if err := r.Create(d, &ProviderConfig{Client: &client}); err != nil {
+		t.Fatal(err)
+	}
+
+	if d.Id() != "2" || reads != 3 {
+		t.Fatalf("expected policy 2 to be found on the 3rd read, got ID %q after %d reads", d.Id(), reads)
+	}
+}
+
+func TestAccNewRelicAlertPolicy_channelIDs(t *testing.T) {
+	rName := acctest.RandString(5)
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNewRelicAlertPolicyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckNewRelicAlertPolicyConfigChannelIDs(rName, `"${newrelic_alert_channel.foo.id}", "${newrelic_alert_channel.bar.id}"`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicAlertPolicyExists("newrelic_alert_policy.foo"),
+					resource.TestCheckResourceAttr(
+						"newrelic_alert_policy.foo", "channel_ids.#", "2"),
+				),
+			},
+			{
+				Config: testAccCheckNewRelicAlertPolicyConfigChannelIDs(rName, `"${newrelic_alert_channel.bar.id}"`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicAlertPolicyExists("newrelic_alert_policy.foo"),
+					resource.TestCheckResourceAttr(
+						"newrelic_alert_policy.foo", "channel_ids.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestResourceNewRelicAlertPolicy_channelIDs(t *testing.T) {
+	// The policies each channel is attached to.
+	channels := map[int][]int{1: {}, 2: {}, 3: {}}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.URL.Path == "/alerts_policy_channels.json":
+			policyID, _ := strconv.Atoi(r.URL.Query().Get("policy_id"))
+
+			if r.Method == "PUT" {
+				for _, v := range r.URL.Query()["channel_ids"] {
+					id, _ := strconv.Atoi(v)
+					channels[id] = append(channels[id], policyID)
+				}
+			} else {
+				id, _ := strconv.Atoi(r.URL.Query().Get("channel_id"))
+				channels[id] = nil
+			}
+
+			w.Write([]byte(`{}`))
+		case r.URL.Path == "/alerts_channels.json":
+			list := []newrelic.AlertChannel{}
+			for id, policyIDs := range channels {
+				list = append(list, newrelic.AlertChannel{ID: id, Links: newrelic.AlertChannelLinks{PolicyIDs: policyIDs}})
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"channels": list})
+		case r.Method == "GET":
+			w.Write([]byte(`{"policies":[{"id":5,"name":"foo"}]}`))
+		default:
+			w.Write([]byte(`{"policy":{"id":5,"name":"foo"}}`))
+		}
+	}))
+	defer server.Close()
+
+	client := newrelic.New(newrelic.Config{BaseURL: server.URL})
+	meta := &ProviderConfig{Client: &client}
+
+	r := resourceNewRelicAlertPolicy()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"name":        "foo",
+		"channel_ids": []interface{}{1, 2},
+	})
+
+	if err := r.Create(d, meta); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(channels[1]) != 1 || len(channels[2]) != 1 || len(channels[3]) != 0 {
+		t.Fatalf("expected channels 1 and 2 to be attached to policy 5, got %v", channels)
+	}
+
+	rc, err := config.NewRawConfig(map[string]interface{}{
+		"name":        "foo",
+		"channel_ids": []interface{}{2, 3},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	diff, err := r.Diff(d.State(), terraform.NewResourceConfig(rc), meta)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	state, err := r.Apply(d.State(), diff, meta)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Only the changes are sent, so channel 2 isn't attached twice.
+	if len(channels[1]) != 0 || len(channels[2]) != 1 || len(channels[3]) != 1 {
+		t.Fatalf("expected channels 2 and 3 to be attached to policy 5, got %v", channels)
+	}
+
+	// Channels attached outside Terraform are read back.
+	channels[1] = []int{5}
+
+	d = r.Data(state)
+	if err := r.Read(d, meta); err != nil {
+		t.Fatal(err)
+	}
+
+	if n := d.Get("channel_ids").(*schema.Set).Len(); n != 3 {
+		t.Fatalf("expected 3 channels to be read, got %v", d.Get("channel_ids"))
+	}
+
+	// The channels listed by the read are cached, and detaching channels drops
+	// them, so links read in the same run see the change.
+	rc, err = config.NewRawConfig(map[string]interface{}{
+		"name":        "foo",
+		"channel_ids": []interface{}{2},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	diff, err = r.Diff(d.State(), terraform.NewResourceConfig(rc), meta)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := r.Apply(d.State(), diff, meta); err != nil {
+		t.Fatal(err)
+	}
+
+	link := resourceNewRelicAlertPolicyChannel().TestResourceData()
+	link.SetId("5:3")
+	if err := resourceNewRelicAlertPolicyChannel().Read(link, meta); err != nil {
+		t.Fatal(err)
+	}
+
+	if link.Id() != "" {
+		t.Fatal("expected the detached channel 3 not to be linked to policy 5")
+	}
+}
+
+func TestResourceNewRelicAlertPolicyRead_notFound(t *testing.T) {
+	testResourceReadNotFound(t, resourceNewRelicAlertPolicy(), "123")
+}
+
+func TestResourceNewRelicAlertPolicyRead_archived(t *testing.T) {
+	// Archived policies are left out of the policies the API lists, while the
+	// account's other policies are still listed.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"policies":[{"id":3,"name":"foo","incident_preference":"PER_POLICY"}]}`))
+	}))
+	defer server.Close()
+
+	client := newrelic.New(newrelic.Config{BaseURL: server.URL})
+	r := resourceNewRelicAlertPolicy()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"name": "foo"})
+	d.SetId("2")
+
+	if err := r.Read(d, &ProviderConfig{Client: &client}); err != nil {
+		t.Fatalf("expected no error reading an archived policy, got %s", err)
+	}
+
+	if d.Id() != "" {
+		t.Fatalf("expected the archived policy to be removed from state, got ID %q", d.Id())
+	}
+}
+
+// testAccCreateNewRelicNrqlAlertConditionOutOfBand adds a condition to the
+// policy without Terraform, as another team or tool would.
+func testAccCreateNewRelicNrqlAlertConditionOutOfBand(n string, rName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		policyID, err := strconv.Atoi(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		client := testAccProvider.Meta().(*ProviderConfig).Client
+
+		_, err = client.CreateAlertNrqlCondition(newrelic.AlertNrqlCondition{
+			PolicyID:      policyID,
+			Name:          fmt.Sprintf("tf-test-out-of-band-%s", rName),
+			Enabled:       true,
+			ValueFunction: "single_value",
+			Nrql: newrelic.AlertNrqlQuery{
+				Query:      "SELECT count(*) FROM Transaction",
+				SinceValue: "5",
+			},
+			Terms: []newrelic.AlertConditionTerm{
+				{
+					Duration:     5,
+					Operator:     "above",
+					Priority:     "critical",
+					Threshold:    1,
+					TimeFunction: "all",
+				},
+			},
+		})
+
+		return err
+	}
+}
+
+func TestUnixMillis(t *testing.T) {
+	got := unixMillis(1546300800123).UTC()
+	expected := time.Date(2019, time.January, 1, 0, 0, 0, 123000000, time.UTC)
+
+	if !got.Equal(expected) {
+		t.Fatalf("expected %s, got %s", expected, got)
+	}
+}
+
 func testAccCheckNewRelicAlertPolicyDestroy(s *terraform.State) error {
 	client := testAccProvider.Meta().(*ProviderConfig).Client
 	for _, r := range s.RootModule().Resources {
@@ -131,3 +515,71 @@ resource "newrelic_alert_policy" "foo" {
 }
 `, rName)
 }
+
+func testAccCheckNewRelicAlertPolicyConfigIncidentPreference(rName string, incidentPreference string) string {
+	return fmt.Sprintf(`
+resource "newrelic_alert_policy" "foo" {
+  name                = "tf-test-%s"
+  incident_preference = "%s"
+}
+`, rName, incidentPreference)
+}
+
+func testAccCheckNewRelicAlertPolicyConfigChannelIDs(rName string, channelIDs string) string {
+	return fmt.Sprintf(`
+resource "newrelic_alert_channel" "foo" {
+  name = "tf-test-foo-%[1]s"
+  type = "email"
+
+  configuration = {
+    recipients = "foo@example.com"
+  }
+}
+
+resource "newrelic_alert_channel" "bar" {
+  name = "tf-test-bar-%[1]s"
+  type = "email"
+
+  configuration = {
+    recipients = "bar@example.com"
+  }
+}
+
+resource "newrelic_alert_policy" "foo" {
+  name        = "tf-test-%[1]s"
+  channel_ids = [%[2]s]
+}
+`, rName, channelIDs)
+}
+
+func testAccCheckNewRelicAlertPolicyConfigUniqueNames(rName string, unique bool) string {
+	return fmt.Sprintf(`
+provider "newrelic" {
+  unique_policy_names = %t
+}
+`, unique) + testAccCheckNewRelicAlertPolicyConfig(rName) + testAccCheckNewRelicAlertPolicyConfigDuplicate(rName, "bar")
+}
+
+// testAccCheckNewRelicAlertPolicyConfigDuplicate is a policy named like the
+// one of testAccCheckNewRelicAlertPolicyConfig.
+func testAccCheckNewRelicAlertPolicyConfigDuplicate(rName string, resourceName string) string {
+	return fmt.Sprintf(`
+resource "newrelic_alert_policy" "%[2]s" {
+  name = "tf-test-%[1]s"
+}
+`, rName, resourceName)
+}
+
+func testAccCheckNewRelicAlertPolicyConfigPreventDestroy(rName string, preventDestroy bool, withPolicy bool) string {
+	config := fmt.Sprintf(`
+provider "newrelic" {
+  prevent_destroy_with_conditions = %t
+}
+`, preventDestroy)
+
+	if withPolicy {
+		config += testAccCheckNewRelicAlertPolicyConfig(rName)
+	}
+
+	return config
+}
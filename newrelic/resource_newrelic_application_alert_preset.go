@@ -0,0 +1,350 @@
+package newrelic
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	newrelic "github.com/paultyng/go-newrelic/v4/api"
+)
+
+// applicationAlertPreset is one of the apm_app_metric conditions created by
+// newrelic_application_alert_preset, along with its default settings.
+type applicationAlertPreset struct {
+	key       string
+	name      string
+	metric    string
+	operator  string
+	threshold float64
+	duration  int
+}
+
+var applicationAlertPresets = []applicationAlertPreset{
+	{key: "response_time", name: "High response time", metric: "response_time_web", operator: "above", threshold: 1, duration: 5},
+	{key: "error_rate", name: "High error rate", metric: "error_percentage", operator: "above", threshold: 5, duration: 5},
+	{key: "throughput", name: "Low throughput", metric: "throughput_web", operator: "below", threshold: 1, duration: 10},
+}
+
+func resourceNewRelicApplicationAlertPreset() *schema.Resource {
+	s := map[string]*schema.Schema{
+		"api_key": apiKeySchema(),
+		"policy_id": {
+			Type:     schema.TypeInt,
+			Required: true,
+			ForceNew: true,
+		},
+		"application_id": {
+			Type:     schema.TypeInt,
+			Required: true,
+			ForceNew: true,
+		},
+		"runbook_url": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validateRunbookURL,
+		},
+	}
+
+	for _, p := range applicationAlertPresets {
+		s[p.key] = applicationAlertPresetSchema(p)
+	}
+
+	return &schema.Resource{
+		Create: resourceNewRelicApplicationAlertPresetCreate,
+		Read:   resourceNewRelicApplicationAlertPresetRead,
+		Update: resourceNewRelicApplicationAlertPresetUpdate,
+		Delete: resourceNewRelicApplicationAlertPresetDelete,
+		Schema: s,
+	}
+}
+
+// applicationAlertPresetSchema is the block overriding the defaults of a
+// preset condition. It's computed so that the defaults are shown in the state
+// when the block is omitted.
+func applicationAlertPresetSchema(p applicationAlertPreset) *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Computed: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"enabled": {
+					Type:     schema.TypeBool,
+					Optional: true,
+					Default:  true,
+				},
+				"name": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Default:      p.name,
+					ValidateFunc: validation.StringLenBetween(1, 64),
+				},
+				"threshold": {
+					Type:         schema.TypeFloat,
+					Optional:     true,
+					Default:      p.threshold,
+					ValidateFunc: float64Gte(0.0),
+				},
+				"duration": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      p.duration,
+					ValidateFunc: intInSlice([]int{5, 10, 15, 30, 60, 120}),
+				},
+				"time_function": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Default:      "all",
+					ValidateFunc: validation.StringInSlice([]string{"all", "any"}, false),
+				},
+				"condition_id": {
+					Type:     schema.TypeInt,
+					Computed: true,
+				},
+			},
+		},
+	}
+}
+
+// applicationAlertPresetSettings returns the settings of a preset condition,
+// or its defaults when the block isn't set.
+func applicationAlertPresetSettings(d *schema.ResourceData, p applicationAlertPreset) map[string]interface{} {
+	if blocks := d.Get(p.key).([]interface{}); len(blocks) > 0 && blocks[0] != nil {
+		return blocks[0].(map[string]interface{})
+	}
+
+	return map[string]interface{}{
+		"enabled":       true,
+		"name":          p.name,
+		"threshold":     p.threshold,
+		"duration":      p.duration,
+		"time_function": "all",
+		"condition_id":  0,
+	}
+}
+
+// buildApplicationAlertPresetCondition builds a preset condition through the
+// newrelic_alert_condition schema, so that it's sent exactly as the
+// equivalent newrelic_alert_condition would be.
+func buildApplicationAlertPresetCondition(d *schema.ResourceData, p applicationAlertPreset, settings map[string]interface{}) *newrelic.AlertCondition {
+	cd := resourceNewRelicAlertCondition().Data(nil)
+	cd.Set("policy_id", d.Get("policy_id").(int))
+	cd.Set("name", settings["name"].(string))
+	cd.Set("enabled", true)
+	cd.Set("type", "apm_app_metric")
+	cd.Set("entities", []interface{}{d.Get("application_id").(int)})
+	cd.Set("metric", p.metric)
+	cd.Set("runbook_url", d.Get("runbook_url").(string))
+	cd.Set("condition_scope", "application")
+	cd.Set("term", []interface{}{
+		map[string]interface{}{
+			"duration":      settings["duration"].(int),
+			"operator":      p.operator,
+			"priority":      "critical",
+			"threshold":     settings["threshold"].(float64),
+			"time_function": settings["time_function"].(string),
+		},
+	})
+
+	return buildAlertConditionStruct(cd)
+}
+
+// readApplicationAlertPresetCondition reads a preset condition through the
+// newrelic_alert_condition schema into the settings of its block.
+func readApplicationAlertPresetCondition(condition *newrelic.AlertCondition, policyID int) (map[string]interface{}, error) {
+	cd := resourceNewRelicAlertCondition().Data(nil)
+	cd.SetId(serializeIDs([]int{policyID, condition.ID}))
+
+	if err := readAlertConditionStruct(condition, cd); err != nil {
+		return nil, err
+	}
+
+	settings := map[string]interface{}{
+		"enabled":      cd.Get("enabled").(bool),
+		"name":         cd.Get("name").(string),
+		"condition_id": condition.ID,
+	}
+
+	for _, t := range cd.Get("term").(*schema.Set).List() {
+		term := t.(map[string]interface{})
+		if term["priority"] != "critical" {
+			continue
+		}
+
+		settings["threshold"] = term["threshold"].(float64)
+		settings["duration"] = term["duration"].(int)
+		settings["time_function"] = term["time_function"].(string)
+	}
+
+	return settings, nil
+}
+
+// syncApplicationAlertPresetConditions creates, updates or deletes each preset
+// condition to match its settings.
+func syncApplicationAlertPresetConditions(d *schema.ResourceData, client *newrelic.Client) error {
+	policyID := d.Get("policy_id").(int)
+
+	for _, p := range applicationAlertPresets {
+		settings := applicationAlertPresetSettings(d, p)
+		id := settings["condition_id"].(int)
+		enabled := settings["enabled"].(bool)
+
+		switch {
+		case enabled && id == 0:
+			condition := buildApplicationAlertPresetCondition(d, p, settings)
+
+			log.Printf("[INFO] Creating New Relic alert condition %s", condition.Name)
+
+			created, err := client.CreateAlertCondition(*condition)
+			if err != nil {
+				return alertPolicyNotFoundError(client, policyID, err)
+			}
+
+			settings["condition_id"] = created.ID
+		case enabled:
+			condition := buildApplicationAlertPresetCondition(d, p, settings)
+			condition.ID = id
+
+			log.Printf("[INFO] Updating New Relic alert condition %d", id)
+
+			if _, err := client.UpdateAlertCondition(*condition); err != nil {
+				return alertPolicyNotFoundError(client, policyID, err)
+			}
+		case id != 0:
+			log.Printf("[INFO] Deleting New Relic alert condition %d", id)
+
+			if err := client.DeleteAlertCondition(policyID, id); err != nil && err != newrelic.ErrNotFound {
+				return err
+			}
+
+			settings["condition_id"] = 0
+		}
+
+		// Keep the condition IDs in the state as soon as they change, so that
+		// a failure doesn't orphan the conditions created so far.
+		if err := d.Set(p.key, []interface{}{settings}); err != nil {
+			return fmt.Errorf("[DEBUG] Error setting application alert preset %s: %#v", p.key, err)
+		}
+
+		d.SetPartial(p.key)
+	}
+
+	return nil
+}
+
+func resourceNewRelicApplicationAlertPresetCreate(d *schema.ResourceData, meta interface{}) error {
+	client, err := meta.(*ProviderConfig).clientFor(d)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(serializeIDs([]int{d.Get("policy_id").(int), d.Get("application_id").(int)}))
+	d.Partial(true)
+
+	if err := syncApplicationAlertPresetConditions(d, client); err != nil {
+		return err
+	}
+
+	d.Partial(false)
+
+	return resourceNewRelicApplicationAlertPresetRead(d, meta)
+}
+
+func resourceNewRelicApplicationAlertPresetRead(d *schema.ResourceData, meta interface{}) error {
+	client, err := meta.(*ProviderConfig).clientFor(d)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Reading New Relic application alert preset %s", d.Id())
+
+	ids, err := parseIDs(d.Id(), 2)
+	if err != nil {
+		return err
+	}
+
+	policyID := ids[0]
+	found, missing := 0, 0
+
+	for _, p := range applicationAlertPresets {
+		settings := applicationAlertPresetSettings(d, p)
+		id := settings["condition_id"].(int)
+		if id == 0 {
+			continue
+		}
+
+		condition, err := client.GetAlertCondition(policyID, id)
+		if err != nil {
+			if err != newrelic.ErrNotFound {
+				return err
+			}
+
+			// Conditions deleted outside Terraform are created again on apply.
+			missing++
+			settings["enabled"] = false
+			settings["condition_id"] = 0
+		} else {
+			found++
+			if settings, err = readApplicationAlertPresetCondition(condition, policyID); err != nil {
+				return err
+			}
+		}
+
+		if err := d.Set(p.key, []interface{}{settings}); err != nil {
+			return fmt.Errorf("[DEBUG] Error setting application alert preset %s: %#v", p.key, err)
+		}
+	}
+
+	if found == 0 && missing > 0 {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("policy_id", policyID)
+	d.Set("application_id", ids[1])
+
+	return nil
+}
+
+func resourceNewRelicApplicationAlertPresetUpdate(d *schema.ResourceData, meta interface{}) error {
+	client, err := meta.(*ProviderConfig).clientFor(d)
+	if err != nil {
+		return err
+	}
+
+	d.Partial(true)
+
+	if err := syncApplicationAlertPresetConditions(d, client); err != nil {
+		return err
+	}
+
+	d.Partial(false)
+
+	return resourceNewRelicApplicationAlertPresetRead(d, meta)
+}
+
+func resourceNewRelicApplicationAlertPresetDelete(d *schema.ResourceData, meta interface{}) error {
+	client, err := meta.(*ProviderConfig).clientFor(d)
+	if err != nil {
+		return err
+	}
+
+	policyID := d.Get("policy_id").(int)
+
+	for _, p := range applicationAlertPresets {
+		id := applicationAlertPresetSettings(d, p)["condition_id"].(int)
+		if id == 0 {
+			continue
+		}
+
+		log.Printf("[INFO] Deleting New Relic alert condition %d", id)
+
+		if err := client.DeleteAlertCondition(policyID, id); err != nil && err != newrelic.ErrNotFound {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,257 @@
+package newrelic
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+	newrelic "github.com/paultyng/go-newrelic/v4/api"
+)
+
+func TestAccNewRelicApplicationAlertPreset_Basic(t *testing.T) {
+	rName := acctest.RandString(5)
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNewRelicApplicationAlertPresetDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckNewRelicApplicationAlertPresetConfig(rName, ""),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicApplicationAlertPresetExists("newrelic_application_alert_preset.foo", 3),
+					resource.TestCheckResourceAttr(
+						"newrelic_application_alert_preset.foo", "response_time.0.name", "High response time"),
+					resource.TestCheckResourceAttr(
+						"newrelic_application_alert_preset.foo", "response_time.0.threshold", "1"),
+					resource.TestCheckResourceAttr(
+						"newrelic_application_alert_preset.foo", "error_rate.0.threshold", "5"),
+					resource.TestCheckResourceAttr(
+						"newrelic_application_alert_preset.foo", "throughput.0.duration", "10"),
+				),
+			},
+			{
+				Config: testAccCheckNewRelicApplicationAlertPresetConfig(rName, `
+  error_rate {
+    threshold = 2.5
+  }
+
+  throughput {
+    enabled = false
+  }`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicApplicationAlertPresetExists("newrelic_application_alert_preset.foo", 2),
+					resource.TestCheckResourceAttr(
+						"newrelic_application_alert_preset.foo", "error_rate.0.threshold", "2.5"),
+					resource.TestCheckResourceAttr(
+						"newrelic_application_alert_preset.foo", "throughput.0.condition_id", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestResourceNewRelicApplicationAlertPreset(t *testing.T) {
+	conditions := map[int]newrelic.AlertCondition{}
+	deleted := []int{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		var req struct {
+			Condition newrelic.AlertCondition `json:"condition"`
+		}
+
+		switch r.Method {
+		case "POST":
+			json.NewDecoder(r.Body).Decode(&req)
+			req.Condition.ID = len(conditions) + 1
+			conditions[req.Condition.ID] = req.Condition
+			json.NewEncoder(w).Encode(req)
+		case "PUT":
+			json.NewDecoder(r.Body).Decode(&req)
+			conditions[req.Condition.ID] = req.Condition
+			json.NewEncoder(w).Encode(req)
+		case "DELETE":
+			id, _ := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/alerts_conditions/"), ".json"))
+			delete(conditions, id)
+			deleted = append(deleted, id)
+			w.Write([]byte(`{}`))
+		default:
+			list := []newrelic.AlertCondition{}
+			for _, c := range conditions {
+				list = append(list, c)
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"conditions": list})
+		}
+	}))
+	defer server.Close()
+
+	client := newrelic.New(newrelic.Config{BaseURL: server.URL})
+	meta := &ProviderConfig{Client: &client}
+
+	r := resourceNewRelicApplicationAlertPreset()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"policy_id":      123,
+		"application_id": 456,
+		"error_rate": []interface{}{
+			map[string]interface{}{"threshold": 2.5},
+		},
+	})
+
+	if err := r.Create(d, meta); err != nil {
+		t.Fatal(err)
+	}
+
+	if d.Id() != "123:456" {
+		t.Fatalf("expected ID 123:456, got %s", d.Id())
+	}
+
+	if len(conditions) != len(applicationAlertPresets) {
+		t.Fatalf("expected %d conditions, got %d", len(applicationAlertPresets), len(conditions))
+	}
+
+	for _, p := range applicationAlertPresets {
+		id := d.Get(p.key + ".0.condition_id").(int)
+		c := conditions[id]
+
+		if c.Metric != p.metric || len(c.Entities) != 1 || c.Entities[0] != "456" {
+			t.Fatalf("expected a %s condition of application 456, got %#v", p.metric, c)
+		}
+
+		threshold := p.threshold
+		if p.key == "error_rate" {
+			threshold = 2.5
+		}
+
+		if c.Terms[0].Operator != p.operator || c.Terms[0].Threshold != threshold {
+			t.Fatalf("expected the %s term to be %s %v, got %#v", p.key, p.operator, threshold, c.Terms[0])
+		}
+
+		if name := d.Get(p.key + ".0.name").(string); name != p.name {
+			t.Fatalf("expected the %s name to default to %q, got %q", p.key, p.name, name)
+		}
+	}
+
+	// Disabling a preset deletes its condition.
+	throughputID := d.Get("throughput.0.condition_id").(int)
+	d.Set("throughput", []interface{}{
+		map[string]interface{}{"enabled": false, "name": "Low throughput", "threshold": 1.0, "duration": 10, "time_function": "all", "condition_id": throughputID},
+	})
+
+	if err := r.Update(d, meta); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(deleted) != 1 || deleted[0] != throughputID {
+		t.Fatalf("expected condition %d to be deleted, got %v", throughputID, deleted)
+	}
+
+	if id := d.Get("throughput.0.condition_id").(int); id != 0 {
+		t.Fatalf("expected the throughput condition ID to be cleared, got %d", id)
+	}
+
+	// The preset is removed from the state once all of its conditions are.
+	conditions = map[int]newrelic.AlertCondition{}
+
+	if err := r.Read(d, meta); err != nil {
+		t.Fatal(err)
+	}
+
+	if d.Id() != "" {
+		t.Fatalf("expected the preset to be removed from the state, got ID %q", d.Id())
+	}
+}
+
+func TestResourceNewRelicApplicationAlertPreset_immutableAttributes(t *testing.T) {
+	testResourceAttributesForceNew(t, resourceNewRelicApplicationAlertPreset(), "policy_id", "application_id")
+}
+
+func testAccCheckNewRelicApplicationAlertPresetDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*ProviderConfig).Client
+	for _, r := range s.RootModule().Resources {
+		if r.Type != "newrelic_application_alert_preset" {
+			continue
+		}
+
+		ids, err := parseIDs(r.Primary.ID, 2)
+		if err != nil {
+			return err
+		}
+
+		for _, p := range applicationAlertPresets {
+			id, _ := strconv.Atoi(r.Primary.Attributes[p.key+".0.condition_id"])
+			if id == 0 {
+				continue
+			}
+
+			if _, err := client.GetAlertCondition(ids[0], id); err == nil {
+				return fmt.Errorf("Alert condition %d still exists", id)
+			}
+		}
+	}
+	return nil
+}
+
+func testAccCheckNewRelicApplicationAlertPresetExists(n string, count int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No application alert preset ID is set")
+		}
+
+		client := testAccProvider.Meta().(*ProviderConfig).Client
+
+		ids, err := parseIDs(rs.Primary.ID, 2)
+		if err != nil {
+			return err
+		}
+
+		found := 0
+		for _, p := range applicationAlertPresets {
+			id, _ := strconv.Atoi(rs.Primary.Attributes[p.key+".0.condition_id"])
+			if id == 0 {
+				continue
+			}
+
+			if _, err := client.GetAlertCondition(ids[0], id); err != nil {
+				return err
+			}
+			found++
+		}
+
+		if found != count {
+			return fmt.Errorf("Expected %d alert conditions, found %d", count, found)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckNewRelicApplicationAlertPresetConfig(rName string, presets string) string {
+	return fmt.Sprintf(`
+data "newrelic_application" "app" {
+	name = "%[2]s"
+}
+
+resource "newrelic_alert_policy" "foo" {
+  name = "tf-test-%[1]s"
+}
+
+resource "newrelic_application_alert_preset" "foo" {
+  policy_id      = "${newrelic_alert_policy.foo.id}"
+  application_id = "${data.newrelic_application.app.id}"
+%[3]s
+}
+`, rName, testAccExpectedApplicationName, presets)
+}
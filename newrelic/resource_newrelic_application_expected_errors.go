@@ -0,0 +1,148 @@
+package newrelic
+
+import (
+	"log"
+	"sort"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceNewRelicApplicationExpectedErrors() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceNewRelicApplicationExpectedErrorsCreate,
+		Read:   resourceNewRelicApplicationExpectedErrorsRead,
+		Update: resourceNewRelicApplicationExpectedErrorsUpdate,
+		Delete: resourceNewRelicApplicationExpectedErrorsDelete,
+		Importer: &schema.ResourceImporter{
+			State: importApplicationExpectedErrors,
+		},
+		Schema: map[string]*schema.Schema{
+			"api_key": apiKeySchema(),
+			"guid": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateEntityGUID,
+			},
+			"error_classes": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validateNotBlank,
+				},
+			},
+			"error_codes": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validateHTTPStatusCodes,
+				},
+			},
+		},
+	}
+}
+
+func importApplicationExpectedErrors(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	d.Set("guid", d.Id())
+	return []*schema.ResourceData{d}, nil
+}
+
+func expandStringSet(set *schema.Set) []string {
+	values := make([]string, 0, set.Len())
+	for _, v := range set.List() {
+		values = append(values, v.(string))
+	}
+	sort.Strings(values)
+
+	return values
+}
+
+func buildApplicationErrorCollectorStruct(d *schema.ResourceData) applicationErrorCollector {
+	return applicationErrorCollector{
+		ExpectedErrorClasses: expandStringSet(d.Get("error_classes").(*schema.Set)),
+		ExpectedErrorCodes:   expandStringSet(d.Get("error_codes").(*schema.Set)),
+	}
+}
+
+// APM applications can't be created through the API, so create sets the
+// expected errors of the existing application.
+func resourceNewRelicApplicationExpectedErrorsCreate(d *schema.ResourceData, meta interface{}) error {
+	client, err := meta.(*ProviderConfig).clientFor(d)
+	if err != nil {
+		return err
+	}
+
+	guid := d.Get("guid").(string)
+
+	log.Printf("[INFO] Updating New Relic application %s expected errors", guid)
+
+	if err := updateApplicationErrorCollector(client, guid, buildApplicationErrorCollectorStruct(d)); err != nil {
+		return err
+	}
+
+	d.SetId(guid)
+
+	return resourceNewRelicApplicationExpectedErrorsRead(d, meta)
+}
+
+func resourceNewRelicApplicationExpectedErrorsRead(d *schema.ResourceData, meta interface{}) error {
+	client, err := meta.(*ProviderConfig).clientFor(d)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Reading New Relic application %s expected errors", d.Id())
+
+	errorCollector, err := getApplicationErrorCollector(client, d.Id())
+	if err != nil {
+		if err == errEntityNotFound {
+			d.SetId("")
+			return nil
+		}
+
+		return err
+	}
+
+	d.Set("guid", d.Id())
+
+	if err := d.Set("error_classes", errorCollector.ExpectedErrorClasses); err != nil {
+		return err
+	}
+
+	return d.Set("error_codes", errorCollector.ExpectedErrorCodes)
+}
+
+func resourceNewRelicApplicationExpectedErrorsUpdate(d *schema.ResourceData, meta interface{}) error {
+	client, err := meta.(*ProviderConfig).clientFor(d)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Updating New Relic application %s expected errors", d.Id())
+
+	if err := updateApplicationErrorCollector(client, d.Id(), buildApplicationErrorCollectorStruct(d)); err != nil {
+		return err
+	}
+
+	return resourceNewRelicApplicationExpectedErrorsRead(d, meta)
+}
+
+// Delete clears the expected errors, so every error counts towards the
+// application's error rate again.
+func resourceNewRelicApplicationExpectedErrorsDelete(d *schema.ResourceData, meta interface{}) error {
+	client, err := meta.(*ProviderConfig).clientFor(d)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Clearing New Relic application %s expected errors", d.Id())
+
+	errorCollector := applicationErrorCollector{
+		ExpectedErrorClasses: []string{},
+		ExpectedErrorCodes:   []string{},
+	}
+
+	return updateApplicationErrorCollector(client, d.Id(), errorCollector)
+}
@@ -0,0 +1,130 @@
+package newrelic
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func testAccApplicationGUID(t *testing.T) string {
+	key := "NEWRELIC_APPLICATION_GUID"
+	guid := os.Getenv(key)
+	if guid == "" {
+		t.Skipf("Environment variable %s is not set", key)
+	}
+
+	return guid
+}
+
+func TestAccNewRelicApplicationExpectedErrors_Basic(t *testing.T) {
+	resourceName := "newrelic_application_expected_errors.foo"
+	guid := testAccApplicationGUID(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNewRelicApplicationExpectedErrorsDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckNewRelicApplicationExpectedErrorsConfig(guid, `"java.io.IOException"`, `"404"`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicApplicationExpectedErrorsExists(resourceName, "java.io.IOException"),
+					resource.TestCheckResourceAttr(resourceName, "error_classes.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "error_codes.#", "1"),
+				),
+			},
+			{
+				Config: testAccCheckNewRelicApplicationExpectedErrorsConfig(guid, `"java.io.IOException", "java.util.concurrent.TimeoutException"`, `"404", "500-599"`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicApplicationExpectedErrorsExists(resourceName, "java.util.concurrent.TimeoutException"),
+					resource.TestCheckResourceAttr(resourceName, "error_classes.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "error_codes.#", "2"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestBuildApplicationErrorCollectorStruct(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceNewRelicApplicationExpectedErrors().Schema, map[string]interface{}{
+		"guid":          "MTIzNDV8QVBNfEFQUExJQ0FUSU9OfDY3ODk",
+		"error_classes": []interface{}{"java.util.concurrent.TimeoutException", "java.io.IOException"},
+	})
+
+	errorCollector := buildApplicationErrorCollectorStruct(d)
+
+	if strings.Join(errorCollector.ExpectedErrorClasses, ",") != "java.io.IOException,java.util.concurrent.TimeoutException" {
+		t.Fatalf("expected sorted error classes, got %v", errorCollector.ExpectedErrorClasses)
+	}
+
+	// An empty list, rather than null, clears the codes.
+	if errorCollector.ExpectedErrorCodes == nil || len(errorCollector.ExpectedErrorCodes) != 0 {
+		t.Fatalf("expected empty error codes, got %#v", errorCollector.ExpectedErrorCodes)
+	}
+}
+
+func testAccCheckNewRelicApplicationExpectedErrorsDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*ProviderConfig).Client
+	for _, r := range s.RootModule().Resources {
+		if r.Type != "newrelic_application_expected_errors" {
+			continue
+		}
+
+		errorCollector, err := getApplicationErrorCollector(client, r.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		if len(errorCollector.ExpectedErrorClasses) > 0 || len(errorCollector.ExpectedErrorCodes) > 0 {
+			return fmt.Errorf("Application %s still has expected errors", r.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func testAccCheckNewRelicApplicationExpectedErrorsExists(n string, errorClass string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No application GUID is set")
+		}
+
+		client := testAccProvider.Meta().(*ProviderConfig).Client
+
+		errorCollector, err := getApplicationErrorCollector(client, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		for _, c := range errorCollector.ExpectedErrorClasses {
+			if c == errorClass {
+				return nil
+			}
+		}
+
+		return fmt.Errorf("Expected error class %s not found", errorClass)
+	}
+}
+
+func testAccCheckNewRelicApplicationExpectedErrorsConfig(guid string, errorClasses string, errorCodes string) string {
+	return fmt.Sprintf(`
+resource "newrelic_application_expected_errors" "foo" {
+  guid          = "%[1]s"
+  error_classes = [%[2]s]
+  error_codes   = [%[3]s]
+}
+`, guid, errorClasses, errorCodes)
+}
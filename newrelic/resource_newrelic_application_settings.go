@@ -0,0 +1,175 @@
+package newrelic
+
+import (
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	newrelic "github.com/paultyng/go-newrelic/v4/api"
+)
+
+func resourceNewRelicApplicationSettings() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceNewRelicApplicationSettingsCreate,
+		Read:   resourceNewRelicApplicationSettingsRead,
+		Update: resourceNewRelicApplicationSettingsUpdate,
+		Delete: resourceNewRelicApplicationSettingsDelete,
+		Importer: &schema.ResourceImporter{
+			State: importApplicationSettings,
+		},
+		Schema: map[string]*schema.Schema{
+			"api_key": apiKeySchema(),
+			"application_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"app_apdex_threshold": {
+				Type:     schema.TypeFloat,
+				Optional: true,
+				Computed: true,
+			},
+			"end_user_apdex_threshold": {
+				Type:     schema.TypeFloat,
+				Optional: true,
+				Computed: true,
+			},
+			"enable_real_user_monitoring": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func importApplicationSettings(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	d.Set("application_id", id)
+	return []*schema.ResourceData{d}, nil
+}
+
+func buildApplicationSettingsStruct(d *schema.ResourceData) *applicationSettings {
+	settings := applicationSettings{
+		AppApdexThreshold:        d.Get("app_apdex_threshold").(float64),
+		EndUserApdexThreshold:    d.Get("end_user_apdex_threshold").(float64),
+		EnableRealUserMonitoring: d.Get("enable_real_user_monitoring").(bool),
+	}
+
+	return &settings
+}
+
+// APM applications can't be created through the API, so create adopts the
+// existing application and applies the configured settings to it.
+func resourceNewRelicApplicationSettingsCreate(d *schema.ResourceData, meta interface{}) error {
+	client, err := meta.(*ProviderConfig).clientFor(d)
+	if err != nil {
+		return err
+	}
+
+	id := d.Get("application_id").(int)
+
+	log.Printf("[INFO] Reading New Relic application %d", id)
+
+	application, err := getApplication(client, id)
+	if err != nil {
+		return err
+	}
+
+	// Settings left out of the configuration keep the application's current
+	// values.
+	settings := applicationSettings{
+		AppApdexThreshold:        application.Settings.AppApdexThreshold,
+		EndUserApdexThreshold:    application.Settings.EndUserApdexThreshold,
+		EnableRealUserMonitoring: application.Settings.EnableRealUserMonitoring,
+	}
+	if attr, ok := d.GetOk("app_apdex_threshold"); ok {
+		settings.AppApdexThreshold = attr.(float64)
+	}
+	if attr, ok := d.GetOk("end_user_apdex_threshold"); ok {
+		settings.EndUserApdexThreshold = attr.(float64)
+	}
+	if attr, ok := d.GetOkExists("enable_real_user_monitoring"); ok {
+		settings.EnableRealUserMonitoring = attr.(bool)
+	}
+
+	log.Printf("[INFO] Updating New Relic application %d settings", id)
+
+	if err := updateApplicationSettings(client, id, settings); err != nil {
+		return err
+	}
+
+	d.SetId(strconv.Itoa(id))
+
+	return resourceNewRelicApplicationSettingsRead(d, meta)
+}
+
+func resourceNewRelicApplicationSettingsRead(d *schema.ResourceData, meta interface{}) error {
+	client, err := meta.(*ProviderConfig).clientFor(d)
+	if err != nil {
+		return err
+	}
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Reading New Relic application %d", id)
+
+	application, err := getApplication(client, id)
+	if err != nil {
+		if err == newrelic.ErrNotFound {
+			d.SetId("")
+			return nil
+		}
+
+		return err
+	}
+
+	d.Set("application_id", application.ID)
+	d.Set("name", application.Name)
+	d.Set("app_apdex_threshold", application.Settings.AppApdexThreshold)
+	d.Set("end_user_apdex_threshold", application.Settings.EndUserApdexThreshold)
+	d.Set("enable_real_user_monitoring", application.Settings.EnableRealUserMonitoring)
+
+	return nil
+}
+
+func resourceNewRelicApplicationSettingsUpdate(d *schema.ResourceData, meta interface{}) error {
+	client, err := meta.(*ProviderConfig).clientFor(d)
+	if err != nil {
+		return err
+	}
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return err
+	}
+
+	settings := buildApplicationSettingsStruct(d)
+
+	log.Printf("[INFO] Updating New Relic application %d settings", id)
+
+	if err := updateApplicationSettings(client, id, *settings); err != nil {
+		return err
+	}
+
+	return resourceNewRelicApplicationSettingsRead(d, meta)
+}
+
+// APM applications can't be deleted through the API, so delete only removes
+// the settings from state and leaves the application as it is.
+func resourceNewRelicApplicationSettingsDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[WARN] New Relic application %s settings removed from state only; the application keeps its current settings", d.Id())
+
+	return nil
+}
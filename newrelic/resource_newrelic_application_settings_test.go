@@ -0,0 +1,106 @@
+package newrelic
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccNewRelicApplicationSettings_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckNewRelicApplicationSettingsConfig("0.5", "true"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicApplicationSettingsExists("newrelic_application_settings.foo"),
+					resource.TestCheckResourceAttr(
+						"newrelic_application_settings.foo", "name", testAccExpectedApplicationName),
+					resource.TestCheckResourceAttr(
+						"newrelic_application_settings.foo", "app_apdex_threshold", "0.5"),
+					resource.TestCheckResourceAttr(
+						"newrelic_application_settings.foo", "enable_real_user_monitoring", "true"),
+				),
+			},
+			{
+				Config: testAccCheckNewRelicApplicationSettingsConfig("0.7", "false"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicApplicationSettingsExists("newrelic_application_settings.foo"),
+					resource.TestCheckResourceAttr(
+						"newrelic_application_settings.foo", "app_apdex_threshold", "0.7"),
+					resource.TestCheckResourceAttr(
+						"newrelic_application_settings.foo", "enable_real_user_monitoring", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccNewRelicApplicationSettings_import(t *testing.T) {
+	resourceName := "newrelic_application_settings.foo"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckNewRelicApplicationSettingsConfig("0.5", "true"),
+			},
+
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckNewRelicApplicationSettingsExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No application ID is set")
+		}
+
+		client := testAccProvider.Meta().(*ProviderConfig).Client
+
+		id, err := strconv.Atoi(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		found, err := getApplication(client, id)
+		if err != nil {
+			return err
+		}
+
+		if strconv.Itoa(found.ID) != rs.Primary.ID {
+			return fmt.Errorf("Application not found: %v - %v", rs.Primary.ID, found)
+		}
+
+		return nil
+	}
+}
+
+// The test application is created in provider_test.go
+func testAccCheckNewRelicApplicationSettingsConfig(appApdexThreshold string, enableRealUserMonitoring string) string {
+	return fmt.Sprintf(`
+data "newrelic_application" "app" {
+  name = "%[1]s"
+}
+
+resource "newrelic_application_settings" "foo" {
+  application_id              = "${data.newrelic_application.app.id}"
+  app_apdex_threshold         = %[2]s
+  enable_real_user_monitoring = %[3]s
+}
+`, testAccExpectedApplicationName, appApdexThreshold, enableRealUserMonitoring)
+}
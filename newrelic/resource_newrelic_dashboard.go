@@ -2,9 +2,14 @@ package newrelic
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"log"
+	"regexp"
+	"sort"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform/helper/hashcode"
 	"github.com/hashicorp/terraform/helper/schema"
@@ -20,9 +25,13 @@ func resourceNewRelicDashboard() *schema.Resource {
 		Update: resourceNewRelicDashboardUpdate,
 		Delete: resourceNewRelicDashboardDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importDashboard,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
 		},
 		Schema: map[string]*schema.Schema{
+			"api_key": apiKeySchema(),
 			"title": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -42,12 +51,26 @@ func resourceNewRelicDashboard() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			// The same URL as dashboard_url, named like the permalink of the
+			// other resources.
+			"permalink": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 			"editable": {
 				Type:         schema.TypeString,
 				Optional:     true,
 				Default:      "editable_by_all",
 				ValidateFunc: validation.StringInSlice([]string{"read_only", "editable_by_owner", "editable_by_all", "all"}, false),
 			},
+			// Computed rather than defaulted, so that dashboards created with
+			// a 12 column grid outside Terraform keep it.
+			"grid_column_count": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: intInSlice([]int{3, 12}),
+			},
 			"filter": {
 				Type:     schema.TypeList,
 				Optional: true,
@@ -69,6 +92,11 @@ func resourceNewRelicDashboard() *schema.Resource {
 					},
 				},
 			},
+			"manage_widgets_exclusively": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
 			"widget": {
 				Type:     schema.TypeSet,
 				Optional: true,
@@ -106,16 +134,63 @@ func resourceNewRelicDashboard() *schema.Resource {
 							Type:     schema.TypeString,
 							Optional: true,
 						},
+						"drilldown_dashboard_id": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validation.IntAtLeast(1),
+						},
 						// TODO: Move this to a set/map?
 						"nrql": {
-							Type:     schema.TypeString,
+							Type:      schema.TypeString,
+							Optional:  true,
+							StateFunc: trimDashboardWidgetString,
+						},
+						"source": {
+							Type:      schema.TypeString,
+							Optional:  true,
+							StateFunc: trimDashboardWidgetString,
+						},
+						"threshold_red": {
+							Type:     schema.TypeFloat,
+							Optional: true,
+						},
+						"threshold_yellow": {
+							Type:     schema.TypeFloat,
 							Optional: true,
 						},
 					},
 				},
 			},
 		},
+		CustomizeDiff: validateDashboardWidgets,
+	}
+}
+
+// validateDashboardWidgets requires source on markdown widgets and only
+// allows thresholds on billboard widgets.
+func validateDashboardWidgets(d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("widget") {
+		return nil
+	}
+
+	for _, widget := range d.Get("widget").(*schema.Set).List() {
+		w := widget.(map[string]interface{})
+		title := w["title"].(string)
+
+		switch w["visualization"].(string) {
+		case "markdown":
+			if w["source"].(string) == "" {
+				return fmt.Errorf("widget %q: source is required for markdown widgets", title)
+			}
+		case "billboard", "billboard_comparison":
+		default:
+			if w["threshold_red"].(float64) != 0 || w["threshold_yellow"].(float64) != 0 {
+				return fmt.Errorf("widget %q: thresholds are only supported on billboard widgets", title)
+			}
+		}
 	}
+
+	return nil
 }
 
 func resourceNewRelicDashboardWidgetsHash(v interface{}) int {
@@ -126,7 +201,7 @@ func resourceNewRelicDashboardWidgetsHash(v interface{}) int {
 	column := m["column"].(int)
 	width := m["width"].(int)
 	height := m["height"].(int)
-	nrql := m["nrql"].(string)
+	nrql := strings.TrimSpace(m["nrql"].(string))
 	title := m["title"].(string)
 	notes := m["notes"].(string)
 	viz := m["visualization"].(string)
@@ -134,24 +209,48 @@ func resourceNewRelicDashboardWidgetsHash(v interface{}) int {
 	buf.WriteString(fmt.Sprintf("%d-%d-%d-%d-%s-%s-%s-%s",
 		row, column, width, height, nrql, title, viz, notes))
 
+	// Only added when set so the hashes of existing widgets don't change.
+	if drilldownDashboardID, ok := m["drilldown_dashboard_id"].(int); ok && drilldownDashboardID != 0 {
+		buf.WriteString(fmt.Sprintf("-%d", drilldownDashboardID))
+	}
+
+	if source, ok := m["source"].(string); ok && strings.TrimSpace(source) != "" {
+		buf.WriteString(fmt.Sprintf("-%s", strings.TrimSpace(source)))
+	}
+
+	for _, k := range []string{"threshold_red", "threshold_yellow"} {
+		if threshold, ok := m[k].(float64); ok && threshold != 0 {
+			buf.WriteString(fmt.Sprintf("-%s:%g", k, threshold))
+		}
+	}
+
 	return hashcode.String(buf.String())
 }
 
-// Assemble the *newrelic.Dashboard variable.
+// trimDashboardWidgetString trims the whitespace New Relic strips from widget
+// queries and markdown sources, e.g. the trailing newline of a heredoc, so
+// that they don't show up as a diff after being read back. The widget hash
+// trims them too, since it's computed from the configured values.
+func trimDashboardWidgetString(v interface{}) string {
+	return strings.TrimSpace(v.(string))
+}
+
+// Assemble the *dashboard variable.
 //
 // Used by the newrelic_dashboard Create and Update functions.
-func expandDashboard(d *schema.ResourceData) *newrelic.Dashboard {
+func expandDashboard(d *schema.ResourceData) *dashboard {
 	metadata := newrelic.DashboardMetadata{
 		Version: 1,
 	}
 
 	// TODO: Some of these should be terraform defaults and validated
-	dashboard := newrelic.Dashboard{
-		Title:      d.Get("title").(string),
-		Metadata:   metadata,
-		Icon:       d.Get("icon").(string),
-		Visibility: d.Get("visibility").(string),
-		Editable:   d.Get("editable").(string),
+	dashboard := dashboard{
+		Title:           d.Get("title").(string),
+		Metadata:        metadata,
+		Icon:            d.Get("icon").(string),
+		Visibility:      d.Get("visibility").(string),
+		Editable:        d.Get("editable").(string),
+		GridColumnCount: d.Get("grid_column_count").(int),
 	}
 
 	if f, ok := d.GetOk("filter"); ok {
@@ -186,9 +285,10 @@ func expandDashboard(d *schema.ResourceData) *newrelic.Dashboard {
 		for _, widget := range widgets {
 			w := widget.(map[string]interface{})
 
-			widgetPresentation := newrelic.DashboardWidgetPresentation{
-				Title: w["title"].(string),
-				Notes: w["notes"].(string),
+			widgetPresentation := dashboardWidgetPresentation{
+				Title:                w["title"].(string),
+				Notes:                w["notes"].(string),
+				DrilldownDashboardID: w["drilldown_dashboard_id"].(int),
 			}
 
 			widgetLayout := newrelic.DashboardWidgetLayout{
@@ -198,34 +298,227 @@ func expandDashboard(d *schema.ResourceData) *newrelic.Dashboard {
 				Height: w["height"].(int),
 			}
 
-			// TODO: Support non-NRQL Widgets
-			widgetData := []newrelic.DashboardWidgetData{
+			red, yellow := w["threshold_red"].(float64), w["threshold_yellow"].(float64)
+			if red != 0 || yellow != 0 {
+				widgetPresentation.Threshold = &dashboardWidgetThreshold{
+					Red:    red,
+					Yellow: yellow,
+				}
+			}
+
+			// Markdown widgets render their source instead of a query.
+			widgetData := []dashboardWidgetData{
 				{
-					NRQL: w["nrql"].(string),
+					NRQL: trimDashboardWidgetString(w["nrql"]),
 				},
 			}
+			if w["visualization"].(string) == "markdown" {
+				widgetData[0] = dashboardWidgetData{
+					Source: trimDashboardWidgetString(w["source"]),
+				}
+			}
 
-			dashboard.Widgets = append(dashboard.Widgets, newrelic.DashboardWidget{
+			dashboard.Widgets = append(dashboard.Widgets, dashboardWidget{
 				Visualization: w["visualization"].(string),
 				Layout:        widgetLayout,
 				Presentation:  widgetPresentation,
 				Data:          widgetData,
 			})
 		}
+
+		sortDashboardWidgets(dashboard.Widgets)
 	}
 
 	return &dashboard
 }
 
-// Unpack the *newrelic.Dashboard variable and set resource data.
+// sortDashboardWidgets orders widgets by their position, row first, so the
+// order sent to the API, which the indexes in its errors refer to, doesn't
+// depend on the order of the set.
+func sortDashboardWidgets(widgets []dashboardWidget) {
+	sort.SliceStable(widgets, func(i, j int) bool {
+		if widgets[i].Layout.Row != widgets[j].Layout.Row {
+			return widgets[i].Layout.Row < widgets[j].Layout.Row
+		}
+
+		return widgets[i].Layout.Column < widgets[j].Layout.Column
+	})
+}
+
+// dashboardWidgetPosition is the row and column of a widget, which identifies
+// it when widgets not managed by Terraform are kept.
+type dashboardWidgetPosition struct {
+	row    int
+	column int
+}
+
+// managedDashboardWidgetPositions returns the positions of the widgets in the
+// given widget sets.
+func managedDashboardWidgetPositions(sets ...interface{}) map[dashboardWidgetPosition]bool {
+	positions := make(map[dashboardWidgetPosition]bool)
+
+	for _, set := range sets {
+		for _, widget := range set.(*schema.Set).List() {
+			w := widget.(map[string]interface{})
+			positions[dashboardWidgetPosition{row: w["row"].(int), column: w["column"].(int)}] = true
+		}
+	}
+
+	return positions
+}
+
+// filterDashboardWidgets returns the widgets at the managed positions.
+func filterDashboardWidgets(widgets []dashboardWidget, managed map[dashboardWidgetPosition]bool) []dashboardWidget {
+	filtered := make([]dashboardWidget, 0, len(managed))
+
+	for _, w := range widgets {
+		if managed[dashboardWidgetPosition{row: w.Layout.Row, column: w.Layout.Column}] {
+			filtered = append(filtered, w)
+		}
+	}
+
+	return filtered
+}
+
+// mergeDashboardWidgets adds the existing widgets that aren't at a managed
+// position to the declared widgets. A declared widget replaces the existing
+// widget at its position. The existing widgets are raw API widgets so that
+// attributes the provider doesn't model are kept.
+func mergeDashboardWidgets(declared []interface{}, existing []interface{}, managed map[dashboardWidgetPosition]bool) []interface{} {
+	merged := append([]interface{}{}, declared...)
+
+	for _, widget := range existing {
+		w, ok := widget.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		if !managed[rawDashboardWidgetPosition(w)] {
+			merged = append(merged, w)
+		}
+	}
+
+	sort.SliceStable(merged, func(i, j int) bool {
+		pi := rawDashboardWidgetPosition(merged[i].(map[string]interface{}))
+		pj := rawDashboardWidgetPosition(merged[j].(map[string]interface{}))
+
+		if pi.row != pj.row {
+			return pi.row < pj.row
+		}
+
+		return pi.column < pj.column
+	})
+
+	return merged
+}
+
+func rawDashboardWidgetPosition(w map[string]interface{}) dashboardWidgetPosition {
+	layout, _ := w["layout"].(map[string]interface{})
+	row, _ := layout["row"].(float64)
+	column, _ := layout["column"].(float64)
+
+	return dashboardWidgetPosition{row: int(row), column: int(column)}
+}
+
+// updateDashboardKeepingWidgets updates the dashboard without removing the
+// widgets added outside Terraform, i.e. those not at the position of a widget
+// in the previous or the new configuration.
+func updateDashboardKeepingWidgets(client *newrelic.Client, dashboard *dashboard, d *schema.ResourceData) error {
+	existing, err := getDashboardJSON(client, dashboard.ID)
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(dashboard)
+	if err != nil {
+		return err
+	}
+
+	var raw map[string]interface{}
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return err
+	}
+
+	declared, _ := raw["widgets"].([]interface{})
+	existingWidgets, _ := existing["widgets"].([]interface{})
+
+	o, n := d.GetChange("widget")
+	raw["widgets"] = mergeDashboardWidgets(declared, existingWidgets, managedDashboardWidgetPositions(o, n))
+
+	if err := updateDashboardJSON(client, dashboard.ID, raw); err != nil {
+		var widgets []dashboardWidget
+		if body, jsonErr := json.Marshal(raw["widgets"]); jsonErr == nil {
+			json.Unmarshal(body, &widgets)
+		}
+
+		return dashboardWidgetError(widgets, err)
+	}
+
+	return nil
+}
+
+var dashboardWidgetIndexRegexp = regexp.MustCompile(`widgets\[(\d+)\]`)
+
+// dashboardWidgetError names the widget an API error is about, as the API
+// rejects the whole dashboard when a single widget is invalid. The widget is
+// found by its index in the error, e.g. "widgets[3].data[0].nrql is invalid",
+// or else by its query or title quoted in the error.
+func dashboardWidgetError(widgets []dashboardWidget, err error) error {
+	apiErr, ok := err.(*newrelic.ErrorResponse)
+	if !ok || apiErr.Detail == nil {
+		return err
+	}
+
+	message := apiErr.Detail.Title
+	widget := -1
+
+	if m := dashboardWidgetIndexRegexp.FindStringSubmatch(message); m != nil {
+		if i, _ := strconv.Atoi(m[1]); i < len(widgets) {
+			widget = i
+		}
+	}
+
+	for i := 0; widget == -1 && i < len(widgets); i++ {
+		w := widgets[i]
+
+		for _, data := range w.Data {
+			if data.NRQL != "" && strings.Contains(message, data.NRQL) {
+				widget = i
+			}
+		}
+
+		if w.Presentation.Title != "" && strings.Contains(message, strconv.Quote(w.Presentation.Title)) {
+			widget = i
+		}
+	}
+
+	if widget == -1 {
+		return err
+	}
+
+	w := widgets[widget]
+
+	return fmt.Errorf("widget %q at row %d, column %d: %s", w.Presentation.Title, w.Layout.Row, w.Layout.Column, message)
+}
+
+// Unpack the *dashboard variable and set resource data.
 //
 // Used by the newrelic_dashboard Read function (resourceNewRelicDashboardRead)
-func flattenDashboard(dashboard *newrelic.Dashboard, d *schema.ResourceData) error {
+func flattenDashboard(dashboard *dashboard, d *schema.ResourceData) error {
 	d.Set("title", dashboard.Title)
 	d.Set("icon", dashboard.Icon)
 	d.Set("visibility", dashboard.Visibility)
 	d.Set("editable", dashboard.Editable)
 	d.Set("dashboard_url", dashboard.UIURL)
+	d.Set("permalink", dashboard.UIURL)
+
+	// Dashboards created before the 12 column grid don't report their grid.
+	gridColumnCount := dashboard.GridColumnCount
+	if gridColumnCount == 0 {
+		gridColumnCount = 3
+	}
+	d.Set("grid_column_count", gridColumnCount)
+
 	if filterErr := d.Set("filter", flattenFilter(&dashboard.Filter)); filterErr != nil {
 		return filterErr
 	}
@@ -237,14 +530,20 @@ func flattenDashboard(dashboard *newrelic.Dashboard, d *schema.ResourceData) err
 		values["visualization"] = widget.Visualization
 		values["title"] = widget.Presentation.Title
 		values["notes"] = widget.Presentation.Notes
+		values["drilldown_dashboard_id"] = widget.Presentation.DrilldownDashboardID
 		values["row"] = widget.Layout.Row
 		values["column"] = widget.Layout.Column
 		values["width"] = widget.Layout.Width
 		values["height"] = widget.Layout.Height
 
-		// TODO: Support non-NRQL Widgets
+		if threshold := widget.Presentation.Threshold; threshold != nil {
+			values["threshold_red"] = threshold.Red
+			values["threshold_yellow"] = threshold.Yellow
+		}
+
 		if len(widget.Data) > 0 {
 			values["nrql"] = widget.Data[0].NRQL
+			values["source"] = widget.Data[0].Source
 		}
 		widgetSet.Add(values)
 	}
@@ -256,23 +555,98 @@ func flattenDashboard(dashboard *newrelic.Dashboard, d *schema.ResourceData) err
 	return nil
 }
 
+// importDashboard imports a dashboard by its numeric ID. Every widget of an
+// imported dashboard is managed, so that all of them are read into the state.
+func importDashboard(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return nil, fmt.Errorf("Error importing New Relic dashboard %q, expected the numeric dashboard ID", d.Id())
+	}
+
+	client, err := meta.(*ProviderConfig).clientFor(d)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("[INFO] Importing New Relic dashboard %d", id)
+
+	dashboard, err := getDashboardJSON(client, id)
+	if err != nil {
+		if err == newrelic.ErrNotFound {
+			return nil, fmt.Errorf("New Relic dashboard %d not found", id)
+		}
+
+		return nil, err
+	}
+
+	// Widgets whose data isn't a NRQL query or markdown source, e.g. APM
+	// metric charts, can't be represented by the widget block and would be
+	// replaced by an empty query on the next apply.
+	widgets, _ := dashboard["widgets"].([]interface{})
+	for _, w := range widgets {
+		widget, _ := w.(map[string]interface{})
+		if !isSupportedDashboardWidget(widget) {
+			position := rawDashboardWidgetPosition(widget)
+			log.Printf("[WARN] The widget at row %d, column %d of New Relic dashboard %d isn't a NRQL or markdown widget and can't be managed by newrelic_dashboard; set manage_widgets_exclusively to false to keep it, or use newrelic_dashboard_json", position.row, position.column, id)
+		}
+	}
+
+	d.Set("manage_widgets_exclusively", true)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// isSupportedDashboardWidget reports whether the data of a raw dashboard
+// widget is only a NRQL query or markdown source.
+func isSupportedDashboardWidget(widget map[string]interface{}) bool {
+	data, _ := widget["data"].([]interface{})
+	for _, d := range data {
+		m, _ := d.(map[string]interface{})
+		for k := range m {
+			if k != "nrql" && k != "source" {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
 func resourceNewRelicDashboardCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).Client
+	client, err := meta.(*ProviderConfig).clientFor(d)
+	if err != nil {
+		return err
+	}
+
 	dashboard := expandDashboard(d)
 	log.Printf("[INFO] Creating New Relic dashboard: %s", dashboard.Title)
 
-	dashboard, err := client.CreateDashboard(*dashboard)
+	widgets := dashboard.Widgets
+
+	dashboard, err = createDashboard(client, *dashboard)
 	if err != nil {
-		return err
+		return dashboardWidgetError(widgets, err)
 	}
 
 	d.SetId(strconv.Itoa(dashboard.ID))
 
+	// Large dashboards can take a while to become readable after creation.
+	err = waitForCreated(meta.(*ProviderConfig).stopContext(), d.Timeout(schema.TimeoutCreate), func() error {
+		_, err := getDashboard(client, dashboard.ID)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("Error waiting for New Relic dashboard %d to be created: %s", dashboard.ID, err)
+	}
+
 	return resourceNewRelicDashboardRead(d, meta)
 }
 
 func resourceNewRelicDashboardRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).Client
+	client, err := meta.(*ProviderConfig).clientFor(d)
+	if err != nil {
+		return err
+	}
 
 	log.Printf("[INFO] Reading New Relic dashboard %s", d.Id())
 
@@ -281,7 +655,7 @@ func resourceNewRelicDashboardRead(d *schema.ResourceData, meta interface{}) err
 		return err
 	}
 
-	dashboard, err := client.GetDashboard(dashboardID)
+	dashboard, err := getDashboard(client, dashboardID)
 	if err != nil {
 		if err == newrelic.ErrNotFound {
 			d.SetId("")
@@ -291,11 +665,19 @@ func resourceNewRelicDashboardRead(d *schema.ResourceData, meta interface{}) err
 		return err
 	}
 
+	if !d.Get("manage_widgets_exclusively").(bool) {
+		dashboard.Widgets = filterDashboardWidgets(dashboard.Widgets, managedDashboardWidgetPositions(d.Get("widget")))
+	}
+
 	return flattenDashboard(dashboard, d)
 }
 
 func resourceNewRelicDashboardUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).Client
+	client, err := meta.(*ProviderConfig).clientFor(d)
+	if err != nil {
+		return err
+	}
+
 	dashboard := expandDashboard(d)
 
 	id, err := strconv.Atoi(d.Id())
@@ -306,7 +688,13 @@ func resourceNewRelicDashboardUpdate(d *schema.ResourceData, meta interface{}) e
 	dashboard.ID = id
 	log.Printf("[INFO] Updating New Relic dashboard %d", id)
 
-	_, err = client.UpdateDashboard(*dashboard)
+	if d.Get("manage_widgets_exclusively").(bool) {
+		if _, err = updateDashboard(client, *dashboard); err != nil {
+			err = dashboardWidgetError(dashboard.Widgets, err)
+		}
+	} else {
+		err = updateDashboardKeepingWidgets(client, dashboard, d)
+	}
 	if err != nil {
 		return err
 	}
@@ -315,7 +703,10 @@ func resourceNewRelicDashboardUpdate(d *schema.ResourceData, meta interface{}) e
 }
 
 func resourceNewRelicDashboardDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).Client
+	client, err := meta.(*ProviderConfig).clientFor(d)
+	if err != nil {
+		return err
+	}
 
 	id, err := strconv.Atoi(d.Id())
 	if err != nil {
@@ -0,0 +1,300 @@
+package newrelic
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+
+	newrelic "github.com/paultyng/go-newrelic/v4/api"
+)
+
+// dashboardJSONServerFields are set by New Relic and ignored when comparing
+// dashboard definitions.
+var dashboardJSONServerFields = []string{
+	"api_url",
+	"created_at",
+	"id",
+	"owner_email",
+	"ui_url",
+	"updated_at",
+}
+
+func resourceNewRelicDashboardJSON() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceNewRelicDashboardJSONCreate,
+		Read:   resourceNewRelicDashboardJSONRead,
+		Update: resourceNewRelicDashboardJSONUpdate,
+		Delete: resourceNewRelicDashboardJSONDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"api_key": apiKeySchema(),
+			"json": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateFunc:     validateDashboardJSON,
+				DiffSuppressFunc: dashboardJSONDiffSuppressFunc,
+			},
+			"dashboard_url": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// expandDashboardJSON parses a dashboard definition, leaving out the fields
+// set by New Relic.
+func expandDashboardJSON(v string) (map[string]interface{}, error) {
+	var dashboard map[string]interface{}
+	if err := json.Unmarshal([]byte(v), &dashboard); err != nil {
+		return nil, err
+	}
+
+	if dashboard == nil {
+		return nil, fmt.Errorf("expected a JSON object")
+	}
+
+	for _, field := range dashboardJSONServerFields {
+		delete(dashboard, field)
+	}
+
+	return dashboard, nil
+}
+
+func validateDashboardJSON(v interface{}, k string) (ws []string, errors []error) {
+	value, ok := v.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected type of %s to be string", k))
+		return
+	}
+
+	dashboard, err := expandDashboardJSON(value)
+	if err != nil {
+		errors = append(errors, fmt.Errorf("%s is not a valid dashboard definition: %s", k, err))
+		return
+	}
+
+	if _, ok := dashboard["dashboard"]; ok && len(dashboard) == 1 {
+		errors = append(errors, fmt.Errorf("%s must be the dashboard object itself, not wrapped in \"dashboard\"", k))
+		return
+	}
+
+	if title, ok := dashboard["title"].(string); !ok || title == "" {
+		errors = append(errors, fmt.Errorf("%s must have a \"title\"", k))
+	}
+
+	if metadata, ok := dashboard["metadata"].(map[string]interface{}); !ok || metadata["version"] == nil {
+		errors = append(errors, fmt.Errorf("%s must have a \"metadata\" object with a \"version\"", k))
+	}
+
+	if widgets, ok := dashboard["widgets"]; ok {
+		if _, ok := widgets.([]interface{}); !ok {
+			errors = append(errors, fmt.Errorf("%s \"widgets\" must be an array", k))
+		}
+	}
+
+	return
+}
+
+// dashboardJSONDiffSuppressFunc compares dashboard definitions semantically,
+// so formatting and key order don't cause a diff.
+func dashboardJSONDiffSuppressFunc(k, old, new string, d *schema.ResourceData) bool {
+	oldDashboard, err := expandDashboardJSON(old)
+	if err != nil {
+		return false
+	}
+
+	newDashboard, err := expandDashboardJSON(new)
+	if err != nil {
+		return false
+	}
+
+	oldJSON, _ := json.Marshal(oldDashboard)
+	newJSON, _ := json.Marshal(newDashboard)
+
+	return string(oldJSON) == string(newJSON)
+}
+
+// pruneDashboardJSON removes the attributes of v that aren't in managed, such
+// as the defaults New Relic fills in, so they don't show up as a diff. Lists
+// are pruned element by element.
+func pruneDashboardJSON(v interface{}, managed interface{}) interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		managedMap, ok := managed.(map[string]interface{})
+		if !ok {
+			return v
+		}
+
+		pruned := make(map[string]interface{}, len(managedMap))
+		for k, m := range managedMap {
+			if attr, ok := value[k]; ok {
+				pruned[k] = pruneDashboardJSON(attr, m)
+			}
+		}
+
+		return pruned
+	case []interface{}:
+		managedList, ok := managed.([]interface{})
+		if !ok {
+			return v
+		}
+
+		pruned := make([]interface{}, len(value))
+		for i, elem := range value {
+			if i < len(managedList) {
+				pruned[i] = pruneDashboardJSON(elem, managedList[i])
+			} else {
+				pruned[i] = elem
+			}
+		}
+
+		return pruned
+	}
+
+	return v
+}
+
+// flattenDashboardJSON sets json to the dashboard returned by the API. Only the
+// attributes already in json are kept, unless it is empty, e.g. on import.
+func flattenDashboardJSON(dashboard map[string]interface{}, d *schema.ResourceData) error {
+	if dashboardURL, ok := dashboard["ui_url"].(string); ok {
+		d.Set("dashboard_url", dashboardURL)
+	}
+
+	for _, field := range dashboardJSONServerFields {
+		delete(dashboard, field)
+	}
+
+	var flattened interface{} = dashboard
+	if managed, err := expandDashboardJSON(d.Get("json").(string)); err == nil {
+		flattened = pruneDashboardJSON(dashboard, managed)
+	}
+
+	dashboardJSON, err := json.Marshal(flattened)
+	if err != nil {
+		return err
+	}
+
+	return d.Set("json", string(dashboardJSON))
+}
+
+func resourceNewRelicDashboardJSONCreate(d *schema.ResourceData, meta interface{}) error {
+	client, err := meta.(*ProviderConfig).clientFor(d)
+	if err != nil {
+		return err
+	}
+
+	dashboard, err := expandDashboardJSON(d.Get("json").(string))
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Creating New Relic dashboard: %v", dashboard["title"])
+
+	created, err := createDashboardJSON(client, dashboard)
+	if err != nil {
+		return err
+	}
+
+	id, err := dashboardJSONID(created)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(strconv.Itoa(id))
+
+	// Large dashboards can take a while to become readable after creation.
+	err = waitForCreated(meta.(*ProviderConfig).stopContext(), d.Timeout(schema.TimeoutCreate), func() error {
+		_, err := getDashboardJSON(client, id)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("Error waiting for New Relic dashboard %d to be created: %s", id, err)
+	}
+
+	return resourceNewRelicDashboardJSONRead(d, meta)
+}
+
+func resourceNewRelicDashboardJSONRead(d *schema.ResourceData, meta interface{}) error {
+	client, err := meta.(*ProviderConfig).clientFor(d)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Reading New Relic dashboard %s", d.Id())
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return err
+	}
+
+	dashboard, err := getDashboardJSON(client, id)
+	if err != nil {
+		if err == newrelic.ErrNotFound {
+			d.SetId("")
+			return nil
+		}
+
+		return err
+	}
+
+	return flattenDashboardJSON(dashboard, d)
+}
+
+func resourceNewRelicDashboardJSONUpdate(d *schema.ResourceData, meta interface{}) error {
+	client, err := meta.(*ProviderConfig).clientFor(d)
+	if err != nil {
+		return err
+	}
+
+	dashboard, err := expandDashboardJSON(d.Get("json").(string))
+	if err != nil {
+		return err
+	}
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Updating New Relic dashboard %d", id)
+
+	if err := updateDashboardJSON(client, id, dashboard); err != nil {
+		return err
+	}
+
+	return resourceNewRelicDashboardJSONRead(d, meta)
+}
+
+func resourceNewRelicDashboardJSONDelete(d *schema.ResourceData, meta interface{}) error {
+	client, err := meta.(*ProviderConfig).clientFor(d)
+	if err != nil {
+		return err
+	}
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Deleting New Relic dashboard %v", id)
+
+	if err := client.DeleteDashboard(id); err != nil {
+		if err == newrelic.ErrNotFound {
+			return nil
+		}
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,308 @@
+package newrelic
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+	newrelic "github.com/paultyng/go-newrelic/v4/api"
+)
+
+func TestAccNewRelicDashboardJSON_Basic(t *testing.T) {
+	rName := fmt.Sprintf("tf-test-%s", acctest.RandString(5))
+	rNameUpdated := fmt.Sprintf("%s-updated", rName)
+	resourceName := "newrelic_dashboard_json.foo"
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNewRelicDashboardJSONDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckNewRelicDashboardJSONConfig(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicDashboardJSONExists(resourceName, rName),
+					resource.TestCheckResourceAttrSet(resourceName, "dashboard_url"),
+				),
+			},
+			{
+				Config: testAccCheckNewRelicDashboardJSONConfig(rNameUpdated),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicDashboardJSONExists(resourceName, rNameUpdated),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				// Imported dashboards include the defaults New Relic fills in.
+				ImportStateVerifyIgnore: []string{"json"},
+			},
+		},
+	})
+}
+
+func TestAccNewRelicDashboardJSON_invalidJSON(t *testing.T) {
+	expectedErrorMsg, _ := regexp.Compile("json must have a \"metadata\" object with a \"version\"")
+	resource.Test(t, resource.TestCase{
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "newrelic_dashboard_json" "foo" {
+  json = "{\"title\": \"tf-test\"}"
+}
+`,
+				ExpectError: expectedErrorMsg,
+			},
+		},
+	})
+}
+
+func TestValidateDashboardJSON(t *testing.T) {
+	runTestCases(t, []testCase{
+		{
+			val: `{"title": "foo", "metadata": {"version": 1}}`,
+			f:   validateDashboardJSON,
+		},
+		{
+			val: `{"title": "foo", "metadata": {"version": 1}, "widgets": []}`,
+			f:   validateDashboardJSON,
+		},
+		{
+			val:         `{"title": "foo",`,
+			f:           validateDashboardJSON,
+			expectedErr: regexp.MustCompile("[\\w]+ is not a valid dashboard definition"),
+		},
+		{
+			val:         `["foo"]`,
+			f:           validateDashboardJSON,
+			expectedErr: regexp.MustCompile("[\\w]+ is not a valid dashboard definition"),
+		},
+		{
+			val:         `{"dashboard": {"title": "foo", "metadata": {"version": 1}}}`,
+			f:           validateDashboardJSON,
+			expectedErr: regexp.MustCompile("[\\w]+ must be the dashboard object itself"),
+		},
+		{
+			val:         `{"metadata": {"version": 1}}`,
+			f:           validateDashboardJSON,
+			expectedErr: regexp.MustCompile("[\\w]+ must have a \"title\""),
+		},
+		{
+			val:         `{"title": "foo"}`,
+			f:           validateDashboardJSON,
+			expectedErr: regexp.MustCompile("[\\w]+ must have a \"metadata\" object with a \"version\""),
+		},
+		{
+			val:         `{"title": "foo", "metadata": {"version": 1}, "widgets": {}}`,
+			f:           validateDashboardJSON,
+			expectedErr: regexp.MustCompile("[\\w]+ \"widgets\" must be an array"),
+		},
+		{
+			val:         1,
+			f:           validateDashboardJSON,
+			expectedErr: regexp.MustCompile("expected type of [\\w]+ to be string"),
+		},
+	})
+}
+
+func TestDashboardJSONDiffSuppressFunc(t *testing.T) {
+	cases := []struct {
+		old      string
+		new      string
+		suppress bool
+	}{
+		{
+			old:      `{"title":"foo","metadata":{"version":1}}`,
+			new:      "{\n  \"metadata\": {\"version\": 1},\n  \"title\": \"foo\"\n}",
+			suppress: true,
+		},
+		{
+			old:      `{"id":1,"created_at":"2019-01-01T00:00:00Z","title":"foo","metadata":{"version":1}}`,
+			new:      `{"title":"foo","metadata":{"version":1}}`,
+			suppress: true,
+		},
+		{
+			old:      `{"title":"foo","metadata":{"version":1}}`,
+			new:      `{"title":"bar","metadata":{"version":1}}`,
+			suppress: false,
+		},
+		{
+			old:      "",
+			new:      `{"title":"foo","metadata":{"version":1}}`,
+			suppress: false,
+		},
+	}
+
+	for _, c := range cases {
+		if suppress := dashboardJSONDiffSuppressFunc("json", c.old, c.new, nil); suppress != c.suppress {
+			t.Errorf("expected suppress %t for %s and %s, got %t", c.suppress, c.old, c.new, suppress)
+		}
+	}
+}
+
+func TestPruneDashboardJSON(t *testing.T) {
+	var dashboard, managed, expected interface{}
+
+	json.Unmarshal([]byte(`{
+		"title": "foo",
+		"icon": "bar-chart",
+		"metadata": {"version": 1},
+		"widgets": [
+			{"visualization": "billboard", "widget_id": 1, "layout": {"row": 1, "column": 1, "width": 1, "height": 1}},
+			{"visualization": "markdown", "widget_id": 2}
+		]
+	}`), &dashboard)
+	json.Unmarshal([]byte(`{
+		"title": "foo",
+		"metadata": {"version": 1},
+		"widgets": [
+			{"visualization": "billboard", "layout": {"row": 1, "column": 1}}
+		]
+	}`), &managed)
+	json.Unmarshal([]byte(`{
+		"title": "foo",
+		"metadata": {"version": 1},
+		"widgets": [
+			{"visualization": "billboard", "layout": {"row": 1, "column": 1}},
+			{"visualization": "markdown", "widget_id": 2}
+		]
+	}`), &expected)
+
+	if pruned := pruneDashboardJSON(dashboard, managed); !reflect.DeepEqual(pruned, expected) {
+		t.Fatalf("expected %v, got %v", expected, pruned)
+	}
+}
+
+func TestResourceNewRelicDashboardJSONCreate_readError(t *testing.T) {
+	reads := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.Method == "POST" {
+			w.Write([]byte(`{"dashboard":{"id":123,"title":"foo"}}`))
+			return
+		}
+
+		reads++
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"error":{"title":"Forbidden"}}`))
+	}))
+	defer server.Close()
+
+	client := newrelic.New(newrelic.Config{BaseURL: server.URL})
+
+	r := resourceNewRelicDashboardJSON()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"json": `{"title": "foo", "metadata": {"version": 1}}`,
+	})
+
+	// Only a dashboard that isn't found yet is waited for.
+	if err := r.Create(d, &ProviderConfig{Client: &client}); err == nil || reads != 1 {
+		t.Fatalf("expected the read error to be returned after 1 read, got %v after %d", err, reads)
+	}
+}
+
+func TestResourceNewRelicDashboardJSONRead_notFound(t *testing.T) {
+	testResourceReadNotFound(t, resourceNewRelicDashboardJSON(), "789")
+}
+
+func testAccCheckNewRelicDashboardJSONDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*ProviderConfig).Client
+	for _, r := range s.RootModule().Resources {
+		if r.Type != "newrelic_dashboard_json" {
+			continue
+		}
+
+		id, err := strconv.ParseInt(r.Primary.ID, 10, 32)
+		if err != nil {
+			return err
+		}
+
+		_, err = client.GetDashboard(int(id))
+
+		if err == nil {
+			return fmt.Errorf("Dashboard still exists")
+		}
+
+	}
+	return nil
+}
+
+func testAccCheckNewRelicDashboardJSONExists(n string, title string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No dashboard ID is set")
+		}
+
+		client := testAccProvider.Meta().(*ProviderConfig).Client
+
+		id, err := strconv.ParseInt(rs.Primary.ID, 10, 32)
+		if err != nil {
+			return err
+		}
+
+		found, err := client.GetDashboard(int(id))
+		if err != nil {
+			return err
+		}
+
+		if found.Title != title {
+			return fmt.Errorf("expected dashboard %s to have title %s, got %s", rs.Primary.ID, title, found.Title)
+		}
+
+		if len(found.Widgets) != 1 || found.Widgets[0].Visualization != "billboard" {
+			return fmt.Errorf("expected dashboard %s to have a billboard widget, got %v", rs.Primary.ID, found.Widgets)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckNewRelicDashboardJSONConfig(title string) string {
+	return fmt.Sprintf(`
+resource "newrelic_dashboard_json" "foo" {
+  json = <<EOF
+{
+  "title": "%s",
+  "metadata": {
+    "version": 1
+  },
+  "widgets": [
+    {
+      "visualization": "billboard",
+      "layout": {
+        "row": 1,
+        "column": 1,
+        "width": 1,
+        "height": 1
+      },
+      "data": [
+        {
+          "nrql": "SELECT count(*) FROM Transaction"
+        }
+      ],
+      "presentation": {
+        "title": "Transactions"
+      }
+    }
+  ]
+}
+EOF
+}
+`, title)
+}
@@ -1,13 +1,24 @@
 package newrelic
 
 import (
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"regexp"
+	"sort"
 	"strconv"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform/config"
 	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
+	newrelic "github.com/paultyng/go-newrelic/v4/api"
 )
 
 func TestAccNewRelicDashboard_Basic(t *testing.T) {
@@ -98,6 +109,529 @@ func TestAccNewRelicDashboard_Basic(t *testing.T) {
 	})
 }
 
+func TestAccNewRelicDashboard_Drilldown(t *testing.T) {
+	rName := fmt.Sprintf("tf-test-%s", acctest.RandString(5))
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNewRelicDashboardDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckNewRelicDashboardConfigDrilldown(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicDashboardExists("newrelic_dashboard.foo"),
+					testAccCheckNewRelicDashboardExists("newrelic_dashboard.bar"),
+					resource.TestCheckResourceAttr(
+						"newrelic_dashboard.foo", "widget.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestResourceNewRelicDashboardWidgetsHash_Drilldown(t *testing.T) {
+	widget := map[string]interface{}{
+		"title":         "Average Transaction Duration",
+		"visualization": "faceted_line_chart",
+		"row":           1,
+		"column":        1,
+		"width":         1,
+		"height":        1,
+		"notes":         "",
+		"nrql":          "SELECT AVERAGE(duration) from Transaction FACET appName TIMESERIES auto",
+	}
+
+	// Widgets without a drilldown dashboard keep the hash they had before
+	// the attribute was added.
+	if hash := resourceNewRelicDashboardWidgetsHash(widget); hash != 754238675 {
+		t.Fatalf("expected hash 754238675, got %d", hash)
+	}
+
+	widget["drilldown_dashboard_id"] = 0
+	if hash := resourceNewRelicDashboardWidgetsHash(widget); hash != 754238675 {
+		t.Fatalf("expected hash 754238675, got %d", hash)
+	}
+
+	widget["drilldown_dashboard_id"] = 1234
+	if hash := resourceNewRelicDashboardWidgetsHash(widget); hash == 754238675 {
+		t.Fatal("expected the drilldown dashboard ID to change the hash")
+	}
+}
+
+func TestResourceNewRelicDashboardWidgetsHash_SourceAndThresholds(t *testing.T) {
+	widget := map[string]interface{}{
+		"title":            "Average Transaction Duration",
+		"visualization":    "faceted_line_chart",
+		"row":              1,
+		"column":           1,
+		"width":            1,
+		"height":           1,
+		"notes":            "",
+		"nrql":             "SELECT AVERAGE(duration) from Transaction FACET appName TIMESERIES auto",
+		"source":           "",
+		"threshold_red":    0.0,
+		"threshold_yellow": 0.0,
+	}
+
+	if hash := resourceNewRelicDashboardWidgetsHash(widget); hash != 754238675 {
+		t.Fatalf("expected hash 754238675, got %d", hash)
+	}
+
+	widget["threshold_red"] = 10.0
+	red := resourceNewRelicDashboardWidgetsHash(widget)
+	if red == 754238675 {
+		t.Fatal("expected threshold_red to change the hash")
+	}
+
+	widget["threshold_red"], widget["threshold_yellow"] = 0.0, 10.0
+	if hash := resourceNewRelicDashboardWidgetsHash(widget); hash == 754238675 || hash == red {
+		t.Fatal("expected threshold_yellow to change the hash")
+	}
+
+	widget["threshold_yellow"], widget["source"] = 0.0, "# Notes"
+	if hash := resourceNewRelicDashboardWidgetsHash(widget); hash == 754238675 {
+		t.Fatal("expected source to change the hash")
+	}
+}
+
+func TestAccNewRelicDashboard_markdownAndBillboard(t *testing.T) {
+	rName := fmt.Sprintf("tf-test-%s", acctest.RandString(5))
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNewRelicDashboardDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckNewRelicDashboardConfigMarkdownAndBillboard(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicDashboardExists("newrelic_dashboard.foo"),
+					resource.TestCheckResourceAttr(
+						"newrelic_dashboard.foo", "widget.#", "3"),
+				),
+			},
+			{
+				Config:   testAccCheckNewRelicDashboardConfigMarkdownAndBillboard(rName),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccNewRelicDashboard_invalidWidgets(t *testing.T) {
+	rName := fmt.Sprintf("tf-test-%s", acctest.RandString(5))
+	resource.Test(t, resource.TestCase{
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCheckNewRelicDashboardConfigWidget(rName, "markdown", `nrql = "SELECT count(*) FROM Transaction"`),
+				ExpectError: regexp.MustCompile("source is required for markdown widgets"),
+			},
+			{
+				Config:      testAccCheckNewRelicDashboardConfigWidget(rName, "line_chart", "threshold_red = 10"),
+				ExpectError: regexp.MustCompile("thresholds are only supported on billboard widgets"),
+			},
+		},
+	})
+}
+
+func TestAccNewRelicDashboard_shuffledWidgets(t *testing.T) {
+	rName := fmt.Sprintf("tf-test-%s", acctest.RandString(5))
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNewRelicDashboardDestroy,
+		Steps: []resource.TestStep{
+			// Applying twice makes sure the second plan is empty.
+			{
+				Config: testAccCheckNewRelicDashboardConfigShuffledWidgets(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicDashboardExists("newrelic_dashboard.foo"),
+					resource.TestCheckResourceAttr(
+						"newrelic_dashboard.foo", "widget.#", "4"),
+				),
+			},
+			{
+				Config:   testAccCheckNewRelicDashboardConfigShuffledWidgets(rName),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestSortDashboardWidgets(t *testing.T) {
+	widgets := []dashboardWidget{
+		{Presentation: dashboardWidgetPresentation{Title: "c"}, Layout: newrelic.DashboardWidgetLayout{Row: 2, Column: 1}},
+		{Presentation: dashboardWidgetPresentation{Title: "b"}, Layout: newrelic.DashboardWidgetLayout{Row: 1, Column: 2}},
+		{Presentation: dashboardWidgetPresentation{Title: "d"}, Layout: newrelic.DashboardWidgetLayout{Row: 2, Column: 3}},
+		{Presentation: dashboardWidgetPresentation{Title: "a"}, Layout: newrelic.DashboardWidgetLayout{Row: 1, Column: 1}},
+	}
+
+	sortDashboardWidgets(widgets)
+
+	var titles string
+	for _, w := range widgets {
+		titles += w.Presentation.Title
+	}
+
+	if titles != "abcd" {
+		t.Fatalf("expected widgets sorted by row and column, got %s", titles)
+	}
+}
+
+func TestDashboardWidgets_roundTrip(t *testing.T) {
+	r := resourceNewRelicDashboard()
+	configured := []interface{}{
+		map[string]interface{}{"title": "d", "visualization": "billboard", "row": 2, "column": 3, "nrql": "SELECT count(*) FROM PageView", "threshold_red": 10.0},
+		map[string]interface{}{"title": "a", "visualization": "faceted_line_chart", "row": 1, "column": 1, "nrql": "SELECT average(duration) FROM Transaction FACET appName\n"},
+		map[string]interface{}{"title": "c", "visualization": "markdown", "row": 2, "column": 1, "source": "# Heading\n"},
+		map[string]interface{}{"title": "b", "visualization": "billboard", "row": 1, "column": 2, "nrql": "SELECT count(*) FROM Transaction", "drilldown_dashboard_id": 123},
+	}
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"title":  "foo",
+		"widget": configured,
+	})
+
+	// New Relic returns the widgets in its own order and strips the whitespace
+	// around queries and sources.
+	dashboard := expandDashboard(d)
+	for i, j := 0, len(dashboard.Widgets)-1; i < j; i, j = i+1, j-1 {
+		dashboard.Widgets[i], dashboard.Widgets[j] = dashboard.Widgets[j], dashboard.Widgets[i]
+	}
+
+	for _, w := range dashboard.Widgets {
+		if data := w.Data[0]; data.NRQL != strings.TrimSpace(data.NRQL) || data.Source != strings.TrimSpace(data.Source) {
+			t.Fatalf("expected widget %q to be sent without surrounding whitespace, got %#v", w.Presentation.Title, data)
+		}
+	}
+
+	read := r.TestResourceData()
+	if err := flattenDashboard(dashboard, read); err != nil {
+		t.Fatal(err)
+	}
+
+	hashes := func(set *schema.Set) []int {
+		codes := []int{}
+		for _, v := range set.List() {
+			codes = append(codes, resourceNewRelicDashboardWidgetsHash(v))
+		}
+		sort.Ints(codes)
+		return codes
+	}
+
+	expected := hashes(d.Get("widget").(*schema.Set))
+	if actual := hashes(read.Get("widget").(*schema.Set)); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected the widgets read back to hash to %v, got %v", expected, actual)
+	}
+}
+
+func TestAccNewRelicDashboard_unmanagedWidgets(t *testing.T) {
+	rName := fmt.Sprintf("tf-test-%s", acctest.RandString(5))
+	resourceName := "newrelic_dashboard.foo"
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNewRelicDashboardDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckNewRelicDashboardConfigUnmanagedWidgets(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicDashboardExists(resourceName),
+					testAccAddNewRelicDashboardWidget(resourceName),
+				),
+			},
+			{
+				Config: testAccCheckNewRelicDashboardConfigUnmanagedWidgets(rName + "-updated"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicDashboardWidgetCount(resourceName, 2),
+					resource.TestCheckResourceAttr(resourceName, "title", rName+"-updated"),
+					resource.TestCheckResourceAttr(resourceName, "widget.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestMergeDashboardWidgets(t *testing.T) {
+	var declared, existing []interface{}
+
+	json.Unmarshal([]byte(`[
+		{"visualization": "billboard", "layout": {"row": 1, "column": 2}}
+	]`), &declared)
+	json.Unmarshal([]byte(`[
+		{"visualization": "markdown", "layout": {"row": 1, "column": 2}},
+		{"visualization": "gauge", "layout": {"row": 2, "column": 1}, "data": [{"apm": {"metrics": []}}]},
+		{"visualization": "line_chart", "layout": {"row": 1, "column": 1}},
+		{"visualization": "heatmap", "layout": {"row": 3, "column": 1}}
+	]`), &existing)
+
+	managed := map[dashboardWidgetPosition]bool{
+		{row: 1, column: 2}: true,
+		{row: 3, column: 1}: true,
+	}
+
+	merged := mergeDashboardWidgets(declared, existing, managed)
+
+	expected := []string{"line_chart", "billboard", "gauge"}
+	if len(merged) != len(expected) {
+		t.Fatalf("expected %d widgets, got %v", len(expected), merged)
+	}
+
+	for i, visualization := range expected {
+		w := merged[i].(map[string]interface{})
+		if w["visualization"] != visualization {
+			t.Fatalf("expected widget %d to be a %s, got %v", i, visualization, merged)
+		}
+	}
+
+	if _, ok := merged[2].(map[string]interface{})["data"]; !ok {
+		t.Fatalf("expected the unmanaged widget's data to be kept, got %v", merged[2])
+	}
+}
+
+func TestResourceNewRelicDashboardUpdate_unmanagedWidgets(t *testing.T) {
+	var updated struct {
+		Dashboard struct {
+			Widgets []map[string]interface{} `json:"widgets"`
+		} `json:"dashboard"`
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.Method == "PUT" {
+			body, _ := ioutil.ReadAll(r.Body)
+			json.Unmarshal(body, &updated)
+			w.Write([]byte(`{"dashboard":{"id":1}}`))
+			return
+		}
+
+		w.Write([]byte(`{"dashboard":{"id":1,"title":"foo","widgets":[
+			{"visualization":"billboard","layout":{"row":1,"column":1,"width":1,"height":1},"presentation":{"title":"managed"},"data":[{"nrql":"SELECT count(*) FROM Transaction"}]},
+			{"visualization":"gauge","layout":{"row":2,"column":1,"width":1,"height":1},"presentation":{"title":"unmanaged"},"data":[{"apm":{"metrics":[]}}]}
+		]}}`))
+	}))
+	defer server.Close()
+
+	client := newrelic.New(newrelic.Config{BaseURL: server.URL})
+	meta := &ProviderConfig{Client: &client}
+
+	r := resourceNewRelicDashboard()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"title":                      "foo",
+		"manage_widgets_exclusively": false,
+		"widget": []interface{}{
+			map[string]interface{}{
+				"title":         "managed",
+				"visualization": "billboard",
+				"row":           1,
+				"column":        1,
+				"nrql":          "SELECT count(*) FROM Transaction",
+			},
+		},
+	})
+	d.SetId("1")
+
+	if err := r.Update(d, meta); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(updated.Dashboard.Widgets) != 2 {
+		t.Fatalf("expected the managed and unmanaged widgets to be sent, got %v", updated.Dashboard.Widgets)
+	}
+
+	if _, ok := updated.Dashboard.Widgets[1]["data"].([]interface{})[0].(map[string]interface{})["apm"]; !ok {
+		t.Fatalf("expected the unmanaged widget to be sent unchanged, got %v", updated.Dashboard.Widgets[1])
+	}
+
+	if n := d.Get("widget").(*schema.Set).Len(); n != 1 {
+		t.Fatalf("expected only the managed widget to be read, got %d widgets", n)
+	}
+}
+
+func TestResourceNewRelicDashboard_gridColumnCountAndFilter(t *testing.T) {
+	var created map[string]interface{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.Method == "POST" {
+			var req struct {
+				Dashboard map[string]interface{} `json:"dashboard"`
+			}
+			json.NewDecoder(r.Body).Decode(&req)
+			created = req.Dashboard
+			created["id"] = 1
+		}
+
+		json.NewEncoder(w).Encode(map[string]interface{}{"dashboard": created})
+	}))
+	defer server.Close()
+
+	client := newrelic.New(newrelic.Config{BaseURL: server.URL})
+	meta := &ProviderConfig{Client: &client}
+
+	r := resourceNewRelicDashboard()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"title":             "foo",
+		"grid_column_count": 12,
+		"filter": []interface{}{
+			map[string]interface{}{
+				"event_types": []interface{}{"Transaction"},
+				"attributes":  []interface{}{"appName"},
+			},
+		},
+	})
+
+	if err := r.Create(d, meta); err != nil {
+		t.Fatal(err)
+	}
+
+	if created["grid_column_count"] != 12.0 {
+		t.Fatalf("expected grid_column_count 12 to be sent, got %v", created["grid_column_count"])
+	}
+
+	filter, _ := created["filter"].(map[string]interface{})
+	if !reflect.DeepEqual(filter["event_types"], []interface{}{"Transaction"}) || !reflect.DeepEqual(filter["attributes"], []interface{}{"appName"}) {
+		t.Fatalf("expected the filter to be sent, got %v", created["filter"])
+	}
+
+	if n := d.Get("grid_column_count").(int); n != 12 {
+		t.Fatalf("expected grid_column_count 12 to be read back, got %d", n)
+	}
+
+	if !d.Get("filter.0.event_types").(*schema.Set).Contains("Transaction") || !d.Get("filter.0.attributes").(*schema.Set).Contains("appName") {
+		t.Fatalf("expected the filter to be read back, got %v", d.Get("filter"))
+	}
+
+	// Dashboards that don't report their grid use the 3 column grid.
+	delete(created, "grid_column_count")
+
+	if err := r.Read(d, meta); err != nil {
+		t.Fatal(err)
+	}
+
+	if n := d.Get("grid_column_count").(int); n != 3 {
+		t.Fatalf("expected grid_column_count to default to 3, got %d", n)
+	}
+
+	// An unset grid_column_count is left to New Relic.
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"title": "foo",
+	})
+
+	if err := r.Create(d, meta); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := created["grid_column_count"]; ok {
+		t.Fatalf("expected no grid_column_count to be sent, got %v", created["grid_column_count"])
+	}
+}
+
+func TestDashboardWidgetError(t *testing.T) {
+	widgets := []dashboardWidget{
+		{
+			Layout:       newrelic.DashboardWidgetLayout{Row: 1, Column: 1},
+			Presentation: dashboardWidgetPresentation{Title: "Transactions"},
+			Data:         []dashboardWidgetData{{NRQL: "SELECT count(*) FROM Transaction"}},
+		},
+		{
+			Layout:       newrelic.DashboardWidgetLayout{Row: 1, Column: 2},
+			Presentation: dashboardWidgetPresentation{Title: "Page views"},
+			Data:         []dashboardWidgetData{{NRQL: "SELECT count(* FROM PageView"}},
+		},
+	}
+
+	cases := map[string]struct {
+		err      error
+		expected string
+	}{
+		"index": {
+			err:      &newrelic.ErrorResponse{Detail: &newrelic.ErrorDetail{Title: "widgets[1].data[0].nrql is invalid"}},
+			expected: `widget "Page views" at row 1, column 2: widgets[1].data[0].nrql is invalid`,
+		},
+		"query": {
+			err:      &newrelic.ErrorResponse{Detail: &newrelic.ErrorDetail{Title: "Invalid NRQL query: SELECT count(* FROM PageView"}},
+			expected: `widget "Page views" at row 1, column 2: Invalid NRQL query: SELECT count(* FROM PageView`,
+		},
+		"title": {
+			err:      &newrelic.ErrorResponse{Detail: &newrelic.ErrorDetail{Title: `Widget "Transactions" has an invalid layout`}},
+			expected: `widget "Transactions" at row 1, column 1: Widget "Transactions" has an invalid layout`,
+		},
+		"index out of range": {
+			err:      &newrelic.ErrorResponse{Detail: &newrelic.ErrorDetail{Title: "widgets[5] is invalid"}},
+			expected: "widgets[5] is invalid",
+		},
+		"another error": {
+			err:      fmt.Errorf("Unexpected status 500 returned from API"),
+			expected: "Unexpected status 500 returned from API",
+		},
+	}
+
+	for name, c := range cases {
+		if err := dashboardWidgetError(widgets, c.err); err.Error() != c.expected {
+			t.Errorf("%s: expected %q, got %q", name, c.expected, err)
+		}
+	}
+}
+
+func TestResourceNewRelicDashboardCreate_invalidWidget(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		w.Write([]byte(`{"error":{"title":"widgets[1].data[0].nrql is invalid"}}`))
+	}))
+	defer server.Close()
+
+	client := newrelic.New(newrelic.Config{BaseURL: server.URL})
+
+	r := resourceNewRelicDashboard()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"title": "foo",
+		"widget": []interface{}{
+			map[string]interface{}{"title": "second", "visualization": "billboard", "row": 2, "column": 1, "nrql": "SELECT count(* FROM PageView"},
+			map[string]interface{}{"title": "first", "visualization": "billboard", "row": 1, "column": 1, "nrql": "SELECT count(*) FROM Transaction"},
+		},
+	})
+
+	// Widgets are sent ordered by position, so widgets[1] is the second row.
+	expected := `widget "second" at row 2, column 1: widgets[1].data[0].nrql is invalid`
+	if err := r.Create(d, &ProviderConfig{Client: &client}); err == nil || err.Error() != expected {
+		t.Fatalf("expected %q, got %q", expected, err)
+	}
+}
+
+func TestResourceNewRelicDashboardCreate_readError(t *testing.T) {
+	reads := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.Method == "POST" {
+			w.Write([]byte(`{"dashboard":{"id":123,"title":"foo"}}`))
+			return
+		}
+
+		reads++
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"error":{"title":"Forbidden"}}`))
+	}))
+	defer server.Close()
+
+	client := newrelic.New(newrelic.Config{BaseURL: server.URL})
+
+	r := resourceNewRelicDashboard()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"title": "foo",
+	})
+
+	// Only a dashboard that isn't found yet is waited for.
+	if err := r.Create(d, &ProviderConfig{Client: &client}); err == nil || reads != 1 {
+		t.Fatalf("expected the read error to be returned after 1 read, got %v after %d", err, reads)
+	}
+}
+
 func TestAccNewRelicDashboard_import(t *testing.T) {
 	resourceName := "newrelic_dashboard.foo"
 	rName := acctest.RandString(5)
@@ -120,6 +654,108 @@ func TestAccNewRelicDashboard_import(t *testing.T) {
 	})
 }
 
+func TestResourceNewRelicDashboardImport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"dashboard":{"id":1,"title":"foo","icon":"line-chart","visibility":"owner","editable":"read_only","ui_url":"https://insights.newrelic.com/1","widgets":[
+			{"visualization":"markdown","layout":{"row":2,"column":1,"width":1,"height":1},"presentation":{"title":"notes"},"data":[{"source":"# Runbook"}]},
+			{"visualization":"billboard","layout":{"row":1,"column":2,"width":1,"height":1},"presentation":{"title":"errors"},"data":[{"nrql":"SELECT count(*) FROM TransactionError"}]},
+			{"visualization":"billboard","layout":{"row":1,"column":1,"width":1,"height":1},"presentation":{"title":"transactions"},"data":[{"nrql":"SELECT count(*) FROM Transaction"}]}
+		]}}`))
+	}))
+	defer server.Close()
+
+	client := newrelic.New(newrelic.Config{BaseURL: server.URL})
+	meta := &ProviderConfig{Client: &client}
+
+	r := resourceNewRelicDashboard()
+
+	d := r.TestResourceData()
+	d.SetId("1")
+
+	imported, err := r.Importer.State(d, meta)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := r.Read(imported[0], meta); err != nil {
+		t.Fatal(err)
+	}
+
+	state := imported[0].State()
+	for k, v := range map[string]string{
+		"title":                      "foo",
+		"icon":                       "line-chart",
+		"visibility":                 "owner",
+		"editable":                   "read_only",
+		"manage_widgets_exclusively": "true",
+		"widget.#":                   "3",
+	} {
+		if state.Attributes[k] != v {
+			t.Fatalf("expected %s to be %q, got %q", k, v, state.Attributes[k])
+		}
+	}
+
+	// The first plan after import is empty for the matching configuration.
+	c, err := config.NewRawConfig(map[string]interface{}{
+		"title":      "foo",
+		"icon":       "line-chart",
+		"visibility": "owner",
+		"editable":   "read_only",
+		"widget": []interface{}{
+			map[string]interface{}{"title": "transactions", "visualization": "billboard", "row": 1, "column": 1, "nrql": "SELECT count(*) FROM Transaction"},
+			map[string]interface{}{"title": "errors", "visualization": "billboard", "row": 1, "column": 2, "nrql": "SELECT count(*) FROM TransactionError"},
+			map[string]interface{}{"title": "notes", "visualization": "markdown", "row": 2, "column": 1, "source": "# Runbook"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	diff, err := r.Diff(state, terraform.NewResourceConfig(c), meta)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff != nil && !diff.Empty() {
+		t.Fatalf("expected no diff after import, got %#v", diff.Attributes)
+	}
+}
+
+func TestResourceNewRelicDashboardImport_invalidID(t *testing.T) {
+	d := resourceNewRelicDashboard().TestResourceData()
+	d.SetId("foo")
+
+	_, err := importDashboard(d, &ProviderConfig{})
+
+	expected := `Error importing New Relic dashboard "foo", expected the numeric dashboard ID`
+	if err == nil || err.Error() != expected {
+		t.Fatalf("expected %q, got %q", expected, err)
+	}
+}
+
+func TestIsSupportedDashboardWidget(t *testing.T) {
+	for widget, expected := range map[string]bool{
+		`{"data":[{"nrql":"SELECT count(*) FROM Transaction"}]}`: true,
+		`{"data":[{"source":"# Runbook"}]}`:                      true,
+		`{}`:                                                     true,
+		`{"data":[{"duration":1800000,"entity_ids":[1],"metrics":[{"name":"Apdex","values":["score"]}]}]}`: false,
+	} {
+		var w map[string]interface{}
+		if err := json.Unmarshal([]byte(widget), &w); err != nil {
+			t.Fatal(err)
+		}
+
+		if isSupportedDashboardWidget(w) != expected {
+			t.Fatalf("expected %s to be supported: %t", widget, expected)
+		}
+	}
+}
+
+func TestResourceNewRelicDashboardRead_notFound(t *testing.T) {
+	testResourceReadNotFound(t, resourceNewRelicDashboard(), "789")
+}
+
 func testAccCheckNewRelicDashboardDestroy(s *terraform.State) error {
 	client := testAccProvider.Meta().(*ProviderConfig).Client
 	for _, r := range s.RootModule().Resources {
@@ -132,7 +768,7 @@ func testAccCheckNewRelicDashboardDestroy(s *terraform.State) error {
 			return err
 		}
 
-		_, err = client.GetDashboard(int(id))
+		_, err = getDashboard(client, int(id))
 
 		if err == nil {
 			return fmt.Errorf("Dashboard still exists")
@@ -159,7 +795,7 @@ func testAccCheckNewRelicDashboardExists(n string) resource.TestCheckFunc {
 			return err
 		}
 
-		found, err := client.GetDashboard(int(id))
+		found, err := getDashboard(client, int(id))
 		if err != nil {
 			return err
 		}
@@ -172,6 +808,56 @@ func testAccCheckNewRelicDashboardExists(n string) resource.TestCheckFunc {
 	}
 }
 
+// testAccAddNewRelicDashboardWidget adds a widget to the dashboard outside
+// Terraform.
+func testAccAddNewRelicDashboardWidget(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*ProviderConfig).Client
+
+		id, err := strconv.Atoi(s.RootModule().Resources[n].Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		dashboard, err := getDashboard(client, id)
+		if err != nil {
+			return err
+		}
+
+		dashboard.Widgets = append(dashboard.Widgets, dashboardWidget{
+			Visualization: "billboard",
+			Layout:        newrelic.DashboardWidgetLayout{Row: 2, Column: 1, Width: 1, Height: 1},
+			Presentation:  dashboardWidgetPresentation{Title: "Added outside Terraform"},
+			Data:          []dashboardWidgetData{{NRQL: "SELECT count(*) FROM Transaction"}},
+		})
+
+		_, err = updateDashboard(client, *dashboard)
+		return err
+	}
+}
+
+func testAccCheckNewRelicDashboardWidgetCount(n string, count int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*ProviderConfig).Client
+
+		id, err := strconv.Atoi(s.RootModule().Resources[n].Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		dashboard, err := getDashboard(client, id)
+		if err != nil {
+			return err
+		}
+
+		if len(dashboard.Widgets) != count {
+			return fmt.Errorf("expected dashboard %d to have %d widgets, got %d", id, count, len(dashboard.Widgets))
+		}
+
+		return nil
+	}
+}
+
 func testAccCheckNewRelicDashboardWidgetConfigAdded(rName string) string {
 	return fmt.Sprintf(`
 resource "newrelic_dashboard" "foo" {
@@ -252,7 +938,9 @@ resource "newrelic_dashboard" "foo" {
     visualization = "faceted_line_chart"
     column        = 1
     row           = 1
-    nrql          = "SELECT AVERAGE(duration) from Transaction FACET appName TIMESERIES auto"
+    nrql          = <<EOF
+SELECT AVERAGE(duration) from Transaction FACET appName TIMESERIES auto
+EOF
   }
 }
 `, rName)
@@ -263,6 +951,24 @@ func testAccCheckNewRelicDashboardConfig(rName string) string {
 resource "newrelic_dashboard" "foo" {
   title = "%s"
 
+  widget {
+    title         = "Average Transaction Duration"
+    visualization = "faceted_line_chart"
+    column        = 1
+    row           = 1
+    nrql          = <<EOF
+SELECT AVERAGE(duration) from Transaction FACET appName TIMESERIES auto
+EOF
+  }
+}
+`, rName)
+}
+
+func testAccCheckNewRelicDashboardConfigDrilldown(rName string) string {
+	return fmt.Sprintf(`
+resource "newrelic_dashboard" "bar" {
+  title = "%[1]s-drilldown"
+
   widget {
     title         = "Average Transaction Duration"
     visualization = "faceted_line_chart"
@@ -271,5 +977,122 @@ resource "newrelic_dashboard" "foo" {
     nrql          = "SELECT AVERAGE(duration) from Transaction FACET appName TIMESERIES auto"
   }
 }
+
+resource "newrelic_dashboard" "foo" {
+  title = "%[1]s"
+
+  widget {
+    title                  = "Average Transaction Duration"
+    visualization          = "faceted_line_chart"
+    column                 = 1
+    row                    = 1
+    nrql                   = "SELECT AVERAGE(duration) from Transaction FACET appName TIMESERIES auto"
+    drilldown_dashboard_id = "${newrelic_dashboard.bar.id}"
+  }
+}
+`, rName)
+}
+
+func testAccCheckNewRelicDashboardConfigShuffledWidgets(rName string) string {
+	return fmt.Sprintf(`
+resource "newrelic_dashboard" "foo" {
+  title = "%s"
+
+  widget {
+    title         = "Error Rate"
+    visualization = "billboard"
+    column        = 2
+    row           = 2
+    nrql          = "SELECT percentage(count(*), WHERE error IS true) FROM Transaction"
+  }
+  widget {
+    title         = "Throughput"
+    visualization = "billboard"
+    column        = 2
+    row           = 1
+    nrql          = "SELECT rate(count(*), 1 minute) FROM Transaction"
+  }
+  widget {
+    title         = "Page Views"
+    visualization = "billboard"
+    column        = 1
+    row           = 2
+    nrql          = "SELECT count(*) FROM PageView"
+  }
+  widget {
+    title         = "Average Transaction Duration"
+    visualization = "faceted_line_chart"
+    column        = 1
+    row           = 1
+    nrql          = <<EOF
+SELECT AVERAGE(duration) from Transaction FACET appName TIMESERIES auto
+EOF
+  }
+}
+`, rName)
+}
+
+func testAccCheckNewRelicDashboardConfigMarkdownAndBillboard(rName string) string {
+	return fmt.Sprintf(`
+resource "newrelic_dashboard" "foo" {
+  title = "%s"
+
+  widget {
+    title         = "Notes"
+    visualization = "markdown"
+    column        = 1
+    row           = 1
+    source        = "### Service health\n\nSee the runbook for details."
+  }
+  widget {
+    title            = "Error Rate"
+    visualization    = "billboard"
+    column           = 2
+    row              = 1
+    nrql             = "SELECT percentage(count(*), WHERE error IS true) FROM Transaction"
+    threshold_red    = 5
+    threshold_yellow = 1.5
+  }
+  widget {
+    title         = "Throughput"
+    visualization = "billboard_comparison"
+    column        = 3
+    row           = 1
+    nrql          = "SELECT rate(count(*), 1 minute) FROM Transaction SINCE 1 hour ago COMPARE WITH 1 week ago"
+  }
+}
 `, rName)
 }
+
+func testAccCheckNewRelicDashboardConfigWidget(rName string, visualization string, attributes string) string {
+	return fmt.Sprintf(`
+resource "newrelic_dashboard" "foo" {
+  title = "%s"
+
+  widget {
+    title         = "Widget"
+    visualization = "%s"
+    column        = 1
+    row           = 1
+    %s
+  }
+}
+`, rName, visualization, attributes)
+}
+
+func testAccCheckNewRelicDashboardConfigUnmanagedWidgets(title string) string {
+	return fmt.Sprintf(`
+resource "newrelic_dashboard" "foo" {
+  title                      = "%s"
+  manage_widgets_exclusively = false
+
+  widget {
+    title         = "Average Transaction Duration"
+    visualization = "faceted_line_chart"
+    column        = 1
+    row           = 1
+    nrql          = "SELECT AVERAGE(duration) from Transaction FACET appName TIMESERIES auto"
+  }
+}
+`, title)
+}
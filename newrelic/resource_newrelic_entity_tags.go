@@ -0,0 +1,252 @@
+package newrelic
+
+import (
+	"log"
+	"sort"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	newrelic "github.com/paultyng/go-newrelic/v4/api"
+)
+
+func resourceNewRelicEntityTags() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceNewRelicEntityTagsCreate,
+		Read:   resourceNewRelicEntityTagsRead,
+		Update: resourceNewRelicEntityTagsUpdate,
+		Delete: resourceNewRelicEntityTagsDelete,
+		Importer: &schema.ResourceImporter{
+			State: importEntityTags,
+		},
+		Schema: map[string]*schema.Schema{
+			"api_key": apiKeySchema(),
+			"guid": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateEntityGUID,
+			},
+			"managed_keys_only": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"tag": {
+				Type:     schema.TypeSet,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"values": {
+							Type:     schema.TypeSet,
+							Required: true,
+							MinItems: 1,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func importEntityTags(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	d.Set("guid", d.Id())
+	return []*schema.ResourceData{d}, nil
+}
+
+func expandEntityTags(tags *schema.Set) []entityTag {
+	expanded := make([]entityTag, 0, tags.Len())
+
+	for _, t := range tags.List() {
+		tag := t.(map[string]interface{})
+
+		values := make([]string, 0)
+		for _, v := range tag["values"].(*schema.Set).List() {
+			values = append(values, v.(string))
+		}
+		sort.Strings(values)
+
+		expanded = append(expanded, entityTag{
+			Key:    tag["key"].(string),
+			Values: values,
+		})
+	}
+
+	sort.Slice(expanded, func(i, j int) bool { return expanded[i].Key < expanded[j].Key })
+
+	return expanded
+}
+
+func flattenEntityTags(tags []entityTag) []interface{} {
+	flattened := make([]interface{}, len(tags))
+
+	for i, t := range tags {
+		values := make([]interface{}, len(t.Values))
+		for j, v := range t.Values {
+			values[j] = v
+		}
+
+		flattened[i] = map[string]interface{}{
+			"key":    t.Key,
+			"values": schema.NewSet(schema.HashString, values),
+		}
+	}
+
+	return flattened
+}
+
+func entityTagKeys(tags []entityTag) []string {
+	keys := make([]string, len(tags))
+	for i, t := range tags {
+		keys[i] = t.Key
+	}
+
+	return keys
+}
+
+// setManagedEntityTags sets the values of the given tags, removing the
+// removedKeys, without touching any other tags on the entity.
+func setManagedEntityTags(client *newrelic.Client, guid string, tags []entityTag, removedKeys []string) error {
+	// Adding tags merges values, so managed keys are cleared first.
+	if keys := append(entityTagKeys(tags), removedKeys...); len(keys) > 0 {
+		log.Printf("[INFO] Deleting New Relic entity tags %v from %s", keys, guid)
+
+		if err := deleteEntityTags(client, guid, keys); err != nil {
+			return err
+		}
+	}
+
+	if len(tags) == 0 {
+		return nil
+	}
+
+	log.Printf("[INFO] Adding New Relic entity tags to %s", guid)
+
+	return addEntityTags(client, guid, tags)
+}
+
+func resourceNewRelicEntityTagsCreate(d *schema.ResourceData, meta interface{}) error {
+	guid := d.Get("guid").(string)
+	tags := expandEntityTags(d.Get("tag").(*schema.Set))
+
+	client, err := meta.(*ProviderConfig).clientFor(d)
+	if err != nil {
+		return err
+	}
+
+	if d.Get("managed_keys_only").(bool) {
+		if err := setManagedEntityTags(client, guid, tags, nil); err != nil {
+			return err
+		}
+	} else {
+		log.Printf("[INFO] Replacing New Relic entity tags on %s", guid)
+
+		if err := replaceEntityTags(client, guid, tags); err != nil {
+			return err
+		}
+	}
+
+	d.SetId(guid)
+
+	return resourceNewRelicEntityTagsRead(d, meta)
+}
+
+func resourceNewRelicEntityTagsRead(d *schema.ResourceData, meta interface{}) error {
+	client, err := meta.(*ProviderConfig).clientFor(d)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Reading New Relic entity tags %s", d.Id())
+
+	tags, err := getEntityTags(client, d.Id())
+	if err != nil {
+		if err == errEntityNotFound {
+			d.SetId("")
+			return nil
+		}
+
+		return err
+	}
+
+	// Only the keys in the configuration are tracked when other systems also
+	// tag the entity.
+	if d.Get("managed_keys_only").(bool) {
+		managed := make(map[string]bool)
+		for _, t := range expandEntityTags(d.Get("tag").(*schema.Set)) {
+			managed[t.Key] = true
+		}
+
+		filtered := tags[:0]
+		for _, t := range tags {
+			if managed[t.Key] {
+				filtered = append(filtered, t)
+			}
+		}
+		tags = filtered
+	}
+
+	d.Set("guid", d.Id())
+
+	return d.Set("tag", flattenEntityTags(tags))
+}
+
+func resourceNewRelicEntityTagsUpdate(d *schema.ResourceData, meta interface{}) error {
+	tags := expandEntityTags(d.Get("tag").(*schema.Set))
+
+	client, err := meta.(*ProviderConfig).clientFor(d)
+	if err != nil {
+		return err
+	}
+
+	if !d.Get("managed_keys_only").(bool) {
+		log.Printf("[INFO] Replacing New Relic entity tags on %s", d.Id())
+
+		if err := replaceEntityTags(client, d.Id(), tags); err != nil {
+			return err
+		}
+
+		return resourceNewRelicEntityTagsRead(d, meta)
+	}
+
+	o, _ := d.GetChange("tag")
+
+	current := make(map[string]bool)
+	for _, t := range tags {
+		current[t.Key] = true
+	}
+
+	var removedKeys []string
+	for _, t := range expandEntityTags(o.(*schema.Set)) {
+		if !current[t.Key] {
+			removedKeys = append(removedKeys, t.Key)
+		}
+	}
+
+	if err := setManagedEntityTags(client, d.Id(), tags, removedKeys); err != nil {
+		return err
+	}
+
+	return resourceNewRelicEntityTagsRead(d, meta)
+}
+
+func resourceNewRelicEntityTagsDelete(d *schema.ResourceData, meta interface{}) error {
+	client, err := meta.(*ProviderConfig).clientFor(d)
+	if err != nil {
+		return err
+	}
+
+	keys := entityTagKeys(expandEntityTags(d.Get("tag").(*schema.Set)))
+
+	log.Printf("[INFO] Deleting New Relic entity tags %v from %s", keys, d.Id())
+
+	if err := deleteEntityTags(client, d.Id(), keys); err != nil {
+		return err
+	}
+
+	return nil
+}
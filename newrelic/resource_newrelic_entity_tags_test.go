@@ -0,0 +1,134 @@
+package newrelic
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func testAccEntityGUID(t *testing.T) string {
+	key := "NEWRELIC_ENTITY_GUID"
+	guid := os.Getenv(key)
+	if guid == "" {
+		t.Skipf("Environment variable %s is not set", key)
+	}
+
+	return guid
+}
+
+func TestAccNewRelicEntityTags_Basic(t *testing.T) {
+	resourceName := "newrelic_entity_tags.foo"
+	guid := testAccEntityGUID(t)
+	rName := acctest.RandString(5)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNewRelicEntityTagsDestroy(fmt.Sprintf("tf-test-%s", rName)),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckNewRelicEntityTagsConfig(guid, rName, "foo"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicEntityTagsExists(resourceName, fmt.Sprintf("tf-test-%s", rName), "foo"),
+					resource.TestCheckResourceAttr(resourceName, "tag.#", "1"),
+				),
+			},
+			{
+				Config: testAccCheckNewRelicEntityTagsConfig(guid, rName, "bar"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicEntityTagsExists(resourceName, fmt.Sprintf("tf-test-%s", rName), "bar"),
+					resource.TestCheckResourceAttr(resourceName, "tag.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestExpandEntityTags(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceNewRelicEntityTags().Schema, map[string]interface{}{
+		"guid": "MTIzNDV8QVBNfEFQUExJQ0FUSU9OfDY3ODk",
+		"tag": []interface{}{
+			map[string]interface{}{"key": "team", "values": []interface{}{"payments", "api"}},
+			map[string]interface{}{"key": "env", "values": []interface{}{"production"}},
+		},
+	})
+
+	tags := expandEntityTags(d.Get("tag").(*schema.Set))
+
+	if len(tags) != 2 || tags[0].Key != "env" || tags[1].Key != "team" {
+		t.Fatalf("expected tags sorted by key, got %v", tags)
+	}
+
+	if len(tags[1].Values) != 2 || tags[1].Values[0] != "api" || tags[1].Values[1] != "payments" {
+		t.Fatalf("expected sorted values, got %v", tags[1].Values)
+	}
+}
+
+func testAccCheckNewRelicEntityTagsDestroy(key string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*ProviderConfig).Client
+		for _, r := range s.RootModule().Resources {
+			if r.Type != "newrelic_entity_tags" {
+				continue
+			}
+
+			tags, err := getEntityTags(client, r.Primary.ID)
+			if err != nil {
+				return err
+			}
+
+			for _, t := range tags {
+				if t.Key == key {
+					return fmt.Errorf("Entity tag %s still exists", key)
+				}
+			}
+		}
+		return nil
+	}
+}
+
+func testAccCheckNewRelicEntityTagsExists(n string, key string, value string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No entity GUID is set")
+		}
+
+		client := testAccProvider.Meta().(*ProviderConfig).Client
+
+		tags, err := getEntityTags(client, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		for _, t := range tags {
+			if t.Key == key && len(t.Values) == 1 && t.Values[0] == value {
+				return nil
+			}
+		}
+
+		return fmt.Errorf("Entity tag %s=%s not found", key, value)
+	}
+}
+
+func testAccCheckNewRelicEntityTagsConfig(guid string, rName string, value string) string {
+	return fmt.Sprintf(`
+resource "newrelic_entity_tags" "foo" {
+  guid              = "%[1]s"
+  managed_keys_only = true
+
+  tag {
+    key    = "tf-test-%[2]s"
+    values = ["%[3]s"]
+  }
+}
+`, guid, rName, value)
+}
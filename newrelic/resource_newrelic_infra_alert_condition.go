@@ -1,7 +1,9 @@
 package newrelic
 
 import (
+	"fmt"
 	"log"
+	"time"
 
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/helper/validation"
@@ -21,6 +23,29 @@ var thresholdConditionTypes = map[string][]string{
 	"infra_host_not_reporting": {
 		"duration_minutes",
 	},
+	"infra_integration": {
+		"duration_minutes",
+		"value",
+		"time_function",
+	},
+}
+
+// infraAlertConditionRequiredAttributes are the attributes each condition
+// type can't be created without.
+var infraAlertConditionRequiredAttributes = map[string][]string{
+	"infra_process_running":    {"comparison", "critical"},
+	"infra_metric":             {"event", "comparison", "critical"},
+	"infra_host_not_reporting": {"critical"},
+	"infra_integration":        {"comparison", "critical"},
+}
+
+// infraAlertConditionUnsupportedAttributes are the attributes the API ignores
+// for each condition type.
+var infraAlertConditionUnsupportedAttributes = map[string][]string{
+	"infra_process_running":    {"event", "select", "integration_provider"},
+	"infra_metric":             {"process_where"},
+	"infra_host_not_reporting": {"event", "select", "comparison", "process_where", "integration_provider"},
+	"infra_integration":        {"process_where"},
 }
 
 // thresholdSchema returns the schema to use for threshold.
@@ -32,10 +57,11 @@ func thresholdSchema() *schema.Resource {
 				Type:     schema.TypeInt,
 				Optional: true,
 			},
+			// In minutes, sent as duration_minutes.
 			"duration": {
 				Type:         schema.TypeInt,
 				Required:     true,
-				ValidateFunc: validation.IntBetween(1, 60),
+				ValidateFunc: validateInfraThresholdDuration,
 			},
 			"time_function": {
 				Type:         schema.TypeString,
@@ -53,19 +79,21 @@ func resourceNewRelicInfraAlertCondition() *schema.Resource {
 	}
 
 	return &schema.Resource{
-		Create: resourceNewRelicInfraAlertConditionCreate,
-		Read:   resourceNewRelicInfraAlertConditionRead,
-		Update: resourceNewRelicInfraAlertConditionUpdate,
-		Delete: resourceNewRelicInfraAlertConditionDelete,
+		Create:        resourceNewRelicInfraAlertConditionCreate,
+		Read:          resourceNewRelicInfraAlertConditionRead,
+		Update:        resourceNewRelicInfraAlertConditionUpdate,
+		Delete:        resourceNewRelicInfraAlertConditionDelete,
+		CustomizeDiff: validateInfraAlertCondition,
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(1 * time.Minute),
+		},
 		Schema: map[string]*schema.Schema{
-			"policy_id": {
-				Type:     schema.TypeInt,
-				Required: true,
-				ForceNew: true,
-			},
+			"api_key":     apiKeySchema(),
+			"policy_id":   alertConditionPolicyIDSchema(),
+			"policy_name": alertConditionPolicyNameSchema(),
 			"name": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -96,7 +124,7 @@ func resourceNewRelicInfraAlertCondition() *schema.Resource {
 			"comparison": {
 				Type:         schema.TypeString,
 				Optional:     true,
-				ValidateFunc: validation.StringInSlice([]string{"above", "below", "equal"}, false),
+				ValidateFunc: validateThresholdOperator,
 			},
 			"select": {
 				Type:     schema.TypeString,
@@ -131,21 +159,141 @@ func resourceNewRelicInfraAlertCondition() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"violation_close_timer": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: intInSlice([]int{1, 2, 4, 8, 12, 24, 48, 72}),
+			},
 		},
 	}
 }
 
-func buildInfraAlertConditionStruct(d *schema.ResourceData) *newrelic.AlertInfraCondition {
+func validateInfraAlertCondition(d *schema.ResourceDiff, meta interface{}) error {
+	if err := validateInfraAlertConditionIntegrationProvider(d); err != nil {
+		return err
+	}
+
+	if err := validateInfraAlertConditionAttributes(d); err != nil {
+		return err
+	}
+
+	if err := validateInfraAlertConditionThresholds(d); err != nil {
+		return err
+	}
+
+	return validateInfraAlertConditionSelect(d)
+}
 
-	condition := newrelic.AlertInfraCondition{
-		Name:       d.Get("name").(string),
-		Enabled:    d.Get("enabled").(bool),
-		PolicyID:   d.Get("policy_id").(int),
-		Event:      d.Get("event").(string),
-		Comparison: d.Get("comparison").(string),
-		Select:     d.Get("select").(string),
-		Type:       d.Get("type").(string),
-		Critical:   expandAlertThreshold(d.Get("critical")),
+// validateInfraAlertConditionAttributes requires the attributes the condition
+// type needs and rejects the ones it ignores, such as a select on a host not
+// reporting condition.
+func validateInfraAlertConditionAttributes(d *schema.ResourceDiff) error {
+	if !d.NewValueKnown("type") {
+		return nil
+	}
+
+	conditionType := d.Get("type").(string)
+
+	for _, k := range infraAlertConditionRequiredAttributes[conditionType] {
+		if _, ok := d.GetOk(k); !ok && d.NewValueKnown(k) {
+			return fmt.Errorf("%s is required for %s conditions", k, conditionType)
+		}
+	}
+
+	for _, k := range infraAlertConditionUnsupportedAttributes[conditionType] {
+		if _, ok := d.GetOk(k); ok {
+			return fmt.Errorf("%s is not supported by %s conditions", k, conditionType)
+		}
+	}
+
+	return nil
+}
+
+// validateInfraAlertConditionThresholds rejects threshold attributes the
+// condition type doesn't support, since the API would otherwise drop them; a
+// dropped time_function makes the condition alert on any breach.
+func validateInfraAlertConditionThresholds(d *schema.ResourceDiff) error {
+	if !d.NewValueKnown("type") {
+		return nil
+	}
+
+	conditionType := d.Get("type").(string)
+
+	supported := map[string]bool{}
+	for _, attr := range thresholdConditionTypes[conditionType] {
+		supported[attr] = true
+	}
+
+	for _, k := range []string{"critical", "warning"} {
+		if !d.NewValueKnown(k) {
+			continue
+		}
+
+		for _, attr := range []string{"value", "time_function"} {
+			if v, ok := d.GetOk(k + ".0." + attr); ok && !supported[attr] {
+				return fmt.Errorf("%s.0.%s is not supported by %s conditions, got %v", k, attr, conditionType, v)
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateInfraAlertConditionIntegrationProvider requires integration_provider
+// for infra_integration conditions only, so other condition types are
+// unaffected.
+func validateInfraAlertConditionIntegrationProvider(d *schema.ResourceDiff) error {
+	if d.Get("type").(string) != "infra_integration" || !d.NewValueKnown("integration_provider") {
+		return nil
+	}
+
+	if _, ok := d.GetOk("integration_provider"); !ok {
+		return fmt.Errorf("integration_provider is required when type is infra_integration")
+	}
+
+	return nil
+}
+
+// validateInfraAlertConditionSelect requires select whenever comparison is
+// set on a metric condition, since the comparison has nothing to evaluate
+// otherwise. Process running conditions compare the process count and take
+// no select.
+func validateInfraAlertConditionSelect(d *schema.ResourceDiff) error {
+	switch d.Get("type").(string) {
+	case "infra_metric", "infra_integration":
+	default:
+		return nil
+	}
+
+	if !d.NewValueKnown("comparison") || !d.NewValueKnown("select") {
+		return nil
+	}
+
+	if _, ok := d.GetOk("comparison"); !ok {
+		return nil
+	}
+
+	if _, ok := d.GetOk("select"); !ok {
+		return fmt.Errorf("select is required when comparison is set")
+	}
+
+	return nil
+}
+
+func buildInfraAlertConditionStruct(d *schema.ResourceData) *alertInfraCondition {
+
+	condition := alertInfraCondition{
+		AlertInfraCondition: newrelic.AlertInfraCondition{
+			Name:       d.Get("name").(string),
+			Enabled:    d.Get("enabled").(bool),
+			PolicyID:   d.Get("policy_id").(int),
+			Event:      d.Get("event").(string),
+			Comparison: d.Get("comparison").(string),
+			Select:     d.Get("select").(string),
+			Type:       d.Get("type").(string),
+			Critical:   expandAlertThreshold(d.Get("critical")),
+		},
 	}
 
 	if attr, ok := d.GetOk("warning"); ok {
@@ -164,10 +312,14 @@ func buildInfraAlertConditionStruct(d *schema.ResourceData) *newrelic.AlertInfra
 		condition.IntegrationProvider = attr.(string)
 	}
 
+	if attr, ok := d.GetOk("violation_close_timer"); ok {
+		condition.ViolationCloseTimer = attr.(int)
+	}
+
 	return &condition
 }
 
-func readInfraAlertConditionStruct(condition *newrelic.AlertInfraCondition, d *schema.ResourceData) error {
+func readInfraAlertConditionStruct(condition *alertInfraCondition, d *schema.ResourceData) error {
 	ids, err := parseIDs(d.Id(), 2)
 	if err != nil {
 		return err
@@ -178,12 +330,14 @@ func readInfraAlertConditionStruct(condition *newrelic.AlertInfraCondition, d *s
 	d.Set("policy_id", policyID)
 	d.Set("name", condition.Name)
 	d.Set("enabled", condition.Enabled)
+	d.Set("type", condition.Type)
+	d.Set("event", condition.Event)
+	d.Set("select", condition.Select)
+	d.Set("comparison", condition.Comparison)
 	d.Set("created_at", condition.CreatedAt)
 	d.Set("updated_at", condition.UpdatedAt)
 
-	if condition.Where != "" {
-		d.Set("where", condition.Where)
-	}
+	d.Set("where", condition.Where)
 
 	if condition.ProcessWhere != "" {
 		d.Set("process_where", condition.ProcessWhere)
@@ -193,6 +347,10 @@ func readInfraAlertConditionStruct(condition *newrelic.AlertInfraCondition, d *s
 		d.Set("integration_provider", condition.IntegrationProvider)
 	}
 
+	if condition.ViolationCloseTimer != 0 {
+		d.Set("violation_close_timer", condition.ViolationCloseTimer)
+	}
+
 	if err := d.Set("critical", flattenAlertThreshold(condition.Critical)); err != nil {
 		return err
 	}
@@ -206,24 +364,61 @@ func readInfraAlertConditionStruct(condition *newrelic.AlertInfraCondition, d *s
 	return nil
 }
 
+// infraAlertPolicyNotFoundError is alertPolicyNotFoundError for Infrastructure
+// conditions, whose policies are looked up through the REST API client.
+func infraAlertPolicyNotFoundError(d *schema.ResourceData, meta interface{}, policyID int, err error) error {
+	client, clientErr := meta.(*ProviderConfig).clientFor(d)
+	if clientErr != nil {
+		return err
+	}
+
+	return alertPolicyNotFoundError(client, policyID, err)
+}
+
 func resourceNewRelicInfraAlertConditionCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).InfraClient
+	client, err := meta.(*ProviderConfig).infraClientFor(d)
+	if err != nil {
+		return err
+	}
+
+	// Policies are looked up by name with the REST client, since they belong
+	// to the REST API rather than the Infrastructure API.
+	restClient, err := meta.(*ProviderConfig).clientFor(d)
+	if err != nil {
+		return err
+	}
+
+	if err := resolveAlertConditionPolicy(restClient, d); err != nil {
+		return err
+	}
+
 	condition := buildInfraAlertConditionStruct(d)
 
 	log.Printf("[INFO] Creating New Relic Infra alert condition %s", condition.Name)
 
-	condition, err := client.CreateAlertInfraCondition(*condition)
+	condition, err = createAlertInfraCondition(client, *condition)
 	if err != nil {
-		return err
+		return infraAlertPolicyNotFoundError(d, meta, d.Get("policy_id").(int), err)
 	}
 
 	d.SetId(serializeIDs([]int{condition.PolicyID, condition.ID}))
 
+	err = waitForCreated(meta.(*ProviderConfig).stopContext(), d.Timeout(schema.TimeoutCreate), func() error {
+		_, err := getAlertInfraCondition(client, condition.PolicyID, condition.ID)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("Error waiting for New Relic Infra alert condition %d to be created: %s", condition.ID, err)
+	}
+
 	return resourceNewRelicInfraAlertConditionRead(d, meta)
 }
 
 func resourceNewRelicInfraAlertConditionRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).InfraClient
+	client, err := meta.(*ProviderConfig).infraClientFor(d)
+	if err != nil {
+		return err
+	}
 
 	log.Printf("[INFO] Reading New Relic Infra alert condition %s", d.Id())
 
@@ -235,7 +430,7 @@ func resourceNewRelicInfraAlertConditionRead(d *schema.ResourceData, meta interf
 	policyID := ids[0]
 	id := ids[1]
 
-	condition, err := client.GetAlertInfraCondition(policyID, id)
+	condition, err := getAlertInfraCondition(client, policyID, id)
 	if err != nil {
 		if err == newrelic.ErrNotFound {
 			d.SetId("")
@@ -249,7 +444,11 @@ func resourceNewRelicInfraAlertConditionRead(d *schema.ResourceData, meta interf
 }
 
 func resourceNewRelicInfraAlertConditionUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).InfraClient
+	client, err := meta.(*ProviderConfig).infraClientFor(d)
+	if err != nil {
+		return err
+	}
+
 	condition := buildInfraAlertConditionStruct(d)
 
 	ids, err := parseIDs(d.Id(), 2)
@@ -260,21 +459,37 @@ func resourceNewRelicInfraAlertConditionUpdate(d *schema.ResourceData, meta inte
 	policyID := ids[0]
 	id := ids[1]
 
+	if onlyEnabledChanged(d, resourceNewRelicInfraAlertCondition().Schema) {
+		log.Printf("[INFO] Reading New Relic Infra alert condition %d to update enabled", id)
+
+		if condition, err = getAlertInfraCondition(client, policyID, id); err != nil {
+			return err
+		}
+
+		// Timestamps are set by the API.
+		condition.Enabled = d.Get("enabled").(bool)
+		condition.CreatedAt = 0
+		condition.UpdatedAt = 0
+	}
+
 	condition.PolicyID = policyID
 	condition.ID = id
 
 	log.Printf("[INFO] Updating New Relic Infra alert condition %d", id)
 
-	_, err = client.UpdateAlertInfraCondition(*condition)
+	_, err = updateAlertInfraCondition(client, *condition)
 	if err != nil {
-		return err
+		return infraAlertPolicyNotFoundError(d, meta, policyID, err)
 	}
 
 	return resourceNewRelicInfraAlertConditionRead(d, meta)
 }
 
 func resourceNewRelicInfraAlertConditionDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).InfraClient
+	client, err := meta.(*ProviderConfig).infraClientFor(d)
+	if err != nil {
+		return err
+	}
 
 	ids, err := parseIDs(d.Id(), 2)
 	if err != nil {
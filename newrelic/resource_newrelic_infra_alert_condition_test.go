@@ -1,13 +1,18 @@
 package newrelic
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
+	"regexp"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform/config"
 	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
+	newrelic "github.com/paultyng/go-newrelic/v4/api"
 )
 
 func TestAccNewRelicInfraAlertCondition_Basic(t *testing.T) {
@@ -35,6 +40,10 @@ func TestAccNewRelicInfraAlertCondition_Basic(t *testing.T) {
 					testAccCheckNewRelicInfraAlertConditionExists("newrelic_infra_alert_condition.foo"),
 					resource.TestCheckResourceAttr(
 						"newrelic_infra_alert_condition.foo", "name", fmt.Sprintf("tf-test-updated-%s", rName)),
+					resource.TestCheckResourceAttrSet(
+						"newrelic_infra_alert_condition.foo", "created_at"),
+					resource.TestCheckResourceAttrSet(
+						"newrelic_infra_alert_condition.foo", "updated_at"),
 				),
 			},
 		},
@@ -104,6 +113,77 @@ func TestAccNewRelicInfraAlertCondition_IntegrationProvider(t *testing.T) {
 		},
 	})
 }
+
+func TestAccNewRelicInfraAlertCondition_Integration(t *testing.T) {
+	rName := acctest.RandString(5)
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNewRelicInfraAlertConditionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckNewRelicInfraAlertConditionConfigIntegration(rName, `integration_provider = "Elb"`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicInfraAlertConditionExists("newrelic_infra_alert_condition.foo"),
+					resource.TestCheckResourceAttr(
+						"newrelic_infra_alert_condition.foo", "type", "infra_integration"),
+					resource.TestCheckResourceAttr(
+						"newrelic_infra_alert_condition.foo", "integration_provider", "Elb"),
+					resource.TestCheckResourceAttr(
+						"newrelic_infra_alert_condition.foo", "warning.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccNewRelicInfraAlertCondition_IntegrationMissingProvider(t *testing.T) {
+	expectedErrorMsg, _ := regexp.Compile("integration_provider is required when type is infra_integration")
+	resource.Test(t, resource.TestCase{
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCheckNewRelicInfraAlertConditionConfigIntegration(acctest.RandString(5), ""),
+				ExpectError: expectedErrorMsg,
+			},
+		},
+	})
+}
+
+func TestAccNewRelicInfraAlertCondition_UserDefinedMetric(t *testing.T) {
+	rName := acctest.RandString(5)
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNewRelicInfraAlertConditionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckNewRelicInfraAlertConditionConfigUserDefinedMetric(rName, `select = "diskUtilizationPercent"`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicInfraAlertConditionExists("newrelic_infra_alert_condition.foo"),
+					resource.TestCheckResourceAttr(
+						"newrelic_infra_alert_condition.foo", "select", "diskUtilizationPercent"),
+					resource.TestCheckResourceAttr(
+						"newrelic_infra_alert_condition.foo", "where", "(hostname LIKE 'prod-%')"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccNewRelicInfraAlertCondition_ComparisonWithoutSelect(t *testing.T) {
+	expectedErrorMsg, _ := regexp.Compile("select is required when comparison is set")
+	resource.Test(t, resource.TestCase{
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCheckNewRelicInfraAlertConditionConfigUserDefinedMetric(acctest.RandString(5), ""),
+				ExpectError: expectedErrorMsg,
+			},
+		},
+	})
+}
+
 func TestAccNewRelicInfraAlertCondition_Thresholds(t *testing.T) {
 	rName := acctest.RandString(5)
 	resource.Test(t, resource.TestCase{
@@ -147,6 +227,266 @@ func TestAccNewRelicInfraAlertCondition_Thresholds(t *testing.T) {
 	})
 }
 
+func TestAccNewRelicInfraAlertCondition_unsupportedTimeFunction(t *testing.T) {
+	expectedErrorMsg, _ := regexp.Compile("critical.0.time_function is not supported by infra_process_running conditions, got all")
+	resource.Test(t, resource.TestCase{
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCheckNewRelicInfraAlertConditionConfigProcessRunningTimeFunction(acctest.RandString(5)),
+				ExpectError: expectedErrorMsg,
+			},
+		},
+	})
+}
+
+func TestValidateInfraAlertConditionAttributes(t *testing.T) {
+	r := resourceNewRelicInfraAlertCondition()
+
+	diff := func(c map[string]interface{}) error {
+		c["policy_id"] = 123
+		c["name"] = "foo"
+
+		rc, err := config.NewRawConfig(c)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = r.Diff(nil, terraform.NewResourceConfig(rc), &ProviderConfig{})
+		return err
+	}
+
+	critical := func(threshold map[string]interface{}) []interface{} {
+		threshold["duration"] = 5
+		return []interface{}{threshold}
+	}
+
+	for _, c := range []struct {
+		config   map[string]interface{}
+		expected string
+	}{
+		{map[string]interface{}{"type": "infra_host_not_reporting", "where": "(hostname LIKE 'prod-%')", "critical": critical(map[string]interface{}{})}, ""},
+		{map[string]interface{}{"type": "infra_host_not_reporting"}, "critical is required for infra_host_not_reporting conditions"},
+		{map[string]interface{}{"type": "infra_host_not_reporting", "select": "cpuPercent", "critical": critical(map[string]interface{}{})}, "select is not supported by infra_host_not_reporting conditions"},
+		{map[string]interface{}{"type": "infra_host_not_reporting", "critical": critical(map[string]interface{}{"value": 10})}, "critical.0.value is not supported by infra_host_not_reporting conditions, got 10"},
+		{map[string]interface{}{"type": "infra_process_running", "process_where": "commandName = 'java'", "comparison": "equal", "critical": critical(map[string]interface{}{"value": 0})}, ""},
+		{map[string]interface{}{"type": "infra_process_running", "process_where": "commandName = 'java'", "critical": critical(map[string]interface{}{"value": 0})}, "comparison is required for infra_process_running conditions"},
+		{map[string]interface{}{"type": "infra_process_running", "event": "ProcessSample", "comparison": "equal", "critical": critical(map[string]interface{}{"value": 0})}, "event is not supported by infra_process_running conditions"},
+		{map[string]interface{}{"type": "infra_metric", "comparison": "above", "select": "cpuPercent", "critical": critical(map[string]interface{}{"value": 90})}, "event is required for infra_metric conditions"},
+		{map[string]interface{}{"type": "infra_metric", "event": "SystemSample", "comparison": "above", "select": "cpuPercent", "process_where": "commandName = 'java'", "critical": critical(map[string]interface{}{"value": 90})}, "process_where is not supported by infra_metric conditions"},
+		{map[string]interface{}{"type": "infra_metric", "event": "K8sPodSample", "comparison": "above", "select": "restartCount", "where": "(clusterName = 'prod' AND namespace = 'payments')", "integration_provider": "Kubernetes", "critical": critical(map[string]interface{}{"value": 3})}, ""},
+		{map[string]interface{}{"type": "infra_metric", "event": "K8sNodeSample", "comparison": "above", "select": "allocatableCpuCoresUtilization", "critical": critical(map[string]interface{}{"value": 90})}, ""},
+	} {
+		err := diff(c.config)
+		if c.expected == "" && err != nil {
+			t.Errorf("expected no error for %v, got %q", c.config, err)
+		}
+
+		if c.expected != "" && (err == nil || err.Error() != c.expected) {
+			t.Errorf("expected %q for %v, got %q", c.expected, c.config, err)
+		}
+	}
+}
+
+func TestAccNewRelicInfraAlertCondition_ViolationCloseTimer(t *testing.T) {
+	rName := acctest.RandString(5)
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNewRelicInfraAlertConditionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckNewRelicInfraAlertConditionConfigViolationCloseTimer(rName, 24),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicInfraAlertConditionExists("newrelic_infra_alert_condition.foo"),
+					resource.TestCheckResourceAttr(
+						"newrelic_infra_alert_condition.foo", "violation_close_timer", "24"),
+				),
+			},
+			{
+				Config: testAccCheckNewRelicInfraAlertConditionConfigViolationCloseTimer(rName, 72),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicInfraAlertConditionExists("newrelic_infra_alert_condition.foo"),
+					resource.TestCheckResourceAttr(
+						"newrelic_infra_alert_condition.foo", "violation_close_timer", "72"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccNewRelicInfraAlertCondition_invalidViolationCloseTimer(t *testing.T) {
+	expectedErrorMsg, _ := regexp.Compile("expected violation_close_timer to be one of \\[1 2 4 8 12 24 48 72\\], got 3")
+	resource.Test(t, resource.TestCase{
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCheckNewRelicInfraAlertConditionConfigViolationCloseTimer(acctest.RandString(5), 3),
+				ExpectError: expectedErrorMsg,
+			},
+		},
+	})
+}
+
+func TestAccNewRelicInfraAlertCondition_thresholdDuration(t *testing.T) {
+	rName := acctest.RandString(5)
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNewRelicInfraAlertConditionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckNewRelicInfraAlertConditionConfigDuration(rName, 5),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicInfraAlertConditionDuration("newrelic_infra_alert_condition.foo", 5),
+					resource.TestCheckResourceAttr(
+						"newrelic_infra_alert_condition.foo", "critical.0.duration", "5"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccNewRelicInfraAlertCondition_invalidThresholdDuration(t *testing.T) {
+	expectedErrorMsg, _ := regexp.Compile("expected critical.0.duration to be between 1 and 60 minutes, got 300")
+	resource.Test(t, resource.TestCase{
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCheckNewRelicInfraAlertConditionConfigDuration(acctest.RandString(5), 300),
+				ExpectError: expectedErrorMsg,
+			},
+		},
+	})
+}
+
+func TestAlertThreshold_durationRoundTrip(t *testing.T) {
+	threshold := expandAlertThreshold([]interface{}{
+		map[string]interface{}{"duration": 5, "value": 10, "time_function": "all"},
+	})
+
+	body, err := json.Marshal(threshold)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(string(body), `"duration_minutes":5`) {
+		t.Fatalf("expected the duration to be sent in minutes, got %s", body)
+	}
+
+	var read newrelic.AlertInfraThreshold
+	if err := json.Unmarshal(body, &read); err != nil {
+		t.Fatal(err)
+	}
+
+	flattened := flattenAlertThreshold(&read)[0].(map[string]interface{})
+	if flattened["duration"] != 5 {
+		t.Fatalf("expected duration 5 to round-trip, got %v", flattened["duration"])
+	}
+}
+
+// Both all, requiring a sustained breach, and any are sent to and read from
+// the API unchanged.
+func TestAlertThreshold_timeFunctionRoundTrip(t *testing.T) {
+	for _, timeFunction := range []string{"all", "any"} {
+		threshold := expandAlertThreshold([]interface{}{
+			map[string]interface{}{"duration": 5, "value": 10, "time_function": timeFunction},
+		})
+
+		body, err := json.Marshal(threshold)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !strings.Contains(string(body), fmt.Sprintf(`"time_function":%q`, timeFunction)) {
+			t.Fatalf("expected time_function %s to be sent, got %s", timeFunction, body)
+		}
+
+		var read newrelic.AlertInfraThreshold
+		if err := json.Unmarshal(body, &read); err != nil {
+			t.Fatal(err)
+		}
+
+		flattened := flattenAlertThreshold(&read)[0].(map[string]interface{})
+		if flattened["time_function"] != timeFunction {
+			t.Fatalf("expected time_function %s to round-trip, got %v", timeFunction, flattened["time_function"])
+		}
+	}
+}
+
+// Fields the resource doesn't manage, e.g. a runbook_url set in the UI, are
+// kept when only enabled changes.
+func TestResourceNewRelicInfraAlertConditionUpdate_enabledOnly(t *testing.T) {
+	condition := map[string]interface{}{
+		"policy_id":               123.0,
+		"id":                      456.0,
+		"name":                    "foo",
+		"runbook_url":             "https://foo.example.com",
+		"type":                    "infra_metric",
+		"comparison":              "above",
+		"created_at_epoch_millis": 1.0,
+		"updated_at_epoch_millis": 2.0,
+		"enabled":                 true,
+		"event_type":              "SystemSample",
+		"select_value":            "cpuPercent",
+		"critical_threshold":      map[string]interface{}{"value": 90.0, "duration_minutes": 5.0, "time_function": "all"},
+	}
+
+	testConditionEnabledToggle(t, resourceNewRelicInfraAlertCondition(), "data", "data", condition, map[string]interface{}{
+		"policy_id":  123,
+		"name":       "foo",
+		"enabled":    false,
+		"type":       "infra_metric",
+		"event":      "SystemSample",
+		"select":     "cpuPercent",
+		"comparison": "above",
+		"critical": []interface{}{
+			map[string]interface{}{"value": 90, "duration": 5, "time_function": "all"},
+		},
+	}, "created_at_epoch_millis", "updated_at_epoch_millis")
+}
+
+// Conditions on Kubernetes integration data, e.g. pod restarts, round-trip
+// without drift.
+func TestResourceNewRelicInfraAlertConditionUpdate_kubernetes(t *testing.T) {
+	condition := map[string]interface{}{
+		"policy_id":            123.0,
+		"id":                   456.0,
+		"name":                 "foo",
+		"type":                 "infra_metric",
+		"comparison":           "above",
+		"enabled":              true,
+		"event_type":           "K8sPodSample",
+		"select_value":         "restartCount",
+		"where_clause":         "(clusterName = 'prod' AND namespace = 'payments')",
+		"integration_provider": "Kubernetes",
+		"critical_threshold":   map[string]interface{}{"value": 3.0, "duration_minutes": 5.0, "time_function": "any"},
+	}
+
+	testConditionEnabledToggle(t, resourceNewRelicInfraAlertCondition(), "data", "data", condition, map[string]interface{}{
+		"policy_id":            123,
+		"name":                 "foo",
+		"enabled":              false,
+		"type":                 "infra_metric",
+		"event":                "K8sPodSample",
+		"select":               "restartCount",
+		"comparison":           "above",
+		"where":                "(clusterName = 'prod' AND namespace = 'payments')",
+		"integration_provider": "Kubernetes",
+		"critical": []interface{}{
+			map[string]interface{}{"value": 3, "duration": 5, "time_function": "any"},
+		},
+	})
+}
+
+func TestResourceNewRelicInfraAlertCondition_immutableAttributes(t *testing.T) {
+	testResourceAttributesForceNew(t, resourceNewRelicInfraAlertCondition(), "policy_id", "policy_name", "type")
+}
+
+func TestResourceNewRelicInfraAlertConditionRead_notFound(t *testing.T) {
+	testResourceReadNotFound(t, resourceNewRelicInfraAlertCondition(), "123:456")
+}
+
 func testAccCheckNewRelicInfraAlertConditionDestroy(s *terraform.State) error {
 	client := testAccProvider.Meta().(*ProviderConfig).InfraClient
 	for _, r := range s.RootModule().Resources {
@@ -204,6 +544,35 @@ func testAccCheckNewRelicInfraAlertConditionExists(n string) resource.TestCheckF
 	}
 }
 
+// testAccCheckNewRelicInfraAlertConditionDuration checks the critical
+// threshold duration the API stored, in minutes.
+func testAccCheckNewRelicInfraAlertConditionDuration(n string, duration int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		client := testAccProvider.Meta().(*ProviderConfig).InfraClient
+
+		ids, err := parseIDs(rs.Primary.ID, 2)
+		if err != nil {
+			return err
+		}
+
+		found, err := client.GetAlertInfraCondition(ids[0], ids[1])
+		if err != nil {
+			return err
+		}
+
+		if found.Critical == nil || found.Critical.Duration != duration {
+			return fmt.Errorf("expected a critical threshold duration of %d minutes, got %v", duration, found.Critical)
+		}
+
+		return nil
+	}
+}
+
 func testAccCheckNewRelicInfraAlertConditionConfig(rName string) string {
 	return fmt.Sprintf(`
 
@@ -330,6 +699,29 @@ resource "newrelic_infra_alert_condition" "foo" {
 `, rName, where)
 }
 
+func testAccCheckNewRelicInfraAlertConditionConfigProcessRunningTimeFunction(rName string) string {
+	return fmt.Sprintf(`
+resource "newrelic_alert_policy" "foo" {
+  name = "tf-test-%[1]s"
+}
+
+resource "newrelic_infra_alert_condition" "foo" {
+  policy_id = "${newrelic_alert_policy.foo.id}"
+
+  name          = "tf-test-%[1]s"
+  type          = "infra_process_running"
+  process_where = "commandName = 'java'"
+  comparison    = "equal"
+
+  critical {
+	duration = 10
+	value = 0
+	time_function = "all"
+  }
+}
+`, rName)
+}
+
 func testAccCheckNewRelicInfraAlertConditionConfigWithIntegrationProvider(rName, integrationProvider string) string {
 	return fmt.Sprintf(`
 resource "newrelic_alert_policy" "foo" {
@@ -354,3 +746,108 @@ resource "newrelic_infra_alert_condition" "foo" {
 }
 `, rName, integrationProvider)
 }
+
+func testAccCheckNewRelicInfraAlertConditionConfigIntegration(rName, integrationProvider string) string {
+	return fmt.Sprintf(`
+resource "newrelic_alert_policy" "foo" {
+  name = "tf-test-%[1]s"
+}
+
+resource "newrelic_infra_alert_condition" "foo" {
+  policy_id = "${newrelic_alert_policy.foo.id}"
+
+  name       = "tf-test-%[1]s"
+  type       = "infra_integration"
+  event      = "LoadBalancerSample"
+  select     = "provider.healthyHostCount.Minimum"
+  comparison = "below"
+  %[2]s
+
+  critical {
+    duration      = 10
+    value         = 1
+    time_function = "all"
+  }
+
+  warning {
+    duration      = 10
+    value         = 2
+    time_function = "all"
+  }
+}
+`, rName, integrationProvider)
+}
+
+func testAccCheckNewRelicInfraAlertConditionConfigUserDefinedMetric(rName, selectValue string) string {
+	return fmt.Sprintf(`
+resource "newrelic_alert_policy" "foo" {
+  name = "tf-test-%[1]s"
+}
+
+resource "newrelic_infra_alert_condition" "foo" {
+  policy_id = "${newrelic_alert_policy.foo.id}"
+
+  name       = "tf-test-%[1]s"
+  type       = "infra_metric"
+  event      = "StorageSample"
+  comparison = "above"
+  where      = "(hostname LIKE 'prod-%%')"
+  %[2]s
+
+  critical {
+    duration      = 10
+    value         = 80
+    time_function = "all"
+  }
+}
+`, rName, selectValue)
+}
+
+func testAccCheckNewRelicInfraAlertConditionConfigViolationCloseTimer(rName string, timer int) string {
+	return fmt.Sprintf(`
+resource "newrelic_alert_policy" "foo" {
+  name = "tf-test-%[1]s"
+}
+
+resource "newrelic_infra_alert_condition" "foo" {
+  policy_id = "${newrelic_alert_policy.foo.id}"
+
+  name                  = "tf-test-%[1]s"
+  type                  = "infra_metric"
+  event                 = "StorageSample"
+  select                = "diskFreePercent"
+  comparison            = "below"
+  violation_close_timer = %[2]d
+
+  critical {
+    duration      = 10
+    value         = 10
+    time_function = "any"
+  }
+}
+`, rName, timer)
+}
+
+func testAccCheckNewRelicInfraAlertConditionConfigDuration(rName string, duration int) string {
+	return fmt.Sprintf(`
+resource "newrelic_alert_policy" "foo" {
+  name = "tf-test-%[1]s"
+}
+
+resource "newrelic_infra_alert_condition" "foo" {
+  policy_id = "${newrelic_alert_policy.foo.id}"
+
+  name       = "tf-test-%[1]s"
+  type       = "infra_metric"
+  event      = "StorageSample"
+  select     = "diskFreePercent"
+  comparison = "below"
+
+  critical {
+    duration      = %[2]d
+    value         = 10
+    time_function = "any"
+  }
+}
+`, rName, duration)
+}
@@ -0,0 +1,186 @@
+package newrelic
+
+import (
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+func resourceNewRelicNotificationChannel() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceNewRelicNotificationChannelCreate,
+		Read:   resourceNewRelicNotificationChannelRead,
+		Update: resourceNewRelicNotificationChannelUpdate,
+		Delete: resourceNewRelicNotificationChannelDelete,
+		Importer: &schema.ResourceImporter{
+			State: importNotificationResource,
+		},
+		Schema: map[string]*schema.Schema{
+			"api_key": apiKeySchema(),
+			"account_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			"destination_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"EMAIL", "WEBHOOK"}, false),
+			},
+			"product": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "IINT",
+				ValidateFunc: validation.StringInSlice([]string{"ALERTS", "IINT"}, false),
+			},
+			"active": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"properties": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func buildNotificationChannelStruct(d *schema.ResourceData) *notificationChannelInput {
+	return &notificationChannelInput{
+		Name:       d.Get("name").(string),
+		Properties: expandNotificationProperties(d.Get("properties").(map[string]interface{})),
+	}
+}
+
+func readNotificationChannelStruct(channel *notificationChannel, d *schema.ResourceData) error {
+	d.Set("name", channel.Name)
+	d.Set("type", channel.Type)
+	d.Set("product", channel.Product)
+	d.Set("active", channel.Active)
+	d.Set("destination_id", channel.DestinationID)
+
+	return d.Set("properties", flattenNotificationProperties(channel.Properties))
+}
+
+func resourceNewRelicNotificationChannelCreate(d *schema.ResourceData, meta interface{}) error {
+	client, err := meta.(*ProviderConfig).clientFor(d)
+	if err != nil {
+		return err
+	}
+
+	accountID := d.Get("account_id").(int)
+	input := buildNotificationChannelStruct(d)
+	input.Type = d.Get("type").(string)
+	input.Product = d.Get("product").(string)
+	input.DestinationID = d.Get("destination_id").(string)
+
+	log.Printf("[INFO] Creating New Relic notification channel %s", input.Name)
+
+	channel, err := createNotificationChannel(client, accountID, *input)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(strconv.Itoa(accountID) + ":" + channel.ID)
+
+	// Channels are always created active.
+	if active := d.Get("active").(bool); !active {
+		input = buildNotificationChannelStruct(d)
+		input.Active = &active
+
+		log.Printf("[INFO] Deactivating New Relic notification channel %s", channel.ID)
+
+		if err := updateNotificationChannel(client, accountID, channel.ID, *input); err != nil {
+			return err
+		}
+	}
+
+	return resourceNewRelicNotificationChannelRead(d, meta)
+}
+
+func resourceNewRelicNotificationChannelRead(d *schema.ResourceData, meta interface{}) error {
+	client, err := meta.(*ProviderConfig).clientFor(d)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Reading New Relic notification channel %s", d.Id())
+
+	accountID, id, err := parseAccountScopedID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	channel, err := getNotificationChannel(client, accountID, id)
+	if err != nil {
+		if err == errNotificationChannelNotFound {
+			d.SetId("")
+			return nil
+		}
+
+		return err
+	}
+
+	d.Set("account_id", accountID)
+
+	return readNotificationChannelStruct(channel, d)
+}
+
+func resourceNewRelicNotificationChannelUpdate(d *schema.ResourceData, meta interface{}) error {
+	client, err := meta.(*ProviderConfig).clientFor(d)
+	if err != nil {
+		return err
+	}
+
+	accountID, id, err := parseAccountScopedID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	input := buildNotificationChannelStruct(d)
+	active := d.Get("active").(bool)
+	input.Active = &active
+
+	log.Printf("[INFO] Updating New Relic notification channel %s", id)
+
+	if err := updateNotificationChannel(client, accountID, id, *input); err != nil {
+		return err
+	}
+
+	return resourceNewRelicNotificationChannelRead(d, meta)
+}
+
+func resourceNewRelicNotificationChannelDelete(d *schema.ResourceData, meta interface{}) error {
+	client, err := meta.(*ProviderConfig).clientFor(d)
+	if err != nil {
+		return err
+	}
+
+	accountID, id, err := parseAccountScopedID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Deleting New Relic notification channel %s", id)
+
+	if err := deleteNotificationChannel(client, accountID, id); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,194 @@
+package newrelic
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccNewRelicNotificationChannel_Basic(t *testing.T) {
+	accountID := testAccAccountID(t)
+	rName := acctest.RandString(5)
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNewRelicNotificationChannelDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckNewRelicNotificationChannelConfig(accountID, rName, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicNotificationChannelExists("newrelic_notification_channel.foo"),
+					resource.TestCheckResourceAttrPair(
+						"newrelic_notification_channel.foo", "destination_id", "newrelic_notification_destination.foo", "destination_id"),
+					resource.TestCheckResourceAttr(
+						"newrelic_notification_channel.foo", "product", "IINT"),
+					resource.TestCheckResourceAttr(
+						"newrelic_notification_channel.foo", "active", "true"),
+				),
+			},
+			{
+				Config: testAccCheckNewRelicNotificationChannelConfig(accountID, rName, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicNotificationChannelExists("newrelic_notification_channel.foo"),
+					resource.TestCheckResourceAttr(
+						"newrelic_notification_channel.foo", "active", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccNewRelicNotificationChannel_import(t *testing.T) {
+	accountID := testAccAccountID(t)
+	resourceName := "newrelic_notification_channel.foo"
+	rName := acctest.RandString(5)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNewRelicNotificationChannelDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckNewRelicNotificationChannelConfig(accountID, rName, true),
+			},
+
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestResourceNewRelicNotificationChannel_inactive(t *testing.T) {
+	var updated map[string]interface{}
+
+	channel := `{"id":"b7f3a2c5","name":"foo","type":"WEBHOOK","product":"IINT","destinationId":"a6e2f1b4","active":%t,"properties":[{"key":"payload","value":"{}"}]}`
+	active := true
+
+	meta, closeServer := testNotificationsProviderConfig(t, func(query string, variables map[string]interface{}) string {
+		switch {
+		case strings.Contains(query, "aiNotificationsCreateChannel"):
+			return `{"data":{"aiNotificationsCreateChannel":{"channel":` + fmt.Sprintf(channel, active) + `,"errors":[]}}}`
+		case strings.Contains(query, "aiNotificationsUpdateChannel"):
+			updated = variables["channel"].(map[string]interface{})
+			active = updated["active"].(bool)
+			return `{"data":{"aiNotificationsUpdateChannel":{"channel":{"id":"b7f3a2c5"},"errors":[]}}}`
+		}
+
+		return `{"data":{"actor":{"account":{"aiNotifications":{"channels":{"entities":[` + fmt.Sprintf(channel, active) + `]}}}}}}`
+	})
+	defer closeServer()
+
+	r := resourceNewRelicNotificationChannel()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"account_id":     1,
+		"destination_id": "a6e2f1b4",
+		"name":           "foo",
+		"type":           "WEBHOOK",
+		"active":         false,
+		"properties":     map[string]interface{}{"payload": "{}"},
+	})
+
+	if err := r.Create(d, meta); err != nil {
+		t.Fatal(err)
+	}
+
+	if d.Id() != "1:b7f3a2c5" {
+		t.Fatalf("expected ID 1:b7f3a2c5, got %s", d.Id())
+	}
+
+	if updated == nil || d.Get("active").(bool) {
+		t.Fatalf("expected the channel to be deactivated after creation, got %v", updated)
+	}
+
+	if _, ok := updated["destinationId"]; ok {
+		t.Fatalf("expected the destination not to be sent on update, got %v", updated)
+	}
+}
+
+func TestResourceNewRelicNotificationChannel_immutableAttributes(t *testing.T) {
+	testResourceAttributesForceNew(t, resourceNewRelicNotificationChannel(), "account_id", "destination_id", "type", "product")
+}
+
+func testAccCheckNewRelicNotificationChannelDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*ProviderConfig).Client
+	for _, r := range s.RootModule().Resources {
+		if r.Type != "newrelic_notification_channel" {
+			continue
+		}
+
+		accountID, id, err := parseAccountScopedID(r.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		_, err = getNotificationChannel(client, accountID, id)
+		if err == nil {
+			return fmt.Errorf("Notification channel still exists")
+		}
+	}
+	return nil
+}
+
+func testAccCheckNewRelicNotificationChannelExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No notification channel ID is set")
+		}
+
+		client := testAccProvider.Meta().(*ProviderConfig).Client
+
+		accountID, id, err := parseAccountScopedID(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		found, err := getNotificationChannel(client, accountID, id)
+		if err != nil {
+			return err
+		}
+
+		if found.ID != id {
+			return fmt.Errorf("Notification channel not found: %v - %v", rs.Primary.ID, found)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckNewRelicNotificationChannelConfig(accountID string, rName string, active bool) string {
+	return fmt.Sprintf(`
+resource "newrelic_notification_destination" "foo" {
+  account_id = %[1]s
+  name       = "tf-test-%[2]s"
+  type       = "WEBHOOK"
+
+  properties = {
+    url = "https://example.com/webhook"
+  }
+}
+
+resource "newrelic_notification_channel" "foo" {
+  account_id     = %[1]s
+  destination_id = "${newrelic_notification_destination.foo.destination_id}"
+  name           = "tf-test-%[2]s"
+  type           = "WEBHOOK"
+  active         = %[3]t
+
+  properties = {
+    payload = "{\"issue\": \"{{ issueTitle }}\"}"
+  }
+}
+`, accountID, rName, active)
+}
@@ -0,0 +1,329 @@
+package newrelic
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+// notificationDestinationRequiredProperties are the properties each
+// destination type can't be created without.
+var notificationDestinationRequiredProperties = map[string]string{
+	"EMAIL":   "email",
+	"WEBHOOK": "url",
+}
+
+func resourceNewRelicNotificationDestination() *schema.Resource {
+	validTypes := make([]string, 0, len(notificationDestinationRequiredProperties))
+	for k := range notificationDestinationRequiredProperties {
+		validTypes = append(validTypes, k)
+	}
+
+	return &schema.Resource{
+		Create: resourceNewRelicNotificationDestinationCreate,
+		Read:   resourceNewRelicNotificationDestinationRead,
+		Update: resourceNewRelicNotificationDestinationUpdate,
+		Delete: resourceNewRelicNotificationDestinationDelete,
+		Importer: &schema.ResourceImporter{
+			State: importNotificationResource,
+		},
+		Schema: map[string]*schema.Schema{
+			"api_key": apiKeySchema(),
+			"account_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			"destination_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(validTypes, false),
+			},
+			"active": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"properties": {
+				Type:     schema.TypeMap,
+				Required: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"auth": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice([]string{"BASIC", "TOKEN"}, false),
+						},
+						"user": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"password": {
+							Type:      schema.TypeString,
+							Optional:  true,
+							Sensitive: true,
+						},
+						"prefix": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"token": {
+							Type:      schema.TypeString,
+							Optional:  true,
+							Sensitive: true,
+						},
+					},
+				},
+			},
+		},
+		CustomizeDiff: validateNotificationDestination,
+	}
+}
+
+// validateNotificationDestination checks the properties and auth each
+// destination type requires, since the API only rejects them on apply.
+func validateNotificationDestination(d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("type") || !d.NewValueKnown("properties") || !d.NewValueKnown("auth") {
+		return nil
+	}
+
+	destinationType := d.Get("type").(string)
+
+	if key, ok := notificationDestinationRequiredProperties[destinationType]; ok {
+		if _, ok := d.Get("properties").(map[string]interface{})[key]; !ok {
+			return fmt.Errorf("properties must contain %s for %s destinations", key, destinationType)
+		}
+	}
+
+	auth := d.Get("auth").([]interface{})
+	if len(auth) == 0 {
+		return nil
+	}
+
+	if destinationType != "WEBHOOK" {
+		return fmt.Errorf("auth is only supported by WEBHOOK destinations, got type %s", destinationType)
+	}
+
+	a := auth[0].(map[string]interface{})
+
+	switch a["type"] {
+	case "BASIC":
+		if a["user"] == "" || a["password"] == "" {
+			return fmt.Errorf("auth of type BASIC requires user and password")
+		}
+	case "TOKEN":
+		if a["token"] == "" {
+			return fmt.Errorf("auth of type TOKEN requires token")
+		}
+	}
+
+	return nil
+}
+
+// importNotificationResource imports notification destinations and channels
+// by "<account_id>:<id>".
+func importNotificationResource(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	accountID, _, err := parseAccountScopedID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	d.Set("account_id", accountID)
+	return []*schema.ResourceData{d}, nil
+}
+
+func expandNotificationProperties(properties map[string]interface{}) []notificationProperty {
+	expanded := make([]notificationProperty, 0, len(properties))
+	for k, v := range properties {
+		expanded = append(expanded, notificationProperty{Key: k, Value: v.(string)})
+	}
+
+	return expanded
+}
+
+func flattenNotificationProperties(properties []notificationProperty) map[string]interface{} {
+	flattened := make(map[string]interface{}, len(properties))
+	for _, p := range properties {
+		flattened[p.Key] = p.Value
+	}
+
+	return flattened
+}
+
+func buildNotificationDestinationStruct(d *schema.ResourceData) *notificationDestinationInput {
+	destination := notificationDestinationInput{
+		Name:       d.Get("name").(string),
+		Properties: expandNotificationProperties(d.Get("properties").(map[string]interface{})),
+	}
+
+	if attr, ok := d.GetOk("auth"); ok {
+		a := attr.([]interface{})[0].(map[string]interface{})
+
+		destination.Auth = &notificationAuthInput{Type: a["type"].(string)}
+
+		switch destination.Auth.Type {
+		case "BASIC":
+			destination.Auth.Basic = &notificationBasicAuthInput{
+				User:     a["user"].(string),
+				Password: a["password"].(string),
+			}
+		case "TOKEN":
+			destination.Auth.Token = &notificationTokenAuthInput{
+				Prefix: a["prefix"].(string),
+				Token:  a["token"].(string),
+			}
+		}
+	}
+
+	return &destination
+}
+
+func readNotificationDestinationStruct(destination *notificationDestination, d *schema.ResourceData) error {
+	d.Set("destination_id", destination.ID)
+	d.Set("name", destination.Name)
+	d.Set("type", destination.Type)
+	d.Set("active", destination.Active)
+
+	if err := d.Set("properties", flattenNotificationProperties(destination.Properties)); err != nil {
+		return err
+	}
+
+	// Passwords and tokens aren't returned by the API, keep them from the
+	// state to avoid a diff.
+	var auth []interface{}
+	if destination.Auth != nil && destination.Auth.AuthType != "" {
+		auth = append(auth, map[string]interface{}{
+			"type":     destination.Auth.AuthType,
+			"user":     destination.Auth.User,
+			"password": d.Get("auth.0.password").(string),
+			"prefix":   destination.Auth.Prefix,
+			"token":    d.Get("auth.0.token").(string),
+		})
+	}
+
+	return d.Set("auth", auth)
+}
+
+func resourceNewRelicNotificationDestinationCreate(d *schema.ResourceData, meta interface{}) error {
+	client, err := meta.(*ProviderConfig).clientFor(d)
+	if err != nil {
+		return err
+	}
+
+	accountID := d.Get("account_id").(int)
+	input := buildNotificationDestinationStruct(d)
+	input.Type = d.Get("type").(string)
+
+	log.Printf("[INFO] Creating New Relic notification destination %s", input.Name)
+
+	destination, err := createNotificationDestination(client, accountID, *input)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(strconv.Itoa(accountID) + ":" + destination.ID)
+
+	// Destinations are always created active.
+	if active := d.Get("active").(bool); !active {
+		input.Type = ""
+		input.Active = &active
+
+		log.Printf("[INFO] Deactivating New Relic notification destination %s", destination.ID)
+
+		if err := updateNotificationDestination(client, accountID, destination.ID, *input); err != nil {
+			return err
+		}
+	}
+
+	return resourceNewRelicNotificationDestinationRead(d, meta)
+}
+
+func resourceNewRelicNotificationDestinationRead(d *schema.ResourceData, meta interface{}) error {
+	client, err := meta.(*ProviderConfig).clientFor(d)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Reading New Relic notification destination %s", d.Id())
+
+	accountID, id, err := parseAccountScopedID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	destination, err := getNotificationDestination(client, accountID, id)
+	if err != nil {
+		if err == errNotificationDestinationNotFound {
+			d.SetId("")
+			return nil
+		}
+
+		return err
+	}
+
+	d.Set("account_id", accountID)
+
+	return readNotificationDestinationStruct(destination, d)
+}
+
+func resourceNewRelicNotificationDestinationUpdate(d *schema.ResourceData, meta interface{}) error {
+	client, err := meta.(*ProviderConfig).clientFor(d)
+	if err != nil {
+		return err
+	}
+
+	accountID, id, err := parseAccountScopedID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	input := buildNotificationDestinationStruct(d)
+	active := d.Get("active").(bool)
+	input.Active = &active
+
+	log.Printf("[INFO] Updating New Relic notification destination %s", id)
+
+	if err := updateNotificationDestination(client, accountID, id, *input); err != nil {
+		return err
+	}
+
+	return resourceNewRelicNotificationDestinationRead(d, meta)
+}
+
+func resourceNewRelicNotificationDestinationDelete(d *schema.ResourceData, meta interface{}) error {
+	client, err := meta.(*ProviderConfig).clientFor(d)
+	if err != nil {
+		return err
+	}
+
+	accountID, id, err := parseAccountScopedID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Deleting New Relic notification destination %s", id)
+
+	if err := deleteNotificationDestination(client, accountID, id); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,328 @@
+package newrelic
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+	newrelic "github.com/paultyng/go-newrelic/v4/api"
+)
+
+func TestAccNewRelicNotificationDestination_Basic(t *testing.T) {
+	accountID := testAccAccountID(t)
+	rName := acctest.RandString(5)
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNewRelicNotificationDestinationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckNewRelicNotificationDestinationConfig(accountID, rName, ""),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicNotificationDestinationExists("newrelic_notification_destination.foo"),
+					resource.TestCheckResourceAttr(
+						"newrelic_notification_destination.foo", "name", fmt.Sprintf("tf-test-%s", rName)),
+					resource.TestCheckResourceAttr(
+						"newrelic_notification_destination.foo", "properties.url", "https://example.com/webhook"),
+					resource.TestCheckResourceAttr(
+						"newrelic_notification_destination.foo", "auth.#", "0"),
+				),
+			},
+			{
+				Config: testAccCheckNewRelicNotificationDestinationConfig(accountID, rName, `
+  auth {
+    type   = "TOKEN"
+    prefix = "Bearer"
+    token  = "tf-test-token"
+  }`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicNotificationDestinationExists("newrelic_notification_destination.foo"),
+					resource.TestCheckResourceAttr(
+						"newrelic_notification_destination.foo", "auth.0.type", "TOKEN"),
+					resource.TestCheckResourceAttr(
+						"newrelic_notification_destination.foo", "auth.0.token", "tf-test-token"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccNewRelicNotificationDestination_email(t *testing.T) {
+	accountID := testAccAccountID(t)
+	rName := acctest.RandString(5)
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNewRelicNotificationDestinationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "newrelic_notification_destination" "foo" {
+  account_id = %[1]s
+  name       = "tf-test-%[2]s"
+  type       = "EMAIL"
+
+  properties = {
+    email = "terraform-acctest+foo@hashicorp.com"
+  }
+}
+`, accountID, rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicNotificationDestinationExists("newrelic_notification_destination.foo"),
+					resource.TestCheckResourceAttr(
+						"newrelic_notification_destination.foo", "type", "EMAIL"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccNewRelicNotificationDestination_import(t *testing.T) {
+	accountID := testAccAccountID(t)
+	resourceName := "newrelic_notification_destination.foo"
+	rName := acctest.RandString(5)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNewRelicNotificationDestinationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckNewRelicNotificationDestinationConfig(accountID, rName, `
+  auth {
+    type     = "BASIC"
+    user     = "terraform"
+    password = "tf-test-password"
+  }`),
+			},
+
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"auth.0.password"},
+			},
+		},
+	})
+}
+
+func TestAccNewRelicNotificationDestination_missingProperty(t *testing.T) {
+	expectedErrorMsg, _ := regexp.Compile("properties must contain email for EMAIL destinations")
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "newrelic_notification_destination" "foo" {
+  account_id = 1
+  name       = "tf-test"
+  type       = "EMAIL"
+
+  properties = {
+    url = "https://example.com/webhook"
+  }
+}
+`,
+				ExpectError: expectedErrorMsg,
+			},
+		},
+	})
+}
+
+func TestAccNewRelicNotificationDestination_incompleteAuth(t *testing.T) {
+	expectedErrorMsg, _ := regexp.Compile("auth of type BASIC requires user and password")
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckNewRelicNotificationDestinationConfig("1", acctest.RandString(5), `
+  auth {
+    type = "BASIC"
+    user = "terraform"
+  }`),
+				ExpectError: expectedErrorMsg,
+			},
+		},
+	})
+}
+
+// testNotificationsProviderConfig returns a ProviderConfig whose NerdGraph
+// requests are answered by handler with the request's query and variables.
+func testNotificationsProviderConfig(t *testing.T, handler func(query string, variables map[string]interface{}) string) (*ProviderConfig, func()) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Query     string                 `json:"query"`
+			Variables map[string]interface{} `json:"variables"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Error(err)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(handler(req.Query, req.Variables)))
+	}))
+
+	client := newrelic.New(newrelic.Config{BaseURL: server.URL})
+
+	return &ProviderConfig{Client: &client}, server.Close
+}
+
+func TestResourceNewRelicNotificationDestination_auth(t *testing.T) {
+	var created map[string]interface{}
+
+	destination := `{"id":"a6e2f1b4","name":"foo","type":"WEBHOOK","active":true,"properties":[{"key":"url","value":"https://example.com/webhook"}],"auth":{"authType":"TOKEN","prefix":"Bearer"}}`
+
+	meta, closeServer := testNotificationsProviderConfig(t, func(query string, variables map[string]interface{}) string {
+		if strings.Contains(query, "aiNotificationsCreateDestination") {
+			created = variables["destination"].(map[string]interface{})
+			return `{"data":{"aiNotificationsCreateDestination":{"destination":` + destination + `,"errors":[]}}}`
+		}
+
+		return `{"data":{"actor":{"account":{"aiNotifications":{"destinations":{"entities":[` + destination + `]}}}}}}`
+	})
+	defer closeServer()
+
+	r := resourceNewRelicNotificationDestination()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"account_id": 1,
+		"name":       "foo",
+		"type":       "WEBHOOK",
+		"properties": map[string]interface{}{"url": "https://example.com/webhook"},
+		"auth": []interface{}{
+			map[string]interface{}{"type": "TOKEN", "prefix": "Bearer", "token": "secret"},
+		},
+	})
+
+	if err := r.Create(d, meta); err != nil {
+		t.Fatal(err)
+	}
+
+	if d.Id() != "1:a6e2f1b4" || d.Get("destination_id").(string) != "a6e2f1b4" {
+		t.Fatalf("expected ID 1:a6e2f1b4, got %s", d.Id())
+	}
+
+	if token := created["auth"].(map[string]interface{})["token"]; token == nil || token.(map[string]interface{})["token"] != "secret" {
+		t.Fatalf("expected the token to be sent, got %v", created["auth"])
+	}
+
+	if _, ok := created["active"]; ok {
+		t.Fatalf("expected active not to be sent on creation, got %v", created)
+	}
+
+	// The token isn't returned by the API and is kept from the state.
+	if token := d.Get("auth.0.token").(string); token != "secret" {
+		t.Fatalf("expected the token to be kept, got %q", token)
+	}
+}
+
+func TestResourceNewRelicNotificationDestinationRead_notFound(t *testing.T) {
+	meta, closeServer := testNotificationsProviderConfig(t, func(query string, variables map[string]interface{}) string {
+		return `{"data":{"actor":{"account":{"aiNotifications":{"destinations":{"entities":[]}}}}}}`
+	})
+	defer closeServer()
+
+	r := resourceNewRelicNotificationDestination()
+	d := r.TestResourceData()
+	d.SetId("1:a6e2f1b4")
+
+	if err := r.Read(d, meta); err != nil {
+		t.Fatal(err)
+	}
+
+	if d.Id() != "" {
+		t.Fatalf("expected the destination to be removed from the state, got ID %q", d.Id())
+	}
+}
+
+func TestNotificationErrors(t *testing.T) {
+	meta, closeServer := testNotificationsProviderConfig(t, func(query string, variables map[string]interface{}) string {
+		return `{"data":{"aiNotificationsCreateDestination":{"destination":null,"errors":[{"description":"Invalid url","details":"url must be https"}]}}}`
+	})
+	defer closeServer()
+
+	_, err := createNotificationDestination(meta.Client, 1, notificationDestinationInput{Name: "foo"})
+
+	expected := "error: aiNotificationsCreateDestination failed: Invalid url url must be https"
+	if err == nil || err.Error() != expected {
+		t.Fatalf("expected %q, got %q", expected, err)
+	}
+}
+
+func TestResourceNewRelicNotificationDestination_immutableAttributes(t *testing.T) {
+	testResourceAttributesForceNew(t, resourceNewRelicNotificationDestination(), "account_id", "type")
+}
+
+func testAccCheckNewRelicNotificationDestinationDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*ProviderConfig).Client
+	for _, r := range s.RootModule().Resources {
+		if r.Type != "newrelic_notification_destination" {
+			continue
+		}
+
+		accountID, id, err := parseAccountScopedID(r.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		_, err = getNotificationDestination(client, accountID, id)
+		if err == nil {
+			return fmt.Errorf("Notification destination still exists")
+		}
+	}
+	return nil
+}
+
+func testAccCheckNewRelicNotificationDestinationExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No notification destination ID is set")
+		}
+
+		client := testAccProvider.Meta().(*ProviderConfig).Client
+
+		accountID, id, err := parseAccountScopedID(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		found, err := getNotificationDestination(client, accountID, id)
+		if err != nil {
+			return err
+		}
+
+		if found.ID != id {
+			return fmt.Errorf("Notification destination not found: %v - %v", rs.Primary.ID, found)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckNewRelicNotificationDestinationConfig(accountID string, rName string, auth string) string {
+	return fmt.Sprintf(`
+resource "newrelic_notification_destination" "foo" {
+  account_id = %[1]s
+  name       = "tf-test-%[2]s"
+  type       = "WEBHOOK"
+
+  properties = {
+    url = "https://example.com/webhook"
+  }
+%[3]s
+}
+`, accountID, rName, auth)
+}
@@ -3,12 +3,22 @@ package newrelic
 import (
 	"fmt"
 	"log"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/helper/validation"
 	newrelic "github.com/paultyng/go-newrelic/v4/api"
 )
 
+// nrqlSinceValues are the evaluation offsets, in minutes, the API accepts for
+// the SINCE clause of a condition's query. Offsets beyond 5 minutes leave time
+// for telemetry that's ingested late.
+var nrqlSinceValues = []string{
+	"1", "2", "3", "4", "5", "6", "7", "8", "9", "10",
+	"11", "12", "13", "14", "15", "16", "17", "18", "19", "20",
+}
+
 func resourceNewRelicNrqlAlertCondition() *schema.Resource {
 
 	return &schema.Resource{
@@ -19,25 +29,39 @@ func resourceNewRelicNrqlAlertCondition() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(1 * time.Minute),
+		},
 		Schema: map[string]*schema.Schema{
-			"policy_id": {
-				Type:     schema.TypeInt,
-				Required: true,
-				ForceNew: true,
-			},
+			"api_key":     apiKeySchema(),
+			"policy_id":   alertConditionPolicyIDSchema(),
+			"policy_name": alertConditionPolicyNameSchema(),
 			"name": {
 				Type:     schema.TypeString,
 				Required: true,
 			},
 			"runbook_url": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateRunbookURL,
 			},
 			"enabled": {
 				Type:     schema.TypeBool,
 				Optional: true,
 				Default:  true,
 			},
+			"type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "static",
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"static", "baseline"}, false),
+			},
+			"baseline_direction": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"lower_only", "upper_only", "upper_and_lower"}, false),
+			},
 			"nrql": {
 				Type:     schema.TypeList,
 				Required: true,
@@ -46,13 +70,19 @@ func resourceNewRelicNrqlAlertCondition() *schema.Resource {
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"query": {
-							Type:     schema.TypeString,
-							Required: true,
+							Type:             schema.TypeString,
+							Required:         true,
+							DiffSuppressFunc: suppressEquivalentNrql,
 						},
 						"since_value": {
 							Type:         schema.TypeString,
 							Required:     true,
-							ValidateFunc: validation.StringInSlice([]string{"1", "2", "3", "4", "5"}, false),
+							ValidateFunc: validation.StringInSlice(nrqlSinceValues, false),
+						},
+						"account_id": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validation.IntAtLeast(1),
 						},
 					},
 				},
@@ -64,13 +94,13 @@ func resourceNewRelicNrqlAlertCondition() *schema.Resource {
 						"duration": {
 							Type:         schema.TypeInt,
 							Required:     true,
-							ValidateFunc: intInSlice([]int{1, 2, 3, 4, 5, 10, 15, 30, 60, 120}),
+							ValidateFunc: validation.IntBetween(1, 120),
 						},
 						"operator": {
 							Type:         schema.TypeString,
 							Optional:     true,
 							Default:      "equal",
-							ValidateFunc: validation.StringInSlice([]string{"above", "below", "equal"}, false),
+							ValidateFunc: validateThresholdOperator,
 						},
 						"priority": {
 							Type:         schema.TypeString,
@@ -92,6 +122,7 @@ func resourceNewRelicNrqlAlertCondition() *schema.Resource {
 				},
 				Required: true,
 				MinItems: 1,
+				MaxItems: 2,
 			},
 			"value_function": {
 				Type:         schema.TypeString,
@@ -99,11 +130,219 @@ func resourceNewRelicNrqlAlertCondition() *schema.Resource {
 				Default:      "single_value",
 				ValidateFunc: validation.StringInSlice([]string{"single_value", "sum"}, false),
 			},
+			"fill_option": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "none",
+				ValidateFunc: validation.StringInSlice([]string{"none", "last_value", "static"}, false),
+			},
+			"fill_value": {
+				Type:     schema.TypeFloat,
+				Optional: true,
+			},
+			// The aggregation attributes are computed, so that the API's
+			// defaults are kept when they're unset.
+			"aggregation_window": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntBetween(30, 7200),
+			},
+			"aggregation_method": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{"event_flow", "event_timer", "cadence"}, false),
+			},
+			"aggregation_delay": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntBetween(0, 1200),
+			},
+			"aggregation_timer": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntBetween(5, 1200),
+			},
+			"violation_time_limit_seconds": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: intInSlice([]int{3600, 7200, 14400, 28800, 43200, 86400}),
+			},
 		},
+		CustomizeDiff: validateNrqlAlertCondition,
+	}
+}
+
+func validateNrqlAlertCondition(d *schema.ResourceDiff, meta interface{}) error {
+	if err := validateTermPriorities(d.Get("term").([]interface{})); err != nil {
+		return err
+	}
+
+	if err := validateNrqlAlertConditionFill(d); err != nil {
+		return err
+	}
+
+	if err := validateNrqlAlertConditionBaseline(d); err != nil {
+		return err
+	}
+
+	if err := validateNrqlAlertConditionAggregation(d); err != nil {
+		return err
+	}
+
+	return validateNrqlAlertConditionQuery(d, meta.(*ProviderConfig))
+}
+
+// validateNrqlAlertConditionFill ensures fill_value is set if, and only if,
+// gaps are filled with a static value.
+func validateNrqlAlertConditionFill(d *schema.ResourceDiff) error {
+	if !d.NewValueKnown("fill_option") || !d.NewValueKnown("fill_value") {
+		return nil
+	}
+
+	fillOption := d.Get("fill_option").(string)
+
+	if _, ok := d.GetOkExists("fill_value"); fillOption == "static" && !ok {
+		return fmt.Errorf("fill_value is required when fill_option is static")
+	}
+
+	// Conditions read without a fill value have a fill_value of 0 in the
+	// state, which GetOkExists reports as set even once it's removed from the
+	// configuration.
+	if _, ok := d.GetOk("fill_value"); fillOption != "static" && ok {
+		return fmt.Errorf("fill_value can only be set when fill_option is static, got fill_option %s", fillOption)
+	}
+
+	return nil
+}
+
+// validateNrqlAlertConditionBaseline ensures baseline_direction is set if,
+// and only if, the condition is a baseline condition, whose thresholds are
+// deviations above the baseline in the chosen direction.
+func validateNrqlAlertConditionBaseline(d *schema.ResourceDiff) error {
+	for _, k := range []string{"type", "baseline_direction", "value_function", "term"} {
+		if !d.NewValueKnown(k) {
+			return nil
+		}
+	}
+
+	_, hasBaselineDirection := d.GetOk("baseline_direction")
+	conditionType := d.Get("type").(string)
+
+	if conditionType != "baseline" {
+		if hasBaselineDirection {
+			return fmt.Errorf("baseline_direction can only be set when type is baseline, got type %s", conditionType)
+		}
+
+		return nil
+	}
+
+	if !hasBaselineDirection {
+		return fmt.Errorf("baseline_direction is required when type is baseline")
+	}
+
+	if valueFunction := d.Get("value_function").(string); valueFunction != "single_value" {
+		return fmt.Errorf("value_function can only be single_value when type is baseline, got value_function %s", valueFunction)
+	}
+
+	for _, t := range d.Get("term").([]interface{}) {
+		term, ok := t.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		if operator := term["operator"].(string); operator != "above" {
+			return fmt.Errorf("term operator must be above when type is baseline, got operator %s", operator)
+		}
+	}
+
+	return nil
+}
+
+// validateNrqlAlertConditionAggregation ensures aggregation_timer is only set
+// for the event_timer aggregation method, which closes windows after a time
+// without data, and aggregation_delay only for the others, which close them
+// after a delay. Values are only checked when they're changed, since the
+// API's defaults for the previous method stay in the state until the next
+// refresh. An unset aggregation_method isn't known until the API sets it, so
+// it's left for the API to check.
+func validateNrqlAlertConditionAggregation(d *schema.ResourceDiff) error {
+	if !d.NewValueKnown("aggregation_method") {
+		return nil
+	}
+
+	method := d.Get("aggregation_method").(string)
+
+	if _, ok := d.GetOk("aggregation_timer"); ok && d.NewValueKnown("aggregation_timer") && d.HasChange("aggregation_timer") && method != "event_timer" {
+		return fmt.Errorf("aggregation_timer can only be set when aggregation_method is event_timer, got aggregation_method %s", method)
+	}
+
+	if _, ok := d.GetOkExists("aggregation_delay"); ok && d.NewValueKnown("aggregation_delay") && d.HasChange("aggregation_delay") && method == "event_timer" {
+		return fmt.Errorf("aggregation_delay can't be set when aggregation_method is event_timer, set aggregation_timer instead")
+	}
+
+	return nil
+}
+
+// suppressEquivalentNrql suppresses diffs between queries that only differ in
+// whitespace or in the case of keywords and function names, e.g. after
+// reformatting a query.
+func suppressEquivalentNrql(k, old, new string, d *schema.ResourceData) bool {
+	return old != "" && normalizeNrql(old) == normalizeNrql(new)
+}
+
+// validateNrqlAlertConditionQuery runs new or changed queries with LIMIT 0
+// when the provider's validate_nrql is enabled, so that malformed NRQL fails
+// the plan instead of the apply, and warns about event types in their FROM
+// clause the account doesn't know.
+func validateNrqlAlertConditionQuery(d *schema.ResourceDiff, p *ProviderConfig) error {
+	if !p.validateNrql || !(d.HasChange("nrql.0.query") || d.HasChange("nrql.0.account_id")) {
+		return nil
+	}
+
+	if !d.NewValueKnown("nrql.0.query") || !d.NewValueKnown("nrql.0.account_id") {
+		return nil
+	}
+
+	accountID := p.accountID
+	if v, ok := d.GetOk("nrql.0.account_id"); ok {
+		accountID = v.(int)
+	}
+
+	if accountID == 0 {
+		return fmt.Errorf("validate_nrql requires the provider account_id or nrql.0.account_id to be set")
+	}
+
+	client, err := p.clientFor(d)
+	if err != nil {
+		return err
+	}
+
+	query := d.Get("nrql.0.query").(string)
+
+	log.Printf("[INFO] Validating NRQL query for New Relic NRQL alert condition %s", d.Get("name").(string))
+
+	if err := validateNrql(client, accountID, query); err != nil {
+		return fmt.Errorf("nrql.0.query %q is not valid: %s", query, err)
+	}
+
+	// Custom event types may not be listed yet, so unknown ones are only
+	// warned about.
+	unknown, err := unknownNrqlEventTypes(client, accountID, query)
+	if err != nil {
+		log.Printf("[WARN] Could not list the event types of New Relic account %d: %s", accountID, err)
+	} else if len(unknown) > 0 {
+		log.Printf("[WARN] nrql.0.query of New Relic NRQL alert condition %s queries event types %v that account %d hasn't reported in the last week; check the FROM clause for typos", d.Get("name").(string), unknown, accountID)
 	}
+
+	return nil
 }
 
-func buildNrqlAlertConditionStruct(d *schema.ResourceData) *newrelic.AlertNrqlCondition {
+func buildNrqlAlertConditionStruct(d *schema.ResourceData) *alertNrqlCondition {
 	termSet := d.Get("term").([]interface{})
 	terms := make([]newrelic.AlertConditionTerm, len(termSet))
 
@@ -119,7 +358,7 @@ func buildNrqlAlertConditionStruct(d *schema.ResourceData) *newrelic.AlertNrqlCo
 		}
 	}
 
-	query := newrelic.AlertNrqlQuery{}
+	query := alertNrqlQuery{}
 
 	if nrqlQuery, ok := d.GetOk("nrql.0.query"); ok {
 		query.Query = nrqlQuery.(string)
@@ -129,23 +368,59 @@ func buildNrqlAlertConditionStruct(d *schema.ResourceData) *newrelic.AlertNrqlCo
 		query.SinceValue = sinceValue.(string)
 	}
 
-	condition := newrelic.AlertNrqlCondition{
-		Name:          d.Get("name").(string),
-		Enabled:       d.Get("enabled").(bool),
-		Terms:         terms,
-		PolicyID:      d.Get("policy_id").(int),
-		Nrql:          query,
-		ValueFunction: d.Get("value_function").(string),
+	if accountID, ok := d.GetOk("nrql.0.account_id"); ok {
+		query.AccountID = accountID.(int)
+	}
+
+	condition := alertNrqlCondition{
+		Type:     d.Get("type").(string),
+		Name:     d.Get("name").(string),
+		Enabled:  d.Get("enabled").(bool),
+		Terms:    terms,
+		PolicyID: d.Get("policy_id").(int),
+		Nrql:     query,
 	}
 
-	if attr, ok := d.GetOk("runbook_url"); ok {
-		condition.RunbookURL = attr.(string)
+	// The value function only applies to static conditions, and the baseline
+	// direction to baseline conditions.
+	if condition.Type == "baseline" {
+		condition.BaselineDirection = d.Get("baseline_direction").(string)
+	} else {
+		condition.ValueFunction = d.Get("value_function").(string)
+	}
+
+	// Always sent so that removing the URL or setting it to "" clears it.
+	condition.RunbookURL = d.Get("runbook_url").(string)
+
+	if attr, ok := d.GetOk("violation_time_limit_seconds"); ok {
+		condition.ViolationTimeLimitSeconds = attr.(int)
+	}
+
+	condition.Signal = &alertNrqlSignal{
+		FillOption: d.Get("fill_option").(string),
+	}
+
+	if fillValue, ok := d.GetOkExists("fill_value"); ok && condition.Signal.FillOption == "static" {
+		value := fillValue.(float64)
+		condition.Signal.FillValue = &value
+	}
+
+	// Unset aggregation attributes are left out, so that the API's defaults
+	// apply. Only the delay or the timer of the method is sent.
+	condition.Signal.AggregationWindow = d.Get("aggregation_window").(int)
+	condition.Signal.AggregationMethod = strings.ToUpper(d.Get("aggregation_method").(string))
+
+	if condition.Signal.AggregationMethod == "EVENT_TIMER" {
+		condition.Signal.AggregationTimer = d.Get("aggregation_timer").(int)
+	} else if delay, ok := d.GetOkExists("aggregation_delay"); ok {
+		value := delay.(int)
+		condition.Signal.AggregationDelay = &value
 	}
 
 	return &condition
 }
 
-func readNrqlAlertConditionStruct(condition *newrelic.AlertNrqlCondition, d *schema.ResourceData) error {
+func readNrqlAlertConditionStruct(condition *alertNrqlCondition, d *schema.ResourceData) error {
 	ids, err := parseIDs(d.Id(), 2)
 	if err != nil {
 		return err
@@ -157,13 +432,68 @@ func readNrqlAlertConditionStruct(condition *newrelic.AlertNrqlCondition, d *sch
 	d.Set("name", condition.Name)
 	d.Set("runbook_url", condition.RunbookURL)
 	d.Set("enabled", condition.Enabled)
-	d.Set("value_function", condition.ValueFunction)
-	d.Set("nrql.0.Query", condition.Nrql.Query)
-	d.Set("nrql.0.SinceValue", condition.Nrql.SinceValue)
+	d.Set("baseline_direction", condition.BaselineDirection)
+
+	if condition.ViolationTimeLimitSeconds != 0 {
+		d.Set("violation_time_limit_seconds", condition.ViolationTimeLimitSeconds)
+	}
+
+	conditionType := "static"
+	if condition.Type != "" {
+		conditionType = condition.Type
+	}
+	d.Set("type", conditionType)
+
+	valueFunction := "single_value"
+	if condition.ValueFunction != "" {
+		valueFunction = condition.ValueFunction
+	}
+	d.Set("value_function", valueFunction)
+
+	fillOption := "none"
+	if condition.Signal != nil && condition.Signal.FillOption != "" {
+		fillOption = condition.Signal.FillOption
+	}
+	d.Set("fill_option", fillOption)
+
+	if condition.Signal != nil && condition.Signal.FillValue != nil {
+		d.Set("fill_value", *condition.Signal.FillValue)
+	} else {
+		d.Set("fill_value", nil)
+	}
+
+	// Aggregation attributes missing from the response keep their values.
+	if condition.Signal != nil {
+		if condition.Signal.AggregationWindow != 0 {
+			d.Set("aggregation_window", condition.Signal.AggregationWindow)
+		}
+
+		if condition.Signal.AggregationMethod != "" {
+			d.Set("aggregation_method", strings.ToLower(condition.Signal.AggregationMethod))
+		}
+
+		if condition.Signal.AggregationDelay != nil {
+			d.Set("aggregation_delay", *condition.Signal.AggregationDelay)
+		}
+
+		if condition.Signal.AggregationTimer != 0 {
+			d.Set("aggregation_timer", condition.Signal.AggregationTimer)
+		}
+	}
+
+	nrql := map[string]interface{}{
+		"query":       condition.Nrql.Query,
+		"since_value": condition.Nrql.SinceValue,
+		"account_id":  condition.Nrql.AccountID,
+	}
+
+	if err := d.Set("nrql", []interface{}{nrql}); err != nil {
+		return fmt.Errorf("[DEBUG] Error setting alert condition nrql: %#v", err)
+	}
 
 	var terms []map[string]interface{}
 
-	for _, src := range condition.Terms {
+	for _, src := range orderNrqlAlertConditionTerms(condition.Terms, d.Get("term").([]interface{})) {
 		dst := map[string]interface{}{
 			"duration":      src.Duration,
 			"operator":      src.Operator,
@@ -181,24 +511,76 @@ func readNrqlAlertConditionStruct(condition *newrelic.AlertNrqlCondition, d *sch
 	return nil
 }
 
+// orderNrqlAlertConditionTerms returns terms in the order of the priorities in
+// current, so that the order the API returns terms in doesn't cause a diff.
+// Terms with other priorities, e.g. on import, follow in the API's order.
+func orderNrqlAlertConditionTerms(terms []newrelic.AlertConditionTerm, current []interface{}) []newrelic.AlertConditionTerm {
+	ordered := make([]newrelic.AlertConditionTerm, 0, len(terms))
+	added := make([]bool, len(terms))
+
+	for _, t := range current {
+		term, ok := t.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		for i, src := range terms {
+			if !added[i] && src.Priority == term["priority"] {
+				ordered = append(ordered, src)
+				added[i] = true
+				break
+			}
+		}
+	}
+
+	for i, src := range terms {
+		if !added[i] {
+			ordered = append(ordered, src)
+		}
+	}
+
+	return ordered
+}
+
 func resourceNewRelicNrqlAlertConditionCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).Client
+	client, err := meta.(*ProviderConfig).clientFor(d)
+	if err != nil {
+		return err
+	}
+
+	if err := resolveAlertConditionPolicy(client, d); err != nil {
+		return err
+	}
+
 	condition := buildNrqlAlertConditionStruct(d)
 
 	log.Printf("[INFO] Creating New Relic NRQL alert condition %s", condition.Name)
 
-	condition, err := client.CreateAlertNrqlCondition(*condition)
+	condition, err = createAlertNrqlCondition(client, *condition)
 	if err != nil {
-		return err
+		return alertPolicyNotFoundError(client, d.Get("policy_id").(int), err)
 	}
 
 	d.SetId(serializeIDs([]int{condition.PolicyID, condition.ID}))
 
+	// Otherwise the read below can find no condition and remove it from the
+	// state.
+	err = waitForCreated(meta.(*ProviderConfig).stopContext(), d.Timeout(schema.TimeoutCreate), func() error {
+		_, err := getAlertNrqlCondition(client, condition.PolicyID, condition.ID)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("Error waiting for New Relic NRQL alert condition %d to be created: %s", condition.ID, err)
+	}
+
 	return resourceNewRelicNrqlAlertConditionRead(d, meta)
 }
 
 func resourceNewRelicNrqlAlertConditionRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).Client
+	client, err := meta.(*ProviderConfig).clientFor(d)
+	if err != nil {
+		return err
+	}
 
 	log.Printf("[INFO] Reading New Relic NRQL alert condition %s", d.Id())
 
@@ -210,7 +592,7 @@ func resourceNewRelicNrqlAlertConditionRead(d *schema.ResourceData, meta interfa
 	policyID := ids[0]
 	id := ids[1]
 
-	condition, err := client.GetAlertNrqlCondition(policyID, id)
+	condition, err := getAlertNrqlCondition(client, policyID, id)
 	if err != nil {
 		if err == newrelic.ErrNotFound {
 			d.SetId("")
@@ -224,7 +606,11 @@ func resourceNewRelicNrqlAlertConditionRead(d *schema.ResourceData, meta interfa
 }
 
 func resourceNewRelicNrqlAlertConditionUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).Client
+	client, err := meta.(*ProviderConfig).clientFor(d)
+	if err != nil {
+		return err
+	}
+
 	condition := buildNrqlAlertConditionStruct(d)
 
 	ids, err := parseIDs(d.Id(), 2)
@@ -235,21 +621,34 @@ func resourceNewRelicNrqlAlertConditionUpdate(d *schema.ResourceData, meta inter
 	policyID := ids[0]
 	id := ids[1]
 
+	if onlyEnabledChanged(d, resourceNewRelicNrqlAlertCondition().Schema) {
+		log.Printf("[INFO] Reading New Relic NRQL alert condition %d to update enabled", id)
+
+		if condition, err = getAlertNrqlCondition(client, policyID, id); err != nil {
+			return err
+		}
+
+		condition.Enabled = d.Get("enabled").(bool)
+	}
+
 	condition.PolicyID = policyID
 	condition.ID = id
 
 	log.Printf("[INFO] Updating New Relic NRQL alert condition %d", id)
 
-	_, err = client.UpdateAlertNrqlCondition(*condition)
+	_, err = updateAlertNrqlCondition(client, *condition)
 	if err != nil {
-		return err
+		return alertPolicyNotFoundError(client, policyID, err)
 	}
 
 	return resourceNewRelicNrqlAlertConditionRead(d, meta)
 }
 
 func resourceNewRelicNrqlAlertConditionDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).Client
+	client, err := meta.(*ProviderConfig).clientFor(d)
+	if err != nil {
+		return err
+	}
 
 	ids, err := parseIDs(d.Id(), 2)
 	if err != nil {
@@ -1,12 +1,19 @@
 package newrelic
 
 import (
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
+	newrelic "github.com/paultyng/go-newrelic/v4/api"
 )
 
 func TestAccNewRelicNrqlAlertCondition_Basic(t *testing.T) {
@@ -80,7 +87,736 @@ func TestAccNewRelicNrqlAlertCondition_Basic(t *testing.T) {
 	})
 }
 
-// TODO: func_ TestAccNewRelicNrqlAlertCondition_Multi(t *testing.T) {
+func TestAccNewRelicNrqlAlertCondition_Multi(t *testing.T) {
+	rName := acctest.RandString(5)
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNewRelicNrqlAlertConditionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckNewRelicNrqlAlertConditionConfigMulti(rName, "warning"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicNrqlAlertConditionExists("newrelic_nrql_alert_condition.foo"),
+					resource.TestCheckResourceAttr(
+						"newrelic_nrql_alert_condition.foo", "term.#", "2"),
+					resource.TestCheckResourceAttr(
+						"newrelic_nrql_alert_condition.foo", "term.0.priority", "critical"),
+					resource.TestCheckResourceAttr(
+						"newrelic_nrql_alert_condition.foo", "term.1.priority", "warning"),
+					resource.TestCheckResourceAttr(
+						"newrelic_nrql_alert_condition.foo", "term.1.duration", "120"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccNewRelicNrqlAlertCondition_removeWarningTerm(t *testing.T) {
+	rName := acctest.RandString(5)
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNewRelicNrqlAlertConditionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckNewRelicNrqlAlertConditionConfigMulti(rName, "warning"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicNrqlAlertConditionExists("newrelic_nrql_alert_condition.foo"),
+					resource.TestCheckResourceAttr(
+						"newrelic_nrql_alert_condition.foo", "term.#", "2"),
+				),
+			},
+			{
+				Config: testAccCheckNewRelicNrqlAlertConditionConfig(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicNrqlAlertConditionExists("newrelic_nrql_alert_condition.foo"),
+					resource.TestCheckResourceAttr(
+						"newrelic_nrql_alert_condition.foo", "term.#", "1"),
+					resource.TestCheckResourceAttr(
+						"newrelic_nrql_alert_condition.foo", "term.0.priority", "critical"),
+				),
+			},
+		},
+	})
+}
+
+func TestResourceNewRelicNrqlAlertConditionUpdate_removeWarningTerm(t *testing.T) {
+	var updated struct {
+		Condition alertNrqlCondition `json:"nrql_condition"`
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.Method == "PUT" {
+			body, _ := ioutil.ReadAll(r.Body)
+			json.Unmarshal(body, &updated)
+			w.Write(body)
+			return
+		}
+
+		updated.Condition.ID = 456
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"nrql_conditions": []alertNrqlCondition{updated.Condition},
+		})
+	}))
+	defer server.Close()
+
+	client := newrelic.New(newrelic.Config{BaseURL: server.URL})
+	meta := &ProviderConfig{Client: &client}
+
+	r := resourceNewRelicNrqlAlertCondition()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"policy_id": 123,
+		"name":      "foo",
+		"nrql": []interface{}{
+			map[string]interface{}{"query": "SELECT count(*) FROM Transaction", "since_value": "5"},
+		},
+		"term": []interface{}{
+			map[string]interface{}{"duration": 5, "priority": "critical", "threshold": 10.0, "time_function": "all"},
+		},
+	})
+	d.SetId("123:456")
+
+	if err := r.Update(d, meta); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(updated.Condition.Terms) != 1 || updated.Condition.Terms[0].Priority != "critical" {
+		t.Fatalf("expected only the critical term to be sent, got %#v", updated.Condition.Terms)
+	}
+
+	if n := len(d.Get("term").([]interface{})); n != 1 {
+		t.Fatalf("expected 1 term to be read back, got %d", n)
+	}
+}
+
+func TestOrderNrqlAlertConditionTerms(t *testing.T) {
+	terms := []newrelic.AlertConditionTerm{
+		{Priority: "warning", Threshold: 5},
+		{Priority: "critical", Threshold: 10},
+	}
+
+	current := []interface{}{
+		map[string]interface{}{"priority": "critical"},
+		map[string]interface{}{"priority": "warning"},
+	}
+
+	ordered := orderNrqlAlertConditionTerms(terms, current)
+	if len(ordered) != 2 || ordered[0].Priority != "critical" || ordered[1].Priority != "warning" {
+		t.Fatalf("expected the configured order, got %#v", ordered)
+	}
+
+	// A warning term added outside Terraform is kept, after the configured terms.
+	ordered = orderNrqlAlertConditionTerms(terms, current[:1])
+	if len(ordered) != 2 || ordered[0].Priority != "critical" || ordered[1].Priority != "warning" {
+		t.Fatalf("expected the unconfigured term last, got %#v", ordered)
+	}
+
+	ordered = orderNrqlAlertConditionTerms(terms, nil)
+	if len(ordered) != 2 || ordered[0].Priority != "warning" {
+		t.Fatalf("expected the API's order without configured terms, got %#v", ordered)
+	}
+}
+
+func TestSuppressEquivalentNrql(t *testing.T) {
+	query := "SELECT count(*) FROM Transaction WHERE appName = 'My App' FACET host SINCE 5 minutes ago"
+
+	cases := []struct {
+		new      string
+		suppress bool
+	}{
+		{query, true},
+		{"select COUNT( * )\n  from Transaction\n  where appName='My App'\n  facet host since 5 MINUTES AGO", true},
+		{query + ";", true},
+		// String literals, event types and attribute names are case and
+		// whitespace sensitive.
+		{"SELECT count(*) FROM Transaction WHERE appName = 'my app' FACET host SINCE 5 minutes ago", false},
+		{"SELECT count(*) FROM Transaction WHERE appName = 'My  App' FACET host SINCE 5 minutes ago", false},
+		{"SELECT count(*) FROM transaction WHERE appName = 'My App' FACET host SINCE 5 minutes ago", false},
+		{"SELECT count(*) FROM Transaction WHERE appname = 'My App' FACET host SINCE 5 minutes ago", false},
+		{"SELECT count(*) FROM Transaction WHERE appName = 'My App' FACET host SINCE 10 minutes ago", false},
+		{"SELECT count(*) FROM `Transaction Event` WHERE appName = 'My App'", false},
+	}
+
+	for _, c := range cases {
+		if suppress := suppressEquivalentNrql("nrql.0.query", query, c.new, nil); suppress != c.suppress {
+			t.Errorf("expected suppress %t for %q, got %t", c.suppress, c.new, suppress)
+		}
+	}
+
+	// Attributes named like keywords, e.g. max or day, are case sensitive
+	// except where the grammar puts the keyword.
+	cases = []struct {
+		new      string
+		suppress bool
+	}{
+		{"select average(Max) from Metric facet Day limit max since 1 day ago timeseries auto with timezone 'UTC'", true},
+		{"SELECT average(max) FROM Metric FACET Day LIMIT MAX SINCE 1 day ago TIMESERIES AUTO WITH TIMEZONE 'UTC'", false},
+		{"SELECT average(Max) FROM Metric FACET day LIMIT MAX SINCE 1 day ago TIMESERIES AUTO WITH TIMEZONE 'UTC'", false},
+		{"SELECT average(Max) FROM Metric FACET Day, Hour LIMIT MAX SINCE 1 day ago TIMESERIES AUTO WITH TIMEZONE 'UTC'", false},
+	}
+
+	query = "SELECT average(Max) FROM Metric FACET Day LIMIT MAX SINCE 1 DAY AGO TIMESERIES AUTO WITH TIMEZONE 'UTC'"
+	for _, c := range cases {
+		if suppress := suppressEquivalentNrql("nrql.0.query", query, c.new, nil); suppress != c.suppress {
+			t.Errorf("expected suppress %t for %q, got %t", c.suppress, c.new, suppress)
+		}
+	}
+
+	for _, c := range []struct{ old, new string }{
+		{"SELECT max(Offset) FROM Metric", "SELECT max(offset) FROM Metric"},
+		{"SELECT latest(Raw) FROM Metric", "SELECT latest(raw) FROM Metric"},
+		{"SELECT count(*) FROM Log FACET Timezone", "SELECT count(*) FROM Log FACET timezone"},
+		{"SELECT count(*) FROM Log WHERE Hour > 5", "SELECT count(*) FROM Log WHERE hour > 5"},
+	} {
+		if suppressEquivalentNrql("nrql.0.query", c.old, c.new, nil) {
+			t.Errorf("expected a diff between %q and %q", c.old, c.new)
+		}
+	}
+
+	// Quotes inside literals don't end them.
+	if !suppressEquivalentNrql("nrql.0.query", `SELECT count(*) FROM Log WHERE message = 'it\'s  DOWN'`, `select count(*) from Log where message='it\'s  DOWN'`, nil) {
+		t.Error("expected queries with an escaped quote in a literal to be equivalent")
+	}
+
+	if suppressEquivalentNrql("nrql.0.query", `SELECT count(*) FROM Log WHERE message = 'it\'s  DOWN'`, `SELECT count(*) FROM Log WHERE message = 'it\'s DOWN'`, nil) {
+		t.Error("expected whitespace inside a literal with an escaped quote to be significant")
+	}
+
+	if suppressEquivalentNrql("nrql.0.query", "", query, nil) {
+		t.Error("expected a new query not to be suppressed")
+	}
+}
+
+func TestAccNewRelicNrqlAlertCondition_duplicatePriority(t *testing.T) {
+	expectedErrorMsg, _ := regexp.Compile("only one term with priority \"critical\" may be defined")
+	resource.Test(t, resource.TestCase{
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCheckNewRelicNrqlAlertConditionConfigMulti(acctest.RandString(5), "critical"),
+				ExpectError: expectedErrorMsg,
+			},
+		},
+	})
+}
+
+func TestAccNewRelicNrqlAlertCondition_ValueFunction(t *testing.T) {
+	rName := acctest.RandString(5)
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNewRelicNrqlAlertConditionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckNewRelicNrqlAlertConditionConfigValueFunction(rName, ""),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicNrqlAlertConditionExists("newrelic_nrql_alert_condition.foo"),
+					resource.TestCheckResourceAttr(
+						"newrelic_nrql_alert_condition.foo", "value_function", "single_value"),
+				),
+			},
+			{
+				Config: testAccCheckNewRelicNrqlAlertConditionConfigValueFunction(rName, `value_function = "sum"`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicNrqlAlertConditionExists("newrelic_nrql_alert_condition.foo"),
+					resource.TestCheckResourceAttr(
+						"newrelic_nrql_alert_condition.foo", "value_function", "sum"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccNewRelicNrqlAlertCondition_invalidValueFunction(t *testing.T) {
+	expectedErrorMsg, _ := regexp.Compile("expected value_function to be one of \\[single_value sum\\]")
+	resource.Test(t, resource.TestCase{
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCheckNewRelicNrqlAlertConditionConfigValueFunction(acctest.RandString(5), `value_function = "sum_of"`),
+				ExpectError: expectedErrorMsg,
+			},
+		},
+	})
+}
+
+func TestAccNewRelicNrqlAlertCondition_fillOptionNone(t *testing.T) {
+	rName := acctest.RandString(5)
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNewRelicNrqlAlertConditionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckNewRelicNrqlAlertConditionConfigFillOption(rName, ""),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicNrqlAlertConditionExists("newrelic_nrql_alert_condition.foo"),
+					resource.TestCheckResourceAttr(
+						"newrelic_nrql_alert_condition.foo", "fill_option", "none"),
+					resource.TestCheckNoResourceAttr(
+						"newrelic_nrql_alert_condition.foo", "fill_value"),
+				),
+			},
+			{
+				Config: testAccCheckNewRelicNrqlAlertConditionConfigFillOption(rName, `fill_option = "none"`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicNrqlAlertConditionExists("newrelic_nrql_alert_condition.foo"),
+					resource.TestCheckResourceAttr(
+						"newrelic_nrql_alert_condition.foo", "fill_option", "none"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccNewRelicNrqlAlertCondition_fillOptionLastValue(t *testing.T) {
+	rName := acctest.RandString(5)
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNewRelicNrqlAlertConditionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckNewRelicNrqlAlertConditionConfigFillOption(rName, `fill_option = "last_value"`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicNrqlAlertConditionExists("newrelic_nrql_alert_condition.foo"),
+					resource.TestCheckResourceAttr(
+						"newrelic_nrql_alert_condition.foo", "fill_option", "last_value"),
+					resource.TestCheckNoResourceAttr(
+						"newrelic_nrql_alert_condition.foo", "fill_value"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccNewRelicNrqlAlertCondition_fillOptionStatic(t *testing.T) {
+	rName := acctest.RandString(5)
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNewRelicNrqlAlertConditionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckNewRelicNrqlAlertConditionConfigFillOption(rName, `fill_option = "static"
+  fill_value  = 0`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicNrqlAlertConditionExists("newrelic_nrql_alert_condition.foo"),
+					resource.TestCheckResourceAttr(
+						"newrelic_nrql_alert_condition.foo", "fill_option", "static"),
+					resource.TestCheckResourceAttr(
+						"newrelic_nrql_alert_condition.foo", "fill_value", "0"),
+				),
+			},
+			{
+				Config: testAccCheckNewRelicNrqlAlertConditionConfigFillOption(rName, `fill_option = "static"
+  fill_value  = 1.5`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicNrqlAlertConditionExists("newrelic_nrql_alert_condition.foo"),
+					resource.TestCheckResourceAttr(
+						"newrelic_nrql_alert_condition.foo", "fill_value", "1.5"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccNewRelicNrqlAlertCondition_invalidFillValue(t *testing.T) {
+	cases := map[string]string{
+		`fill_option = "static"`: "fill_value is required when fill_option is static",
+		`fill_option = "last_value"
+  fill_value  = 1`: "fill_value can only be set when fill_option is static, got fill_option last_value",
+		`fill_option = "previous"`: "expected fill_option to be one of \\[none last_value static\\]",
+	}
+
+	for fill, expected := range cases {
+		expectedErrorMsg, _ := regexp.Compile(expected)
+		resource.Test(t, resource.TestCase{
+			Providers: testAccProviders,
+			Steps: []resource.TestStep{
+				{
+					Config:      testAccCheckNewRelicNrqlAlertConditionConfigFillOption(acctest.RandString(5), fill),
+					ExpectError: expectedErrorMsg,
+				},
+			},
+		})
+	}
+}
+
+func TestAccNewRelicNrqlAlertCondition_baseline(t *testing.T) {
+	rName := acctest.RandString(5)
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNewRelicNrqlAlertConditionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckNewRelicNrqlAlertConditionConfigBaseline(rName, "upper_only"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicNrqlAlertConditionExists("newrelic_nrql_alert_condition.foo"),
+					resource.TestCheckResourceAttr(
+						"newrelic_nrql_alert_condition.foo", "type", "baseline"),
+					resource.TestCheckResourceAttr(
+						"newrelic_nrql_alert_condition.foo", "baseline_direction", "upper_only"),
+					resource.TestCheckResourceAttr(
+						"newrelic_nrql_alert_condition.foo", "term.0.threshold", "3"),
+				),
+			},
+			{
+				Config: testAccCheckNewRelicNrqlAlertConditionConfigBaseline(rName, "upper_and_lower"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicNrqlAlertConditionExists("newrelic_nrql_alert_condition.foo"),
+					resource.TestCheckResourceAttr(
+						"newrelic_nrql_alert_condition.foo", "baseline_direction", "upper_and_lower"),
+				),
+			},
+			{
+				ResourceName:      "newrelic_nrql_alert_condition.foo",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccNewRelicNrqlAlertCondition_invalidBaseline(t *testing.T) {
+	baseline := `
+  type               = "baseline"
+  baseline_direction = "upper_only"`
+
+	cases := map[string]string{
+		`baseline_direction = "upper_only"`:       "baseline_direction can only be set when type is baseline, got type static",
+		`type = "baseline"`:                       "baseline_direction is required when type is baseline",
+		baseline + "\n  value_function = \"sum\"": "value_function can only be single_value when type is baseline, got value_function sum",
+		baseline:           "term operator must be above when type is baseline, got operator below",
+		`type = "outlier"`: "expected type to be one of \\[static baseline\\]",
+	}
+
+	for fields, expected := range cases {
+		expectedErrorMsg, _ := regexp.Compile(expected)
+		resource.Test(t, resource.TestCase{
+			Providers: testAccProviders,
+			Steps: []resource.TestStep{
+				{
+					Config:      testAccCheckNewRelicNrqlAlertConditionConfigFillOption(acctest.RandString(5), fields),
+					ExpectError: expectedErrorMsg,
+				},
+			},
+		})
+	}
+}
+
+func TestReadNrqlAlertConditionStruct_baseline(t *testing.T) {
+	condition := &alertNrqlCondition{
+		Type:              "baseline",
+		BaselineDirection: "lower_only",
+		Terms: []newrelic.AlertConditionTerm{
+			{Duration: 5, Operator: "above", Priority: "critical", Threshold: 3, TimeFunction: "all"},
+		},
+	}
+
+	r := resourceNewRelicNrqlAlertCondition()
+	d := r.TestResourceData()
+	d.SetId("123:456")
+
+	if err := readNrqlAlertConditionStruct(condition, d); err != nil {
+		t.Fatal(err)
+	}
+
+	if d.Get("type").(string) != "baseline" || d.Get("baseline_direction").(string) != "lower_only" {
+		t.Fatalf("expected a lower_only baseline condition, got type %q and baseline_direction %q", d.Get("type"), d.Get("baseline_direction"))
+	}
+
+	// The API doesn't return a value function for baseline conditions.
+	if valueFunction := d.Get("value_function").(string); valueFunction != "single_value" {
+		t.Fatalf("expected value_function to default to single_value, got %q", valueFunction)
+	}
+
+	built := buildNrqlAlertConditionStruct(d)
+	if built.Type != "baseline" || built.BaselineDirection != "lower_only" || built.ValueFunction != "" {
+		t.Fatalf("expected a lower_only baseline condition without a value function, got %#v", built)
+	}
+}
+
+func TestAccNewRelicNrqlAlertCondition_violationTimeLimitSeconds(t *testing.T) {
+	rName := acctest.RandString(5)
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNewRelicNrqlAlertConditionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckNewRelicNrqlAlertConditionConfigFillOption(rName, "violation_time_limit_seconds = 3600"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicNrqlAlertConditionExists("newrelic_nrql_alert_condition.foo"),
+					resource.TestCheckResourceAttr(
+						"newrelic_nrql_alert_condition.foo", "violation_time_limit_seconds", "3600"),
+				),
+			},
+			{
+				Config: testAccCheckNewRelicNrqlAlertConditionConfigFillOption(rName, "violation_time_limit_seconds = 86400"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicNrqlAlertConditionExists("newrelic_nrql_alert_condition.foo"),
+					resource.TestCheckResourceAttr(
+						"newrelic_nrql_alert_condition.foo", "violation_time_limit_seconds", "86400"),
+				),
+			},
+			{
+				ResourceName:      "newrelic_nrql_alert_condition.foo",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccNewRelicNrqlAlertCondition_invalidViolationTimeLimitSeconds(t *testing.T) {
+	expectedErrorMsg, _ := regexp.Compile("expected violation_time_limit_seconds to be one of \\[3600 7200 14400 28800 43200 86400\\], got 60")
+	resource.Test(t, resource.TestCase{
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCheckNewRelicNrqlAlertConditionConfigFillOption(acctest.RandString(5), "violation_time_limit_seconds = 60"),
+				ExpectError: expectedErrorMsg,
+			},
+		},
+	})
+}
+
+func TestReadNrqlAlertConditionStruct_violationTimeLimitSeconds(t *testing.T) {
+	r := resourceNewRelicNrqlAlertCondition()
+	d := r.TestResourceData()
+	d.SetId("123:456")
+
+	// Without a time limit, the API's default is left in place.
+	if built := buildNrqlAlertConditionStruct(d); built.ViolationTimeLimitSeconds != 0 {
+		t.Fatalf("expected no violation time limit to be sent, got %d", built.ViolationTimeLimitSeconds)
+	}
+
+	condition := &alertNrqlCondition{ViolationTimeLimitSeconds: 28800}
+	if err := readNrqlAlertConditionStruct(condition, d); err != nil {
+		t.Fatal(err)
+	}
+
+	if limit := d.Get("violation_time_limit_seconds").(int); limit != 28800 {
+		t.Fatalf("expected violation_time_limit_seconds to be 28800, got %d", limit)
+	}
+
+	if built := buildNrqlAlertConditionStruct(d); built.ViolationTimeLimitSeconds != 28800 {
+		t.Fatalf("expected the violation time limit to be sent, got %d", built.ViolationTimeLimitSeconds)
+	}
+}
+
+func TestAccNewRelicNrqlAlertCondition_aggregation(t *testing.T) {
+	rName := acctest.RandString(5)
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNewRelicNrqlAlertConditionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckNewRelicNrqlAlertConditionConfigFillOption(rName, `aggregation_window = 120
+  aggregation_method = "event_flow"
+  aggregation_delay  = 60`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicNrqlAlertConditionExists("newrelic_nrql_alert_condition.foo"),
+					resource.TestCheckResourceAttr(
+						"newrelic_nrql_alert_condition.foo", "aggregation_window", "120"),
+					resource.TestCheckResourceAttr(
+						"newrelic_nrql_alert_condition.foo", "aggregation_method", "event_flow"),
+					resource.TestCheckResourceAttr(
+						"newrelic_nrql_alert_condition.foo", "aggregation_delay", "60"),
+				),
+			},
+			{
+				Config: testAccCheckNewRelicNrqlAlertConditionConfigFillOption(rName, `aggregation_window = 60
+  aggregation_method = "event_timer"
+  aggregation_timer  = 30`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicNrqlAlertConditionExists("newrelic_nrql_alert_condition.foo"),
+					resource.TestCheckResourceAttr(
+						"newrelic_nrql_alert_condition.foo", "aggregation_method", "event_timer"),
+					resource.TestCheckResourceAttr(
+						"newrelic_nrql_alert_condition.foo", "aggregation_timer", "30"),
+				),
+			},
+			{
+				ResourceName:      "newrelic_nrql_alert_condition.foo",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccNewRelicNrqlAlertCondition_invalidAggregation(t *testing.T) {
+	cases := map[string]string{
+		`aggregation_method = "cadence"
+  aggregation_timer  = 30`: "aggregation_timer can only be set when aggregation_method is event_timer, got aggregation_method cadence",
+		`aggregation_method = "event_timer"
+  aggregation_delay  = 60`: "aggregation_delay can't be set when aggregation_method is event_timer, set aggregation_timer instead",
+		`aggregation_method = "sliding"`: "expected aggregation_method to be one of \\[event_flow event_timer cadence\\]",
+		`aggregation_window = 10`:        "expected aggregation_window to be in the range \\(30 - 7200\\), got 10",
+	}
+
+	for fields, expected := range cases {
+		expectedErrorMsg, _ := regexp.Compile(expected)
+		resource.Test(t, resource.TestCase{
+			Providers: testAccProviders,
+			Steps: []resource.TestStep{
+				{
+					Config:      testAccCheckNewRelicNrqlAlertConditionConfigFillOption(acctest.RandString(5), fields),
+					ExpectError: expectedErrorMsg,
+				},
+			},
+		})
+	}
+}
+
+func TestReadNrqlAlertConditionStruct_aggregation(t *testing.T) {
+	r := resourceNewRelicNrqlAlertCondition()
+	d := r.TestResourceData()
+	d.SetId("123:456")
+
+	// Without aggregation attributes, the API's defaults are left in place.
+	if signal := buildNrqlAlertConditionStruct(d).Signal; signal.AggregationWindow != 0 || signal.AggregationMethod != "" || signal.AggregationDelay != nil || signal.AggregationTimer != 0 {
+		t.Fatalf("expected no aggregation attributes to be sent, got %#v", signal)
+	}
+
+	delay := 0
+	condition := &alertNrqlCondition{Signal: &alertNrqlSignal{AggregationWindow: 60, AggregationMethod: "CADENCE", AggregationDelay: &delay}}
+	if err := readNrqlAlertConditionStruct(condition, d); err != nil {
+		t.Fatal(err)
+	}
+
+	if method := d.Get("aggregation_method").(string); method != "cadence" || d.Get("aggregation_window").(int) != 60 {
+		t.Fatalf("expected a 60 second cadence window, got %s and %d", method, d.Get("aggregation_window").(int))
+	}
+
+	// A delay of 0 is sent rather than left to the API's default.
+	signal := buildNrqlAlertConditionStruct(d).Signal
+	if signal.AggregationMethod != "CADENCE" || signal.AggregationDelay == nil || *signal.AggregationDelay != 0 {
+		t.Fatalf("expected the cadence method to be sent with a delay of 0, got %#v", signal)
+	}
+
+	// Only the timer is sent with the event_timer method.
+	d.Set("aggregation_method", "event_timer")
+	d.Set("aggregation_timer", 30)
+
+	signal = buildNrqlAlertConditionStruct(d).Signal
+	if signal.AggregationMethod != "EVENT_TIMER" || signal.AggregationTimer != 30 || signal.AggregationDelay != nil {
+		t.Fatalf("expected the event_timer method to be sent with only its timer, got %#v", signal)
+	}
+}
+
+func TestNrqlAlertConditionSinceValue(t *testing.T) {
+	validate := resourceNewRelicNrqlAlertCondition().Schema["nrql"].Elem.(*schema.Resource).Schema["since_value"].ValidateFunc
+
+	for _, v := range []string{"1", "5", "20"} {
+		if _, es := validate(v, "nrql.0.since_value"); len(es) > 0 {
+			t.Errorf("expected since_value %s to be valid, got %v", v, es)
+		}
+	}
+
+	for _, v := range []string{"0", "21", "5m"} {
+		if _, es := validate(v, "nrql.0.since_value"); len(es) == 0 {
+			t.Errorf("expected since_value %s to be invalid", v)
+		}
+	}
+}
+
+func TestAccNewRelicNrqlAlertCondition_AccountID(t *testing.T) {
+	accountID := testAccAccountID(t)
+	rName := acctest.RandString(5)
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNewRelicNrqlAlertConditionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckNewRelicNrqlAlertConditionConfigAccountID(rName, accountID),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicNrqlAlertConditionExists("newrelic_nrql_alert_condition.foo"),
+					resource.TestCheckResourceAttr(
+						"newrelic_nrql_alert_condition.foo", "nrql.0.account_id", accountID),
+				),
+			},
+			{
+				ResourceName:      "newrelic_nrql_alert_condition.foo",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccNewRelicNrqlAlertCondition_invalidAccountID(t *testing.T) {
+	expectedErrorMsg, _ := regexp.Compile("expected nrql.0.account_id to be at least \\(1\\), got 0")
+	resource.Test(t, resource.TestCase{
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCheckNewRelicNrqlAlertConditionConfigAccountID(acctest.RandString(5), "0"),
+				ExpectError: expectedErrorMsg,
+			},
+		},
+	})
+}
+
+func TestAccNewRelicNrqlAlertCondition_validateNrql(t *testing.T) {
+	accountID := testAccAccountID(t)
+	expectedErrorMsg, _ := regexp.Compile("nrql.0.query \"SELECT count\\(\\*\\) FROM\" is not valid")
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCheckNewRelicNrqlAlertConditionConfigValidateNrql(acctest.RandString(5), accountID, "SELECT count(*) FROM"),
+				PlanOnly:    true,
+				ExpectError: expectedErrorMsg,
+			},
+		},
+	})
+}
+
+func TestResourceNewRelicNrqlAlertConditionUpdate_enabledOnly(t *testing.T) {
+	condition := map[string]interface{}{
+		"id":             456.0,
+		"type":           "static",
+		"name":           "foo",
+		"enabled":        false,
+		"runbook_url":    "",
+		"value_function": "single_value",
+		"nrql":           map[string]interface{}{"query": "SELECT count(*) FROM Transaction", "since_value": "5"},
+		"signal":         map[string]interface{}{"fill_option": "none"},
+		"terms": []interface{}{
+			map[string]interface{}{"duration": "5", "operator": "above", "priority": "critical", "threshold": "1", "time_function": "all"},
+		},
+	}
+
+	testConditionEnabledToggle(t, resourceNewRelicNrqlAlertCondition(), "nrql_conditions", "nrql_condition", condition, map[string]interface{}{
+		"policy_id": 123,
+		"name":      "foo",
+		"enabled":   true,
+		"nrql": []interface{}{
+			map[string]interface{}{"query": "SELECT count(*) FROM Transaction", "since_value": "5"},
+		},
+		"term": []interface{}{
+			map[string]interface{}{"duration": 5, "operator": "above", "priority": "critical", "threshold": 1.0, "time_function": "all"},
+		},
+	})
+}
+
+func TestResourceNewRelicNrqlAlertCondition_immutableAttributes(t *testing.T) {
+	testResourceAttributesForceNew(t, resourceNewRelicNrqlAlertCondition(), "policy_id", "policy_name")
+}
+
+func TestResourceNewRelicNrqlAlertConditionRead_notFound(t *testing.T) {
+	testResourceReadNotFound(t, resourceNewRelicNrqlAlertCondition(), "123:456")
+}
 
 func testAccCheckNewRelicNrqlAlertConditionDestroy(s *terraform.State) error {
 	client := testAccProvider.Meta().(*ProviderConfig).Client
@@ -199,4 +935,187 @@ resource "newrelic_nrql_alert_condition" "foo" {
 `, rName)
 }
 
-// TODO: const testAccCheckNewRelicNrqlAlertConditionConfigMulti = `
+func testAccCheckNewRelicNrqlAlertConditionConfigMulti(rName string, secondPriority string) string {
+	return fmt.Sprintf(`
+
+resource "newrelic_alert_policy" "foo" {
+  name = "tf-test-%[1]s"
+}
+
+resource "newrelic_nrql_alert_condition" "foo" {
+  policy_id = "${newrelic_alert_policy.foo.id}"
+
+  name            = "tf-test-%[1]s"
+  enabled         = false
+
+  term {
+    duration      = 5
+    operator      = "above"
+    priority      = "critical"
+    threshold     = "10"
+    time_function = "all"
+  }
+  term {
+    duration      = 120
+    operator      = "above"
+    priority      = "%[2]s"
+    threshold     = "5"
+    time_function = "all"
+  }
+  nrql {
+    query         = "SELECT uniqueCount(hostname) FROM ComputeSample"
+    since_value   = "5"
+  }
+  value_function  = "single_value"
+}
+`, rName, secondPriority)
+}
+
+func testAccCheckNewRelicNrqlAlertConditionConfigValueFunction(rName string, valueFunction string) string {
+	return fmt.Sprintf(`
+
+resource "newrelic_alert_policy" "foo" {
+  name = "tf-test-%[1]s"
+}
+
+resource "newrelic_nrql_alert_condition" "foo" {
+  policy_id = "${newrelic_alert_policy.foo.id}"
+
+  name            = "tf-test-%[1]s"
+  enabled         = false
+
+  term {
+    duration      = 5
+    operator      = "above"
+    priority      = "critical"
+    threshold     = "10"
+    time_function = "all"
+  }
+  nrql {
+    query         = "SELECT count(*) FROM TransactionError"
+    since_value   = "5"
+  }
+  %[2]s
+}
+`, rName, valueFunction)
+}
+
+func testAccCheckNewRelicNrqlAlertConditionConfigFillOption(rName string, fill string) string {
+	return fmt.Sprintf(`
+
+resource "newrelic_alert_policy" "foo" {
+  name = "tf-test-%[1]s"
+}
+
+resource "newrelic_nrql_alert_condition" "foo" {
+  policy_id = "${newrelic_alert_policy.foo.id}"
+
+  name            = "tf-test-%[1]s"
+  enabled         = false
+
+  term {
+    duration      = 5
+    operator      = "below"
+    priority      = "critical"
+    threshold     = "1"
+    time_function = "all"
+  }
+  nrql {
+    query         = "SELECT count(*) FROM Transaction WHERE appName = 'low-traffic'"
+    since_value   = "5"
+  }
+  %[2]s
+}
+`, rName, fill)
+}
+
+func testAccCheckNewRelicNrqlAlertConditionConfigBaseline(rName string, direction string) string {
+	return fmt.Sprintf(`
+
+resource "newrelic_alert_policy" "foo" {
+  name = "tf-test-%[1]s"
+}
+
+resource "newrelic_nrql_alert_condition" "foo" {
+  policy_id = "${newrelic_alert_policy.foo.id}"
+
+  name               = "tf-test-%[1]s"
+  enabled            = false
+  type               = "baseline"
+  baseline_direction = "%[2]s"
+
+  term {
+    duration      = 5
+    operator      = "above"
+    priority      = "critical"
+    threshold     = "3"
+    time_function = "all"
+  }
+  nrql {
+    query         = "SELECT count(*) FROM Transaction"
+    since_value   = "3"
+  }
+}
+`, rName, direction)
+}
+
+func testAccCheckNewRelicNrqlAlertConditionConfigAccountID(rName string, accountID string) string {
+	return fmt.Sprintf(`
+
+resource "newrelic_alert_policy" "foo" {
+  name = "tf-test-%[1]s"
+}
+
+resource "newrelic_nrql_alert_condition" "foo" {
+  policy_id = "${newrelic_alert_policy.foo.id}"
+
+  name            = "tf-test-%[1]s"
+  enabled         = false
+
+  term {
+    duration      = 5
+    operator      = "above"
+    priority      = "critical"
+    threshold     = "10"
+    time_function = "all"
+  }
+  nrql {
+    query         = "SELECT count(*) FROM TransactionError"
+    since_value   = "5"
+    account_id    = %[2]s
+  }
+}
+`, rName, accountID)
+}
+
+func testAccCheckNewRelicNrqlAlertConditionConfigValidateNrql(rName string, accountID string, query string) string {
+	return fmt.Sprintf(`
+provider "newrelic" {
+  account_id    = %[2]s
+  validate_nrql = true
+}
+
+resource "newrelic_alert_policy" "foo" {
+  name = "tf-test-%[1]s"
+}
+
+resource "newrelic_nrql_alert_condition" "foo" {
+  policy_id = "${newrelic_alert_policy.foo.id}"
+
+  name            = "tf-test-%[1]s"
+  enabled         = false
+
+  term {
+    duration      = 5
+    operator      = "above"
+    priority      = "critical"
+    threshold     = "10"
+    time_function = "all"
+  }
+  nrql {
+    query         = "%[3]s"
+    since_value   = "5"
+  }
+}
+`, rName, accountID, query)
+}
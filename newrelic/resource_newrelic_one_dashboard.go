@@ -0,0 +1,409 @@
+package newrelic
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	newrelic "github.com/paultyng/go-newrelic/v4/api"
+)
+
+func resourceNewRelicOneDashboard() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceNewRelicOneDashboardCreate,
+		Read:   resourceNewRelicOneDashboardRead,
+		Update: resourceNewRelicOneDashboardUpdate,
+		Delete: resourceNewRelicOneDashboardDelete,
+		Importer: &schema.ResourceImporter{
+			State: importOneDashboard,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"api_key": apiKeySchema(),
+			"account_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"permissions": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "PUBLIC_READ_WRITE",
+				ValidateFunc: validation.StringInSlice([]string{"PRIVATE", "PUBLIC_READ_ONLY", "PUBLIC_READ_WRITE"}, false),
+			},
+			"tags":     entityTagsSchema(),
+			"tags_all": entityTagsAllSchema(),
+			"guid": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"permalink": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			// A list rather than a set, so that pages keep the order they're
+			// declared in.
+			"page": {
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"guid": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"widget": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem:     oneDashboardWidgetSchema(),
+						},
+					},
+				},
+			},
+		},
+		CustomizeDiff: customizeDiffOneDashboard,
+	}
+}
+
+func oneDashboardWidgetSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"title": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"visualization": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"row": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"column": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntBetween(1, 12),
+			},
+			"width": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      4,
+				ValidateFunc: validation.IntBetween(1, 12),
+			},
+			"height": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      3,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"nrql_query": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						// Computed, since queries run against the account of
+						// the dashboard unless another one is set.
+						"account_id": {
+							Type:     schema.TypeInt,
+							Optional: true,
+							Computed: true,
+						},
+						"query": {
+							Type:      schema.TypeString,
+							Required:  true,
+							StateFunc: trimDashboardWidgetString,
+						},
+					},
+				},
+			},
+			"text": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				StateFunc: trimDashboardWidgetString,
+			},
+		},
+	}
+}
+
+func customizeDiffOneDashboard(d *schema.ResourceDiff, meta interface{}) error {
+	if err := validateOneDashboardWidgets(d, meta); err != nil {
+		return err
+	}
+
+	return customizeDiffEntityTags(d, meta)
+}
+
+// validateOneDashboardWidgets requires text on markdown widgets and NRQL
+// queries on the others.
+func validateOneDashboardWidgets(d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("page") {
+		return nil
+	}
+
+	for _, p := range d.Get("page").([]interface{}) {
+		page := p.(map[string]interface{})
+
+		for _, w := range page["widget"].([]interface{}) {
+			widget := w.(map[string]interface{})
+			title := widget["title"].(string)
+			queries := widget["nrql_query"].([]interface{})
+
+			if widget["visualization"].(string) == "viz.markdown" {
+				if widget["text"].(string) == "" || len(queries) > 0 {
+					return fmt.Errorf("page %q, widget %q: markdown widgets require text and no nrql_query", page["name"], title)
+				}
+
+				continue
+			}
+
+			if len(queries) == 0 {
+				return fmt.Errorf("page %q, widget %q: nrql_query is required for %s widgets", page["name"], title, widget["visualization"])
+			}
+
+			if widget["text"].(string) != "" {
+				return fmt.Errorf("page %q, widget %q: text is only supported on viz.markdown widgets", page["name"], title)
+			}
+		}
+	}
+
+	return nil
+}
+
+func expandOneDashboard(d *schema.ResourceData) *oneDashboardInput {
+	accountID := d.Get("account_id").(int)
+
+	dashboard := oneDashboardInput{
+		Name:        d.Get("name").(string),
+		Description: d.Get("description").(string),
+		Permissions: d.Get("permissions").(string),
+		Pages:       []oneDashboardPage{},
+	}
+
+	for _, p := range d.Get("page").([]interface{}) {
+		page := p.(map[string]interface{})
+
+		// The GUID of an existing page is sent so that the page is updated
+		// rather than replaced by a new one.
+		dashboardPage := oneDashboardPage{
+			GUID:        page["guid"].(string),
+			Name:        page["name"].(string),
+			Description: page["description"].(string),
+			Widgets:     []oneDashboardWidget{},
+		}
+
+		for _, w := range page["widget"].([]interface{}) {
+			widget := w.(map[string]interface{})
+
+			configuration := oneDashboardWidgetConfiguration{
+				Text: trimDashboardWidgetString(widget["text"]),
+			}
+
+			for _, q := range widget["nrql_query"].([]interface{}) {
+				query := q.(map[string]interface{})
+
+				queryAccountID := query["account_id"].(int)
+				if queryAccountID == 0 {
+					queryAccountID = accountID
+				}
+
+				configuration.NrqlQueries = append(configuration.NrqlQueries, oneDashboardNrqlQuery{
+					AccountID: queryAccountID,
+					Query:     trimDashboardWidgetString(query["query"]),
+				})
+			}
+
+			dashboardPage.Widgets = append(dashboardPage.Widgets, oneDashboardWidget{
+				Title: widget["title"].(string),
+				Layout: oneDashboardWidgetLayout{
+					Row:    widget["row"].(int),
+					Column: widget["column"].(int),
+					Width:  widget["width"].(int),
+					Height: widget["height"].(int),
+				},
+				Visualization:    oneDashboardWidgetVisualization{ID: widget["visualization"].(string)},
+				RawConfiguration: configuration,
+			})
+		}
+
+		dashboard.Pages = append(dashboard.Pages, dashboardPage)
+	}
+
+	return &dashboard
+}
+
+// flattenOneDashboard sets the dashboard's pages and widgets in the order
+// they're returned in, which is the order they were sent in.
+func flattenOneDashboard(dashboard *oneDashboard, d *schema.ResourceData) error {
+	d.Set("guid", dashboard.GUID)
+	d.Set("account_id", dashboard.AccountID)
+	d.Set("name", dashboard.Name)
+	d.Set("description", dashboard.Description)
+	d.Set("permissions", dashboard.Permissions)
+	d.Set("permalink", dashboard.Permalink)
+
+	pages := make([]interface{}, 0, len(dashboard.Pages))
+	for _, page := range dashboard.Pages {
+		widgets := make([]interface{}, 0, len(page.Widgets))
+		for _, widget := range page.Widgets {
+			queries := make([]interface{}, 0, len(widget.RawConfiguration.NrqlQueries))
+			for _, query := range widget.RawConfiguration.NrqlQueries {
+				queries = append(queries, map[string]interface{}{
+					"account_id": query.AccountID,
+					"query":      query.Query,
+				})
+			}
+
+			widgets = append(widgets, map[string]interface{}{
+				"title":         widget.Title,
+				"visualization": widget.Visualization.ID,
+				"row":           widget.Layout.Row,
+				"column":        widget.Layout.Column,
+				"width":         widget.Layout.Width,
+				"height":        widget.Layout.Height,
+				"nrql_query":    queries,
+				"text":          widget.RawConfiguration.Text,
+			})
+		}
+
+		pages = append(pages, map[string]interface{}{
+			"guid":        page.GUID,
+			"name":        page.Name,
+			"description": page.Description,
+			"widget":      widgets,
+		})
+	}
+
+	return d.Set("page", pages)
+}
+
+// importOneDashboard imports a dashboard by its entity GUID, which also
+// identifies its account.
+func importOneDashboard(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if !isEntityGUID(d.Id()) {
+		return nil, fmt.Errorf("Error importing New Relic One dashboard %q, expected the dashboard GUID", d.Id())
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceNewRelicOneDashboardCreate(d *schema.ResourceData, meta interface{}) error {
+	client, err := meta.(*ProviderConfig).clientFor(d)
+	if err != nil {
+		return err
+	}
+
+	dashboard := expandOneDashboard(d)
+
+	log.Printf("[INFO] Creating New Relic One dashboard %s", dashboard.Name)
+
+	guid, err := createOneDashboard(client, d.Get("account_id").(int), *dashboard)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(guid)
+
+	// New dashboards are only found by their GUID once they're indexed as
+	// entities.
+	err = waitForCreated(meta.(*ProviderConfig).stopContext(), d.Timeout(schema.TimeoutCreate), func() error {
+		_, err := getOneDashboard(client, guid)
+		if err == errEntityNotFound {
+			return newrelic.ErrNotFound
+		}
+
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("Error waiting for New Relic One dashboard %s to be created: %s", guid, err)
+	}
+
+	if err := applyEntityTags(meta.(*ProviderConfig).stopContext(), client, guid, d); err != nil {
+		return err
+	}
+
+	return resourceNewRelicOneDashboardRead(d, meta)
+}
+
+func resourceNewRelicOneDashboardRead(d *schema.ResourceData, meta interface{}) error {
+	client, err := meta.(*ProviderConfig).clientFor(d)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Reading New Relic One dashboard %s", d.Id())
+
+	dashboard, err := getOneDashboard(client, d.Id())
+	if err != nil {
+		if err == errEntityNotFound {
+			d.SetId("")
+			return nil
+		}
+
+		return err
+	}
+
+	if err := flattenOneDashboard(dashboard, d); err != nil {
+		return err
+	}
+
+	return readEntityTags(client, d.Id(), d)
+}
+
+func resourceNewRelicOneDashboardUpdate(d *schema.ResourceData, meta interface{}) error {
+	client, err := meta.(*ProviderConfig).clientFor(d)
+	if err != nil {
+		return err
+	}
+
+	dashboard := expandOneDashboard(d)
+
+	log.Printf("[INFO] Updating New Relic One dashboard %s", d.Id())
+
+	if err := updateOneDashboard(client, d.Id(), *dashboard); err != nil {
+		return err
+	}
+
+	if err := applyEntityTags(meta.(*ProviderConfig).stopContext(), client, d.Id(), d); err != nil {
+		return err
+	}
+
+	return resourceNewRelicOneDashboardRead(d, meta)
+}
+
+func resourceNewRelicOneDashboardDelete(d *schema.ResourceData, meta interface{}) error {
+	client, err := meta.(*ProviderConfig).clientFor(d)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Deleting New Relic One dashboard %s", d.Id())
+
+	if err := deleteOneDashboard(client, d.Id()); err != nil {
+		return err
+	}
+
+	return nil
+}
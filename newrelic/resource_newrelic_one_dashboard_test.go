@@ -0,0 +1,309 @@
+package newrelic
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/config"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccNewRelicOneDashboard_Basic(t *testing.T) {
+	accountID := testAccAccountID(t)
+	resourceName := "newrelic_one_dashboard.foo"
+	rName := acctest.RandString(5)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNewRelicOneDashboardDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckNewRelicOneDashboardConfig(accountID, rName, "Overview", "Errors"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicOneDashboardExists(resourceName),
+					resource.TestCheckResourceAttrSet(resourceName, "permalink"),
+					resource.TestCheckResourceAttr(resourceName, "page.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "page.0.name", "Overview"),
+					resource.TestCheckResourceAttr(resourceName, "page.1.name", "Errors"),
+					resource.TestCheckResourceAttr(resourceName, "page.0.widget.0.nrql_query.0.account_id", accountID),
+				),
+			},
+			// Reordering pages keeps the declared order.
+			{
+				Config: testAccCheckNewRelicOneDashboardConfig(accountID, rName, "Errors", "Overview"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicOneDashboardExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "page.0.name", "Errors"),
+					resource.TestCheckResourceAttr(resourceName, "page.1.name", "Overview"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestResourceNewRelicOneDashboard_pages(t *testing.T) {
+	guid := "MXxWSVp8REFTSEJPQVJEfGRhLTE"
+
+	var created map[string]interface{}
+	reads := 0
+
+	meta, closeServer := testNotificationsProviderConfig(t, func(query string, variables map[string]interface{}) string {
+		if strings.Contains(query, "dashboardCreate") {
+			created = variables["dashboard"].(map[string]interface{})
+			return `{"data":{"dashboardCreate":{"entityResult":{"guid":"` + guid + `"},"errors":[]}}}`
+		}
+
+		// The dashboard isn't found until it's indexed.
+		reads++
+		if reads == 1 {
+			return `{"data":{"actor":{"entity":null}}}`
+		}
+
+		pages := created["pages"].([]interface{})
+		for i, p := range pages {
+			p.(map[string]interface{})["guid"] = fmt.Sprintf("page-%d", i+1)
+		}
+
+		dashboard := map[string]interface{}{
+			"guid":        guid,
+			"accountId":   1,
+			"name":        created["name"],
+			"description": created["description"],
+			"permissions": created["permissions"],
+			"permalink":   "https://one.newrelic.com/redirect/entity/" + guid,
+			"pages":       pages,
+		}
+
+		body, _ := json.Marshal(map[string]interface{}{"data": map[string]interface{}{"actor": map[string]interface{}{"entity": dashboard}}})
+		return string(body)
+	})
+	defer closeServer()
+
+	r := resourceNewRelicOneDashboard()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"account_id": 1,
+		"name":       "foo",
+		"page": []interface{}{
+			map[string]interface{}{
+				"name": "Overview",
+				"widget": []interface{}{
+					map[string]interface{}{
+						"title":         "Throughput",
+						"visualization": "viz.line",
+						"row":           1,
+						"column":        1,
+						"nrql_query": []interface{}{
+							map[string]interface{}{"query": "SELECT count(*) FROM Transaction TIMESERIES\n"},
+							map[string]interface{}{"account_id": 2, "query": "SELECT count(*) FROM Transaction TIMESERIES"},
+						},
+					},
+				},
+			},
+			map[string]interface{}{
+				"name": "About",
+				"widget": []interface{}{
+					map[string]interface{}{
+						"title":         "Notes",
+						"visualization": "viz.markdown",
+						"row":           1,
+						"column":        1,
+						"text":          "# Notes",
+					},
+				},
+			},
+		},
+	})
+
+	defer testFastCreatePolling()()
+
+	if err := r.Create(d, meta); err != nil {
+		t.Fatal(err)
+	}
+
+	if d.Id() != guid {
+		t.Fatalf("expected ID %s, got %s", guid, d.Id())
+	}
+
+	// Queries without an account_id run against the dashboard's account.
+	pages := created["pages"].([]interface{})
+	widget := pages[0].(map[string]interface{})["widgets"].([]interface{})[0].(map[string]interface{})
+	queries := widget["rawConfiguration"].(map[string]interface{})["nrqlQueries"].([]interface{})
+	if accountID := queries[0].(map[string]interface{})["accountId"]; accountID != 1.0 {
+		t.Fatalf("expected the first query to run against account 1, got %v", accountID)
+	}
+
+	if accountID := queries[1].(map[string]interface{})["accountId"]; accountID != 2.0 {
+		t.Fatalf("expected the second query to run against account 2, got %v", accountID)
+	}
+
+	for i, name := range []string{"Overview", "About"} {
+		if actual := d.Get(fmt.Sprintf("page.%d.name", i)).(string); actual != name {
+			t.Fatalf("expected page %d to be %s, got %s", i, name, actual)
+		}
+	}
+
+	if query := d.Get("page.0.widget.0.nrql_query.0.query").(string); query != "SELECT count(*) FROM Transaction TIMESERIES" {
+		t.Fatalf("expected the query to be trimmed, got %q", query)
+	}
+
+	if text := d.Get("page.1.widget.0.text").(string); text != "# Notes" {
+		t.Fatalf("expected the markdown text to be read back, got %q", text)
+	}
+
+	// Updates send the GUIDs of the existing pages, so pages are updated in
+	// place.
+	dashboard := expandOneDashboard(d)
+	if dashboard.Pages[0].GUID != "page-1" || dashboard.Pages[1].GUID != "page-2" {
+		t.Fatalf("expected the page GUIDs to be sent, got %#v", dashboard.Pages)
+	}
+}
+
+func TestValidateOneDashboardWidgets(t *testing.T) {
+	r := resourceNewRelicOneDashboard()
+
+	diff := func(widget map[string]interface{}) error {
+		widget["title"] = "foo"
+		widget["row"] = 1
+		widget["column"] = 1
+
+		rc, err := config.NewRawConfig(map[string]interface{}{
+			"account_id": 1,
+			"name":       "foo",
+			"page": []interface{}{
+				map[string]interface{}{
+					"name":   "bar",
+					"widget": []interface{}{widget},
+				},
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = r.Diff(nil, terraform.NewResourceConfig(rc), &ProviderConfig{})
+		return err
+	}
+
+	query := []interface{}{map[string]interface{}{"query": "SELECT count(*) FROM Transaction"}}
+
+	for _, c := range []struct {
+		widget   map[string]interface{}
+		expected string
+	}{
+		{map[string]interface{}{"visualization": "viz.line", "nrql_query": query}, ""},
+		{map[string]interface{}{"visualization": "viz.markdown", "text": "# foo"}, ""},
+		{map[string]interface{}{"visualization": "viz.line"}, `page "bar", widget "foo": nrql_query is required for viz.line widgets`},
+		{map[string]interface{}{"visualization": "viz.line", "nrql_query": query, "text": "# foo"}, `page "bar", widget "foo": text is only supported on viz.markdown widgets`},
+		{map[string]interface{}{"visualization": "viz.markdown"}, `page "bar", widget "foo": markdown widgets require text and no nrql_query`},
+		{map[string]interface{}{"visualization": "viz.markdown", "text": "# foo", "nrql_query": query}, `page "bar", widget "foo": markdown widgets require text and no nrql_query`},
+	} {
+		err := diff(c.widget)
+		if c.expected == "" && err != nil {
+			t.Errorf("expected no error for %v, got %q", c.widget, err)
+		}
+
+		if c.expected != "" && (err == nil || err.Error() != c.expected) {
+			t.Errorf("expected %q for %v, got %q", c.expected, c.widget, err)
+		}
+	}
+}
+
+func TestResourceNewRelicOneDashboard_immutableAttributes(t *testing.T) {
+	testResourceAttributesForceNew(t, resourceNewRelicOneDashboard(), "account_id")
+}
+
+func TestResourceNewRelicOneDashboardImport_invalidID(t *testing.T) {
+	d := resourceNewRelicOneDashboard().TestResourceData()
+	d.SetId("123")
+
+	if _, err := importOneDashboard(d, &ProviderConfig{}); err == nil {
+		t.Fatal("expected an error importing a dashboard by a numeric ID")
+	}
+}
+
+func testAccCheckNewRelicOneDashboardDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*ProviderConfig).Client
+	for _, r := range s.RootModule().Resources {
+		if r.Type != "newrelic_one_dashboard" {
+			continue
+		}
+
+		_, err := getOneDashboard(client, r.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("New Relic One dashboard still exists")
+		}
+	}
+	return nil
+}
+
+func testAccCheckNewRelicOneDashboardExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No dashboard GUID is set")
+		}
+
+		client := testAccProvider.Meta().(*ProviderConfig).Client
+
+		found, err := getOneDashboard(client, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		if found.GUID != rs.Primary.ID {
+			return fmt.Errorf("New Relic One dashboard not found: %v - %v", rs.Primary.ID, found)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckNewRelicOneDashboardConfig(accountID string, rName string, firstPage string, secondPage string) string {
+	return fmt.Sprintf(`
+resource "newrelic_one_dashboard" "foo" {
+  account_id = %[1]s
+  name       = "tf-test-%[2]s"
+
+  page {
+    name = "%[3]s"
+
+    widget {
+      title         = "Throughput"
+      visualization = "viz.line"
+      row           = 1
+      column        = 1
+
+      nrql_query {
+        query = "SELECT count(*) FROM Transaction TIMESERIES"
+      }
+    }
+  }
+
+  page {
+    name = "%[4]s"
+
+    widget {
+      title         = "Notes"
+      visualization = "viz.markdown"
+      row           = 1
+      column        = 1
+      text          = "# %[4]s"
+    }
+  }
+}
+`, accountID, rName, firstPage, secondPage)
+}
@@ -1,7 +1,9 @@
 package newrelic
 
 import (
+	"fmt"
 	"log"
+	"time"
 
 	"github.com/hashicorp/terraform/helper/schema"
 	newrelic "github.com/paultyng/go-newrelic/v4/api"
@@ -17,12 +19,13 @@ func resourceNewRelicSyntheticsAlertCondition() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(1 * time.Minute),
+		},
 		Schema: map[string]*schema.Schema{
-			"policy_id": {
-				Type:     schema.TypeInt,
-				Required: true,
-				ForceNew: true,
-			},
+			"api_key":     apiKeySchema(),
+			"policy_id":   alertConditionPolicyIDSchema(),
+			"policy_name": alertConditionPolicyNameSchema(),
 			"name": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -31,6 +34,7 @@ func resourceNewRelicSyntheticsAlertCondition() *schema.Resource {
 				Type:     schema.TypeString,
 				Elem:     &schema.Schema{Type: schema.TypeString},
 				Required: true,
+				ForceNew: true,
 			},
 			"runbook_url": {
 				Type:     schema.TypeString,
@@ -79,23 +83,42 @@ func readSyntheticsAlertConditionStruct(condition *newrelic.AlertSyntheticsCondi
 }
 
 func resourceNewRelicSyntheticsAlertConditionCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).Client
+	client, err := meta.(*ProviderConfig).clientFor(d)
+	if err != nil {
+		return err
+	}
+
+	if err := resolveAlertConditionPolicy(client, d); err != nil {
+		return err
+	}
+
 	condition := buildSyntheticsAlertConditionStruct(d)
 
 	log.Printf("[INFO] Creating New Relic Synthetics alert condition %s", condition.Name)
 
-	condition, err := client.CreateAlertSyntheticsCondition(*condition)
+	condition, err = client.CreateAlertSyntheticsCondition(*condition)
 	if err != nil {
 		return err
 	}
 
 	d.SetId(serializeIDs([]int{condition.PolicyID, condition.ID}))
 
+	err = waitForCreated(meta.(*ProviderConfig).stopContext(), d.Timeout(schema.TimeoutCreate), func() error {
+		_, err := client.GetAlertSyntheticsCondition(condition.PolicyID, condition.ID)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("Error waiting for New Relic Synthetics alert condition %d to be created: %s", condition.ID, err)
+	}
+
 	return resourceNewRelicSyntheticsAlertConditionRead(d, meta)
 }
 
 func resourceNewRelicSyntheticsAlertConditionRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).Client
+	client, err := meta.(*ProviderConfig).clientFor(d)
+	if err != nil {
+		return err
+	}
 
 	log.Printf("[INFO] Reading New Relic Synthetics alert condition %s", d.Id())
 
@@ -121,7 +144,11 @@ func resourceNewRelicSyntheticsAlertConditionRead(d *schema.ResourceData, meta i
 }
 
 func resourceNewRelicSyntheticsAlertConditionUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).Client
+	client, err := meta.(*ProviderConfig).clientFor(d)
+	if err != nil {
+		return err
+	}
+
 	condition := buildSyntheticsAlertConditionStruct(d)
 
 	ids, err := parseIDs(d.Id(), 2)
@@ -146,7 +173,10 @@ func resourceNewRelicSyntheticsAlertConditionUpdate(d *schema.ResourceData, meta
 }
 
 func resourceNewRelicSyntheticsAlertConditionDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).Client
+	client, err := meta.(*ProviderConfig).clientFor(d)
+	if err != nil {
+		return err
+	}
 
 	ids, err := parseIDs(d.Id(), 2)
 	if err != nil {
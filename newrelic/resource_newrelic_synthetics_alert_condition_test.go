@@ -44,6 +44,10 @@ func TestAccNewRelicSyntheticsAlertCondition_Basic(t *testing.T) {
 	})
 }
 
+func TestResourceNewRelicSyntheticsAlertCondition_immutableAttributes(t *testing.T) {
+	testResourceAttributesForceNew(t, resourceNewRelicSyntheticsAlertCondition(), "policy_id", "policy_name", "monitor_id")
+}
+
 func testAccCheckNewRelicSyntheticsAlertConditionDestroy(s *terraform.State) error {
 	client := testAccProvider.Meta().(*ProviderConfig).Client
 	for _, r := range s.RootModule().Resources {
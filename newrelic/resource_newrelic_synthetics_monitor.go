@@ -3,21 +3,28 @@ package newrelic
 import (
 	"fmt"
 	"log"
+	"sort"
+	"time"
 
 	synthetics "github.com/dollarshaveclub/new-relic-synthetics-go"
 	util "github.com/dollarshaveclub/new-relic-synthetics-go/util"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/helper/validation"
+	newrelic "github.com/paultyng/go-newrelic/v4/api"
 )
 
 func resourceNewRelicSyntheticsMonitor() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNewRelicSyntheticsMonitorCreate,
-		Read:   resourceNewRelicSyntheticsMonitorRead,
-		Update: resourceNewRelicSyntheticsMonitorUpdate,
-		Delete: resourceNewRelicSyntheticsMonitorDelete,
+		Create:        resourceNewRelicSyntheticsMonitorCreate,
+		Read:          resourceNewRelicSyntheticsMonitorRead,
+		Update:        resourceNewRelicSyntheticsMonitorUpdate,
+		Delete:        resourceNewRelicSyntheticsMonitorDelete,
+		CustomizeDiff: validateSyntheticsMonitor,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importSyntheticsMonitor,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
 		},
 		Schema: map[string]*schema.Schema{
 			"type": {
@@ -38,12 +45,13 @@ func resourceNewRelicSyntheticsMonitor() *schema.Resource {
 			"frequency": {
 				Type:         schema.TypeInt,
 				Required:     true,
-				ValidateFunc: intInSlice([]int{1, 5, 10, 15, 30, 60, 360, 720, 1440}),
+				ValidateFunc: validateSyntheticsMonitorFrequency,
 			},
+			// Required for SIMPLE and BROWSER monitors, see
+			// validateSyntheticsMonitorURI.
 			"uri": {
 				Type:     schema.TypeString,
 				Optional: true,
-				// TODO: ValidateFunc (required if SIMPLE or BROWSER)
 			},
 			"locations": {
 				Type:     schema.TypeSet,
@@ -65,7 +73,6 @@ func resourceNewRelicSyntheticsMonitor() *schema.Resource {
 				Optional: true,
 				Default:  7,
 			},
-			// TODO: ValidationFunc (options only valid if SIMPLE or BROWSER)
 			"validation_string": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -82,52 +89,271 @@ func resourceNewRelicSyntheticsMonitor() *schema.Resource {
 				Type:     schema.TypeBool,
 				Optional: true,
 			},
+			"custom_header": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						// Headers commonly carry credentials, e.g. an
+						// Authorization header, and Terraform can't tell
+						// which values are secret.
+						"value": {
+							Type:      schema.TypeString,
+							Required:  true,
+							Sensitive: true,
+						},
+					},
+				},
+			},
+			"request_body": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"permalink": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"tags":     entityTagsSchema(),
+			"tags_all": entityTagsAllSchema(),
 		},
 	}
 }
 
-func buildSyntheticsMonitorStruct(d *schema.ResourceData) *synthetics.CreateMonitorArgs {
-	monitor := synthetics.CreateMonitorArgs{
-		Name:         d.Get("name").(string),
-		Type:         d.Get("type").(string),
-		Frequency:    uint(d.Get("frequency").(int)),
-		Status:       d.Get("status").(string),
-		SLAThreshold: d.Get("sla_threshold").(float64),
+// syntheticsMonitorOptionTypes are the monitor types each option applies to.
+// Only SIMPLE and BROWSER monitors load a single page whose content can be
+// validated.
+var syntheticsMonitorOptionTypes = map[string][]string{
+	"validation_string":         {"SIMPLE", "BROWSER"},
+	"verify_ssl":                {"SIMPLE", "BROWSER"},
+	"bypass_head_request":       {"SIMPLE"},
+	"treat_redirect_as_failure": {"SIMPLE"},
+	"custom_header":             {"SIMPLE", "BROWSER"},
+	"request_body":              {"SIMPLE"},
+}
+
+func syntheticsMonitorOptionApplies(option string, monitorType string) bool {
+	for _, t := range syntheticsMonitorOptionTypes[option] {
+		if t == monitorType {
+			return true
+		}
 	}
 
-	if uri, ok := d.GetOk("uri"); ok {
-		monitor.URI = uri.(string)
+	return false
+}
+
+func validateSyntheticsMonitor(d *schema.ResourceDiff, meta interface{}) error {
+	if err := validateSyntheticsMonitorURI(d); err != nil {
+		return err
 	}
 
-	locations_raw := d.Get("locations").(*schema.Set)
-	locations := make([]string, locations_raw.Len())
-	for i, v := range locations_raw.List() {
-		locations[i] = fmt.Sprint(v)
+	if err := validateSyntheticsMonitorOptions(d); err != nil {
+		return err
 	}
 
-	if validationString, ok := d.GetOk("validation_string"); ok {
-		monitor.ValidationString = util.StrPtr(validationString.(string))
+	if err := validateSyntheticsMonitorLocations(d, meta); err != nil {
+		return err
 	}
 
-	if verifySSL, ok := d.GetOk("verify_ssl"); ok {
-		monitor.VerifySSL = util.BoolPtr(verifySSL.(bool))
+	return validateSyntheticsMonitorTags(d, meta)
+}
+
+// validateSyntheticsMonitorTags plans the monitor's tags. Monitors are tagged
+// through their entity, whose GUID is only known with the provider
+// account_id; without one, the default_tags aren't applied to monitors.
+func validateSyntheticsMonitorTags(d *schema.ResourceDiff, meta interface{}) error {
+	if meta.(*ProviderConfig).accountID != 0 {
+		return customizeDiffEntityTags(d, meta)
 	}
 
-	if bypassHeadRequest, ok := d.GetOk("bypass_head_request"); ok {
-		monitor.BypassHEADRequest = util.BoolPtr(bypassHeadRequest.(bool))
+	if tags, ok := d.GetOk("tags"); ok && len(tags.(map[string]interface{})) > 0 {
+		return fmt.Errorf("tags on Synthetics monitors require the provider account_id")
 	}
 
-	if treatRedirectAsFailure, ok := d.GetOk("treat_redirect_as_failure"); ok {
-		monitor.TreatRedirectAsFailure = util.BoolPtr(treatRedirectAsFailure.(bool))
+	return nil
+}
+
+// validateSyntheticsMonitorURI requires a uri for the monitor types that
+// check one. Scripted monitors get theirs from the script.
+func validateSyntheticsMonitorURI(d *schema.ResourceDiff) error {
+	if !d.NewValueKnown("type") || !d.NewValueKnown("uri") {
+		return nil
 	}
 
-	monitor.Locations = locations
-	return &monitor
+	switch monitorType := d.Get("type").(string); monitorType {
+	case "SIMPLE", "BROWSER":
+		if d.Get("uri").(string) == "" {
+			return fmt.Errorf("uri is required for %s monitors", monitorType)
+		}
+	}
+
+	return nil
 }
 
-func buildSyntheticsUpdateMonitorArgs(d *schema.ResourceData) *synthetics.UpdateMonitorArgs {
-	monitor := synthetics.UpdateMonitorArgs{
+// validateSyntheticsMonitorOptions rejects options the monitor's type doesn't
+// support, which the API would otherwise silently drop.
+func validateSyntheticsMonitorOptions(d *schema.ResourceDiff) error {
+	if !d.NewValueKnown("type") {
+		return nil
+	}
+
+	monitorType := d.Get("type").(string)
+
+	options := make([]string, 0, len(syntheticsMonitorOptionTypes))
+	for option := range syntheticsMonitorOptionTypes {
+		options = append(options, option)
+	}
+	sort.Strings(options)
+
+	for _, option := range options {
+		if _, ok := d.GetOk(option); ok && !syntheticsMonitorOptionApplies(option, monitorType) {
+			return fmt.Errorf("%s is only supported by %v monitors, got type %s",
+				option, syntheticsMonitorOptionTypes[option], monitorType)
+		}
+	}
+
+	return nil
+}
+
+// validateSyntheticsMonitorLocations checks the configured locations against
+// the locations available to the account, since the API accepts unknown
+// locations and the monitor then never runs. Private location GUIDs aren't
+// in the list of public locations and are passed through as they are.
+func validateSyntheticsMonitorLocations(d *schema.ResourceDiff, meta interface{}) error {
+	if !d.HasChange("locations") || !d.NewValueKnown("locations") {
+		return nil
+	}
+
+	var public []string
+	for _, v := range d.Get("locations").(*schema.Set).List() {
+		if l := v.(string); !isSyntheticsPrivateLocationGUID(l) {
+			public = append(public, l)
+		}
+	}
+
+	if len(public) == 0 {
+		return nil
+	}
+
+	valid, err := meta.(*ProviderConfig).syntheticsLocationNames()
+	if err != nil {
+		return err
+	}
+
+	validSet := make(map[string]bool, len(valid))
+	for _, v := range valid {
+		validSet[v] = true
+	}
+
+	var invalid []string
+	for _, l := range public {
+		if !validSet[l] {
+			invalid = append(invalid, l)
+		}
+	}
+
+	if len(invalid) > 0 {
+		sort.Strings(invalid)
+		return fmt.Errorf("invalid Synthetics monitor locations %v, valid locations are %v or private location GUIDs", invalid, valid)
+	}
+
+	return nil
+}
+
+type syntheticsMonitorOptions struct {
+	validationString       *string
+	verifySSL              *bool
+	bypassHEADRequest      *bool
+	treatRedirectAsFailure *bool
+	customHeaders          *[]syntheticsCustomHeader
+	requestBody            *string
+}
+
+// expandSyntheticsMonitorOptions returns the options that apply to the
+// monitor's type. They are always sent, so that options set to false or
+// removed are turned off, except for an unchanged empty validation_string.
+func expandSyntheticsMonitorOptions(d *schema.ResourceData) syntheticsMonitorOptions {
+	monitorType := d.Get("type").(string)
+	options := syntheticsMonitorOptions{}
+
+	if syntheticsMonitorOptionApplies("validation_string", monitorType) {
+		if v := d.Get("validation_string").(string); v != "" || d.HasChange("validation_string") {
+			options.validationString = util.StrPtr(v)
+		}
+	}
+
+	if syntheticsMonitorOptionApplies("verify_ssl", monitorType) {
+		options.verifySSL = util.BoolPtr(d.Get("verify_ssl").(bool))
+	}
+
+	if syntheticsMonitorOptionApplies("bypass_head_request", monitorType) {
+		options.bypassHEADRequest = util.BoolPtr(d.Get("bypass_head_request").(bool))
+	}
+
+	if syntheticsMonitorOptionApplies("treat_redirect_as_failure", monitorType) {
+		options.treatRedirectAsFailure = util.BoolPtr(d.Get("treat_redirect_as_failure").(bool))
+	}
+
+	if syntheticsMonitorOptionApplies("custom_header", monitorType) {
+		headers := []syntheticsCustomHeader{}
+		for _, h := range d.Get("custom_header").(*schema.Set).List() {
+			header := h.(map[string]interface{})
+			headers = append(headers, syntheticsCustomHeader{
+				Name:  header["name"].(string),
+				Value: header["value"].(string),
+			})
+		}
+
+		sort.Slice(headers, func(i, j int) bool { return headers[i].Name < headers[j].Name })
+		options.customHeaders = &headers
+	}
+
+	if syntheticsMonitorOptionApplies("request_body", monitorType) {
+		if v := d.Get("request_body").(string); v != "" || d.HasChange("request_body") {
+			options.requestBody = util.StrPtr(v)
+		}
+	}
+
+	return options
+}
+
+// apiOptions returns the options as sent in the options of a monitor.
+func (o syntheticsMonitorOptions) apiOptions() map[string]interface{} {
+	options := map[string]interface{}{}
+
+	if o.validationString != nil {
+		options["validationString"] = *o.validationString
+	}
+	if o.verifySSL != nil {
+		options["verifySSL"] = *o.verifySSL
+	}
+	if o.bypassHEADRequest != nil {
+		options["bypassHEADRequest"] = *o.bypassHEADRequest
+	}
+	if o.treatRedirectAsFailure != nil {
+		options["treatRedirectAsFailure"] = *o.treatRedirectAsFailure
+	}
+	if o.customHeaders != nil {
+		options["customHeaders"] = *o.customHeaders
+	}
+	if o.requestBody != nil {
+		options["requestBody"] = *o.requestBody
+	}
+
+	if len(options) == 0 {
+		return nil
+	}
+
+	return options
+}
+
+func buildSyntheticsMonitorStruct(d *schema.ResourceData) *syntheticsMonitorCreateRequest {
+	monitor := synthetics.CreateMonitorArgs{
 		Name:         d.Get("name").(string),
+		Type:         d.Get("type").(string),
 		Frequency:    uint(d.Get("frequency").(int)),
 		Status:       d.Get("status").(string),
 		SLAThreshold: d.Get("sla_threshold").(float64),
@@ -143,66 +369,142 @@ func buildSyntheticsUpdateMonitorArgs(d *schema.ResourceData) *synthetics.Update
 		locations[i] = fmt.Sprint(v)
 	}
 
-	if validationString, ok := d.GetOk("validation_string"); ok {
-		monitor.ValidationString = util.StrPtr(validationString.(string))
+	monitor.Locations = locations
+
+	return &syntheticsMonitorCreateRequest{
+		CreateMonitorArgs: monitor,
+		Options:           expandSyntheticsMonitorOptions(d).apiOptions(),
 	}
+}
 
-	if verifySSL, ok := d.GetOk("verify_ssl"); ok {
-		monitor.VerifySSL = util.BoolPtr(verifySSL.(bool))
+func buildSyntheticsUpdateMonitorArgs(d *schema.ResourceData) *syntheticsMonitorUpdateRequest {
+	monitor := synthetics.UpdateMonitorArgs{
+		Name:         d.Get("name").(string),
+		Frequency:    uint(d.Get("frequency").(int)),
+		Status:       d.Get("status").(string),
+		SLAThreshold: d.Get("sla_threshold").(float64),
 	}
 
-	if bypassHeadRequest, ok := d.GetOk("bypass_head_request"); ok {
-		monitor.BypassHEADRequest = util.BoolPtr(bypassHeadRequest.(bool))
+	if uri, ok := d.GetOk("uri"); ok {
+		monitor.URI = uri.(string)
 	}
 
-	if treatRedirectAsFailure, ok := d.GetOk("treat_redirect_as_failure"); ok {
-		monitor.TreatRedirectAsFailure = util.BoolPtr(treatRedirectAsFailure.(bool))
+	locations_raw := d.Get("locations").(*schema.Set)
+	locations := make([]string, locations_raw.Len())
+	for i, v := range locations_raw.List() {
+		locations[i] = fmt.Sprint(v)
 	}
 
 	monitor.Locations = locations
-	return &monitor
+
+	return &syntheticsMonitorUpdateRequest{
+		UpdateMonitorArgs: monitor,
+		Options:           expandSyntheticsMonitorOptions(d).apiOptions(),
+	}
 }
 
 func readSyntheticsMonitorStruct(monitor *synthetics.Monitor, d *schema.ResourceData) error {
 	d.Set("name", monitor.Name)
 	d.Set("type", monitor.Type)
-	d.Set("frequency", monitor.Frequency)
+	d.Set("frequency", int(monitor.Frequency))
 	d.Set("uri", monitor.URI)
 	d.Set("locations", monitor.Locations)
 	d.Set("status", monitor.Status)
 	d.Set("sla_threshold", monitor.SLAThreshold)
 
-	if monitor.VerifySSL != nil {
-		d.Set("verify_ssl", *monitor.VerifySSL)
+	// Options missing from the response are unset, so that options removed
+	// outside Terraform show up as a diff.
+	if syntheticsMonitorOptionApplies("validation_string", monitor.Type) {
+		validationString := ""
+		if monitor.ValidationString != nil {
+			validationString = *monitor.ValidationString
+		}
+		d.Set("validation_string", validationString)
 	}
 
-	if monitor.ValidationString != nil {
-		d.Set("validation_string", *monitor.ValidationString)
+	if syntheticsMonitorOptionApplies("custom_header", monitor.Type) {
+		customHeaders := syntheticsMonitorCustomHeaders(monitor)
+		headers := make([]interface{}, 0, len(customHeaders))
+		for _, h := range customHeaders {
+			headers = append(headers, map[string]interface{}{
+				"name":  h.Name,
+				"value": h.Value,
+			})
+		}
+
+		if err := d.Set("custom_header", headers); err != nil {
+			return fmt.Errorf("[DEBUG] Error setting Synthetics monitor custom headers: %#v", err)
+		}
 	}
 
-	if monitor.BypassHEADRequest != nil {
-		d.Set("bypass_head_request", monitor.BypassHEADRequest)
+	if syntheticsMonitorOptionApplies("request_body", monitor.Type) {
+		requestBody, _ := monitor.Options["requestBody"].(string)
+		d.Set("request_body", requestBody)
 	}
 
-	if monitor.TreatRedirectAsFailure != nil {
-		d.Set("treat_redirect_as_failure", monitor.TreatRedirectAsFailure)
+	for option, value := range map[string]*bool{
+		"verify_ssl":                monitor.VerifySSL,
+		"bypass_head_request":       monitor.BypassHEADRequest,
+		"treat_redirect_as_failure": monitor.TreatRedirectAsFailure,
+	} {
+		if syntheticsMonitorOptionApplies(option, monitor.Type) {
+			d.Set(option, value != nil && *value)
+		}
 	}
 
 	return nil
 }
 
+// importSyntheticsMonitor imports a monitor by its ID. The script of a
+// scripted monitor is a separate newrelic_synthetics_monitor_script resource,
+// so the import logs a hint to import it with the same ID.
+func importSyntheticsMonitor(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	monitor, err := meta.(*ProviderConfig).Synthetics.GetMonitor(d.Id())
+	if err != nil && err != synthetics.ErrMonitorNotFound {
+		return nil, err
+	}
+
+	if monitor != nil && (monitor.Type == "SCRIPT_BROWSER" || monitor.Type == "SCRIPT_API") {
+		log.Printf("[WARN] Synthetics monitor %s is a %s monitor, import its script with: terraform import newrelic_synthetics_monitor_script.<name> %s", d.Id(), monitor.Type, d.Id())
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
 func resourceNewRelicSyntheticsMonitorCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ProviderConfig).Synthetics
 	monitor := buildSyntheticsMonitorStruct(d)
 
 	log.Printf("[INFO] Creating New Relic Synthetics monitor %s", monitor.Name)
 
-	condition, err := client.CreateMonitor(monitor)
+	id, err := createSyntheticsMonitor(client, *monitor)
 	if err != nil {
 		return err
 	}
 
-	d.SetId(condition.ID)
+	d.SetId(id)
+
+	// Scripted browser monitors can take a while to become readable after
+	// creation.
+	err = waitForCreated(meta.(*ProviderConfig).stopContext(), d.Timeout(schema.TimeoutCreate), func() error {
+		if _, err := client.GetMonitor(id); err != nil {
+			if err == synthetics.ErrMonitorNotFound {
+				return newrelic.ErrNotFound
+			}
+
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("Error waiting for New Relic Synthetics monitor %s to be created: %s", id, err)
+	}
+
+	if err := applySyntheticsMonitorTags(d, meta); err != nil {
+		return err
+	}
+
 	return resourceNewRelicSyntheticsMonitorRead(d, meta)
 }
 
@@ -221,17 +523,65 @@ func resourceNewRelicSyntheticsMonitorRead(d *schema.ResourceData, meta interfac
 		return err
 	}
 
-	return readSyntheticsMonitorStruct(monitor, d)
+	d.Set("permalink", meta.(*ProviderConfig).permalink(d, "synthetics", "monitors/"+d.Id()))
+
+	if err := readSyntheticsMonitorStruct(monitor, d); err != nil {
+		return err
+	}
+
+	if guid := meta.(*ProviderConfig).syntheticsMonitorGUID(d.Id()); guid != "" {
+		return readEntityTags(meta.(*ProviderConfig).Client, guid, d)
+	}
+
+	return nil
+}
+
+func applySyntheticsMonitorTags(d *schema.ResourceData, meta interface{}) error {
+	guid := meta.(*ProviderConfig).syntheticsMonitorGUID(d.Id())
+	if guid == "" {
+		return nil
+	}
+
+	return applyEntityTags(meta.(*ProviderConfig).stopContext(), meta.(*ProviderConfig).Client, guid, d)
+}
+
+// syntheticsMonitorStatusOnlyChange reports whether status is the only
+// attribute changed, e.g. when pausing a monitor for a maintenance window.
+func syntheticsMonitorStatusOnlyChange(d *schema.ResourceData) bool {
+	if !d.HasChange("status") {
+		return false
+	}
+
+	for k := range resourceNewRelicSyntheticsMonitor().Schema {
+		if k != "status" && d.HasChange(k) {
+			return false
+		}
+	}
+
+	return true
 }
 
 func resourceNewRelicSyntheticsMonitorUpdate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ProviderConfig).Synthetics
 	monitor := buildSyntheticsUpdateMonitorArgs(d)
 
+	// Unset arguments are left out of the PATCH, so a status change only
+	// sends the new status and leaves the rest of the monitor untouched.
+	if syntheticsMonitorStatusOnlyChange(d) {
+		monitor = &syntheticsMonitorUpdateRequest{
+			UpdateMonitorArgs: synthetics.UpdateMonitorArgs{
+				Status: d.Get("status").(string),
+			},
+		}
+	}
+
 	log.Printf("[INFO] Updating New Relic Synthetics monitor %s", d.Id())
 
-	_, err := client.UpdateMonitor(d.Id(), monitor)
-	if err != nil {
+	if err := updateSyntheticsMonitor(client, d.Id(), *monitor); err != nil {
+		return err
+	}
+
+	if err := applySyntheticsMonitorTags(d, meta); err != nil {
 		return err
 	}
 
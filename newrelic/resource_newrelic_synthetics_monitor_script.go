@@ -1,7 +1,10 @@
 package newrelic
 
 import (
+	"fmt"
 	"log"
+	"strings"
+	"unicode"
 
 	synthetics "github.com/dollarshaveclub/new-relic-synthetics-go"
 	"github.com/hashicorp/terraform/helper/schema"
@@ -23,14 +26,77 @@ func resourceNewRelicSyntheticsMonitorScript() *schema.Resource {
 				ForceNew: true,
 			},
 			"text": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateFunc:     validateNotBlank,
+				DiffSuppressFunc: suppressEquivalentSyntheticsMonitorScript,
 			},
 		},
+		CustomizeDiff: func(d *schema.ResourceDiff, meta interface{}) error {
+			// Monitors created in the same run are checked on create instead.
+			if !d.NewValueKnown("monitor_id") {
+				return nil
+			}
+
+			return validateSyntheticsMonitorScriptable(meta.(*ProviderConfig).Synthetics, d.Get("monitor_id").(string))
+		},
 	}
 }
 
-func importSyntheticsMonitorScript(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+// normalizeSyntheticsMonitorScript returns the canonical form of a script:
+// without a byte order mark, with "\n" line endings, without trailing
+// whitespace on each line and without leading or trailing blank lines. The
+// Synthetics API stores scripts with these transformations applied, so the
+// script read back rarely matches the uploaded one byte for byte.
+func normalizeSyntheticsMonitorScript(script string) string {
+	script = strings.TrimPrefix(script, "\ufeff")
+	script = strings.Replace(script, "\r\n", "\n", -1)
+	script = strings.Replace(script, "\r", "\n", -1)
+
+	lines := strings.Split(script, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRightFunc(line, unicode.IsSpace)
+	}
+
+	return strings.Trim(strings.Join(lines, "\n"), "\n")
+}
+
+// suppressEquivalentSyntheticsMonitorScript suppresses diffs between scripts
+// with the same canonical form, so an unchanged script isn't uploaded again.
+func suppressEquivalentSyntheticsMonitorScript(k, old, new string, d *schema.ResourceData) bool {
+	return old != "" && normalizeSyntheticsMonitorScript(old) == normalizeSyntheticsMonitorScript(new)
+}
+
+// validateSyntheticsMonitorScriptable ensures the monitor is a scripted
+// monitor, since the API rejects scripts for other monitor types with an
+// unhelpful error. Missing monitors are left for the API to report.
+func validateSyntheticsMonitorScriptable(client *synthetics.Client, id string) error {
+	log.Printf("[INFO] Reading New Relic Synthetics monitor %s", id)
+
+	monitor, err := client.GetMonitor(id)
+	if err != nil {
+		if err == synthetics.ErrMonitorNotFound {
+			return nil
+		}
+
+		return err
+	}
+
+	if monitor.Type != "SCRIPT_BROWSER" && monitor.Type != "SCRIPT_API" {
+		return fmt.Errorf("Synthetics monitor %s is of type %s; scripts can only be attached to SCRIPT_BROWSER or SCRIPT_API monitors", id, monitor.Type)
+	}
+
+	return nil
+}
+
+// importSyntheticsMonitorScript imports the script of a monitor by the
+// monitor's ID, so that a scripted monitor and its script are imported with
+// the same ID. Monitors that can't have a script fail the import.
+func importSyntheticsMonitorScript(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if err := validateSyntheticsMonitorScriptable(meta.(*ProviderConfig).Synthetics, d.Id()); err != nil {
+		return nil, fmt.Errorf("Error importing Synthetics monitor script %s: %s", d.Id(), err)
+	}
+
 	d.Set("monitor_id", d.Id())
 	return []*schema.ResourceData{d}, nil
 }
@@ -48,6 +114,11 @@ func resourceNewRelicSyntheticsMonitorScriptCreate(d *schema.ResourceData, meta
 	script := buildSyntheticsMonitorScriptStruct(d)
 
 	id := d.Get("monitor_id").(string)
+
+	if err := validateSyntheticsMonitorScriptable(client, id); err != nil {
+		return err
+	}
+
 	log.Printf("[INFO] Creating New Relic Synthetics monitor script %s", id)
 
 	err := client.UpdateMonitorScript(id, script)
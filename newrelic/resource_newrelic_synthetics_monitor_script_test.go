@@ -2,6 +2,9 @@ package newrelic
 
 import (
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
 	"strings"
 	"testing"
 
@@ -36,10 +39,165 @@ func TestAccNewRelicSyntheticsMonitorScript_Basic(t *testing.T) {
 						"newrelic_synthetics_monitor_script.foo_script", "text", scriptTextUpdated),
 				),
 			},
+			// The script is imported by the ID of its monitor.
+			{
+				ResourceName:      "newrelic_synthetics_monitor_script.foo_script",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestSuppressEquivalentSyntheticsMonitorScript(t *testing.T) {
+	script := "var assert = require('assert');\n$browser.get('https://example.com');\n"
+
+	cases := []struct {
+		new      string
+		suppress bool
+	}{
+		{script, true},
+		{"\ufeffvar assert = require('assert');\r\n$browser.get('https://example.com');\r\n", true},
+		{"var assert = require('assert');  \n$browser.get('https://example.com');\t\n\n\n", true},
+		{"\n\nvar assert = require('assert');\n$browser.get('https://example.com');", true},
+		// Indentation and whitespace inside lines are kept.
+		{"  var assert = require('assert');\n$browser.get('https://example.com');\n", false},
+		{"var assert = require('assert');\n\n$browser.get('https://example.com');\n", false},
+		{"var assert = require('assert');\n$browser.get('https://example.org');\n", false},
+	}
+
+	for _, c := range cases {
+		if suppress := suppressEquivalentSyntheticsMonitorScript("text", script, c.new, nil); suppress != c.suppress {
+			t.Errorf("expected suppress %t for %q, got %t", c.suppress, c.new, suppress)
+		}
+	}
+}
+
+func TestImportSyntheticsMonitorScript(t *testing.T) {
+	monitorType := "SCRIPT_API"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"6b8a2b6e-0d77-4c8a-9a1b-2f53c8b6f7a1","type":"` + monitorType + `"}`))
+	}))
+	defer server.Close()
+
+	client, err := (&Config{APIKey: "foo", SyntheticsAPIURL: server.URL + "/synthetics/api"}).ClientSynthetics()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	meta := &ProviderConfig{Synthetics: client}
+
+	d := resourceNewRelicSyntheticsMonitorScript().TestResourceData()
+	d.SetId("6b8a2b6e-0d77-4c8a-9a1b-2f53c8b6f7a1")
+
+	if _, err := importSyntheticsMonitorScript(d, meta); err != nil {
+		t.Fatal(err)
+	}
+
+	if id := d.Get("monitor_id").(string); id != "6b8a2b6e-0d77-4c8a-9a1b-2f53c8b6f7a1" {
+		t.Fatalf("expected the monitor ID to be set, got %q", id)
+	}
+
+	monitorType = "SIMPLE"
+
+	if _, err := importSyntheticsMonitorScript(d, meta); err == nil || !strings.Contains(err.Error(), "scripts can only be attached to SCRIPT_BROWSER or SCRIPT_API monitors") {
+		t.Fatalf("expected importing the script of a SIMPLE monitor to fail, got %v", err)
+	}
+}
+
+func TestAccNewRelicSyntheticsMonitorScript_toggleMonitorStatus(t *testing.T) {
+	rname := acctest.RandString(5)
+	scriptText := acctest.RandString(5)
+	var monitorID string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNewRelicSyntheticsMonitorScriptDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckNewRelicSyntheticsMonitorScriptConfigWithStatus(rname, scriptText, "DISABLED"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicSyntheticsMonitorScriptExists("newrelic_synthetics_monitor_script.foo_script"),
+					testAccCheckNewRelicSyntheticsMonitorID("newrelic_synthetics_monitor.foo", &monitorID),
+				),
+			},
+			{
+				Config: testAccCheckNewRelicSyntheticsMonitorScriptConfigWithStatus(rname, scriptText, "ENABLED"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicSyntheticsMonitorScriptExists("newrelic_synthetics_monitor_script.foo_script"),
+					testAccCheckNewRelicSyntheticsMonitorID("newrelic_synthetics_monitor.foo", &monitorID),
+					resource.TestCheckResourceAttr(
+						"newrelic_synthetics_monitor.foo", "status", "ENABLED"),
+					resource.TestCheckResourceAttr(
+						"newrelic_synthetics_monitor_script.foo_script", "text", scriptText),
+				),
+			},
+			{
+				Config: testAccCheckNewRelicSyntheticsMonitorScriptConfigWithStatus(rname, scriptText, "DISABLED"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicSyntheticsMonitorScriptExists("newrelic_synthetics_monitor_script.foo_script"),
+					testAccCheckNewRelicSyntheticsMonitorID("newrelic_synthetics_monitor.foo", &monitorID),
+					resource.TestCheckResourceAttr(
+						"newrelic_synthetics_monitor.foo", "status", "DISABLED"),
+					resource.TestCheckResourceAttr(
+						"newrelic_synthetics_monitor_script.foo_script", "text", scriptText),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckNewRelicSyntheticsMonitorID records the monitor's ID on the
+// first call and fails if it changes afterwards, i.e. if the monitor was
+// recreated.
+func TestAccNewRelicSyntheticsMonitorScript_simpleMonitor(t *testing.T) {
+	expectedErrorMsg, _ := regexp.Compile("scripts can only be attached to SCRIPT_BROWSER or SCRIPT_API monitors")
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNewRelicSyntheticsMonitorScriptDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCheckNewRelicSyntheticsMonitorScriptConfigWithType(acctest.RandString(5), acctest.RandString(5), "SIMPLE"),
+				ExpectError: expectedErrorMsg,
+			},
 		},
 	})
 }
 
+func TestAccNewRelicSyntheticsMonitorScript_blankText(t *testing.T) {
+	expectedErrorMsg, _ := regexp.Compile("expected text to not be blank")
+	resource.Test(t, resource.TestCase{
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCheckNewRelicSyntheticsMonitorScriptConfig(acctest.RandString(5), "  "),
+				ExpectError: expectedErrorMsg,
+			},
+		},
+	})
+}
+
+func testAccCheckNewRelicSyntheticsMonitorID(n string, id *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if *id == "" {
+			*id = rs.Primary.ID
+		} else if *id != rs.Primary.ID {
+			return fmt.Errorf("Synthetics monitor was recreated: %s, expected %s", rs.Primary.ID, *id)
+		}
+
+		return nil
+	}
+}
+
 func testAccCheckNewRelicSyntheticsMonitorScriptExists(n string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -81,12 +239,35 @@ func testAccCheckNewRelicSyntheticsMonitorScriptDestroy(s *terraform.State) erro
 }
 
 func testAccCheckNewRelicSyntheticsMonitorScriptConfig(rName string, scriptText string) string {
+	return testAccCheckNewRelicSyntheticsMonitorScriptConfigWithStatus(rName, scriptText, "DISABLED")
+}
+
+func testAccCheckNewRelicSyntheticsMonitorScriptConfigWithStatus(rName string, scriptText string, status string) string {
 	return fmt.Sprintf(`
 
 resource "newrelic_synthetics_monitor" "foo" {
   name = "%[1]s"
   type = "SCRIPT_BROWSER"
   frequency = 1
+  status = "%[3]s"
+  locations = ["AWS_US_EAST_1"]
+  uri = "https://google.com"
+}
+
+resource "newrelic_synthetics_monitor_script" "foo_script" {
+  monitor_id = "${newrelic_synthetics_monitor.foo.id}"
+  text = "%[2]s"
+}
+`, rName, scriptText, status)
+}
+
+func testAccCheckNewRelicSyntheticsMonitorScriptConfigWithType(rName string, scriptText string, monitorType string) string {
+	return fmt.Sprintf(`
+
+resource "newrelic_synthetics_monitor" "foo" {
+  name = "%[1]s"
+  type = "%[3]s"
+  frequency = 1
   status = "DISABLED"
   locations = ["AWS_US_EAST_1"]
   uri = "https://google.com"
@@ -96,5 +277,5 @@ resource "newrelic_synthetics_monitor_script" "foo_script" {
   monitor_id = "${newrelic_synthetics_monitor.foo.id}"
   text = "%[2]s"
 }
-`, rName, scriptText)
+`, rName, scriptText, monitorType)
 }
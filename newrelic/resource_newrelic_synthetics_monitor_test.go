@@ -1,11 +1,22 @@
 package newrelic
 
 import (
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"regexp"
+	"strings"
 	"testing"
 
+	synthetics "github.com/dollarshaveclub/new-relic-synthetics-go"
+	util "github.com/dollarshaveclub/new-relic-synthetics-go/util"
+	"github.com/hashicorp/terraform/config"
 	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
 )
 
@@ -56,6 +67,425 @@ func TestAccNewRelicSyntheticsMonitor_Basic(t *testing.T) {
 	})
 }
 
+func TestAccNewRelicSyntheticsMonitor_invalidLocation(t *testing.T) {
+	expectedErrorMsg, _ := regexp.Compile("invalid Synthetics monitor locations \\[AWS_NOWHERE_1\\]")
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCheckNewRelicSyntheticsMonitorConfigLocations(acctest.RandString(5), "AWS_NOWHERE_1"),
+				ExpectError: expectedErrorMsg,
+			},
+		},
+	})
+}
+
+func TestAccNewRelicSyntheticsMonitor_privateLocation(t *testing.T) {
+	key := "NEWRELIC_SYNTHETICS_PRIVATE_LOCATION"
+	privateLocation := os.Getenv(key)
+	if privateLocation == "" {
+		t.Skipf("Environment variable %s is not set", key)
+	}
+
+	rName := acctest.RandString(5)
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNewRelicSyntheticsMonitorDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckNewRelicSyntheticsMonitorConfigLocations(rName, "AWS_US_EAST_1", privateLocation),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicSyntheticsMonitorExists("newrelic_synthetics_monitor.foo"),
+					resource.TestCheckResourceAttr(
+						"newrelic_synthetics_monitor.foo", "locations.#", "2"),
+				),
+			},
+			{
+				Config:   testAccCheckNewRelicSyntheticsMonitorConfigLocations(rName, "AWS_US_EAST_1", privateLocation),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccNewRelicSyntheticsMonitor_options(t *testing.T) {
+	rName := acctest.RandString(5)
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNewRelicSyntheticsMonitorDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckNewRelicSyntheticsMonitorConfigOptions(rName, "SIMPLE", `
+  validation_string   = "Welcome"
+  verify_ssl          = true
+  bypass_head_request = true`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicSyntheticsMonitorExists("newrelic_synthetics_monitor.foo"),
+					resource.TestCheckResourceAttr(
+						"newrelic_synthetics_monitor.foo", "validation_string", "Welcome"),
+					resource.TestCheckResourceAttr(
+						"newrelic_synthetics_monitor.foo", "verify_ssl", "true"),
+					resource.TestCheckResourceAttr(
+						"newrelic_synthetics_monitor.foo", "bypass_head_request", "true"),
+				),
+			},
+			{
+				Config: testAccCheckNewRelicSyntheticsMonitorConfigOptions(rName, "SIMPLE", `
+  verify_ssl = false`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicSyntheticsMonitorExists("newrelic_synthetics_monitor.foo"),
+					resource.TestCheckResourceAttr(
+						"newrelic_synthetics_monitor.foo", "validation_string", ""),
+					resource.TestCheckResourceAttr(
+						"newrelic_synthetics_monitor.foo", "verify_ssl", "false"),
+					resource.TestCheckResourceAttr(
+						"newrelic_synthetics_monitor.foo", "bypass_head_request", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccNewRelicSyntheticsMonitor_customHeaders(t *testing.T) {
+	rName := acctest.RandString(5)
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNewRelicSyntheticsMonitorDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckNewRelicSyntheticsMonitorConfigOptions(rName, "SIMPLE", `
+  request_body = "{\"ping\": true}"
+
+  custom_header {
+    name  = "Authorization"
+    value = "Bearer secret"
+  }
+
+  custom_header {
+    name  = "X-Monitor"
+    value = "terraform"
+  }`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicSyntheticsMonitorExists("newrelic_synthetics_monitor.foo"),
+					resource.TestCheckResourceAttr(
+						"newrelic_synthetics_monitor.foo", "custom_header.#", "2"),
+					resource.TestCheckResourceAttr(
+						"newrelic_synthetics_monitor.foo", "request_body", `{"ping": true}`),
+				),
+			},
+			{
+				Config: testAccCheckNewRelicSyntheticsMonitorConfigOptions(rName, "SIMPLE", ""),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicSyntheticsMonitorExists("newrelic_synthetics_monitor.foo"),
+					resource.TestCheckResourceAttr(
+						"newrelic_synthetics_monitor.foo", "custom_header.#", "0"),
+					resource.TestCheckResourceAttr(
+						"newrelic_synthetics_monitor.foo", "request_body", ""),
+				),
+			},
+		},
+	})
+}
+
+func TestAccNewRelicSyntheticsMonitor_unsupportedOption(t *testing.T) {
+	cases := map[string]string{
+		"SCRIPT_API": `validation_string = "Welcome"`,
+		"BROWSER":    `bypass_head_request = true`,
+	}
+
+	for monitorType, option := range cases {
+		expectedErrorMsg, _ := regexp.Compile("is only supported by \\[.*\\] monitors, got type " + monitorType)
+		resource.Test(t, resource.TestCase{
+			Providers: testAccProviders,
+			Steps: []resource.TestStep{
+				{
+					Config:      testAccCheckNewRelicSyntheticsMonitorConfigOptions(acctest.RandString(5), monitorType, option),
+					ExpectError: expectedErrorMsg,
+				},
+			},
+		})
+	}
+}
+
+func TestValidateSyntheticsMonitorURI(t *testing.T) {
+	r := resourceNewRelicSyntheticsMonitor()
+
+	diff := func(monitorType string, uri string) error {
+		c := map[string]interface{}{
+			"name":      "foo",
+			"type":      monitorType,
+			"frequency": 5,
+			"status":    "DISABLED",
+			// A private location, so that the locations aren't looked up.
+			"locations": []interface{}{"MXxTWU5USHxQUklWQVRFX0xPQ0FUSU9OfGFiYw"},
+		}
+		if uri != "" {
+			c["uri"] = uri
+		}
+
+		rc, err := config.NewRawConfig(c)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = r.Diff(nil, terraform.NewResourceConfig(rc), &ProviderConfig{})
+		return err
+	}
+
+	for _, c := range []struct {
+		monitorType string
+		uri         string
+		expected    string
+	}{
+		{"SIMPLE", "https://example.com", ""},
+		{"SIMPLE", "", "uri is required for SIMPLE monitors"},
+		{"BROWSER", "", "uri is required for BROWSER monitors"},
+		{"SCRIPT_API", "", ""},
+	} {
+		err := diff(c.monitorType, c.uri)
+		if c.expected == "" && err != nil {
+			t.Errorf("expected no error for a %s monitor with uri %q, got %q", c.monitorType, c.uri, err)
+		}
+
+		if c.expected != "" && (err == nil || err.Error() != c.expected) {
+			t.Errorf("expected %q for a %s monitor with uri %q, got %q", c.expected, c.monitorType, c.uri, err)
+		}
+	}
+}
+
+func TestExpandSyntheticsMonitorOptions(t *testing.T) {
+	r := resourceNewRelicSyntheticsMonitor()
+
+	simple := expandSyntheticsMonitorOptions(schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"type":              "SIMPLE",
+		"validation_string": "Welcome",
+	}))
+
+	if simple.validationString == nil || *simple.validationString != "Welcome" {
+		t.Fatalf("expected validation_string to be sent, got %v", simple.validationString)
+	}
+
+	for option, value := range map[string]*bool{
+		"verify_ssl":                simple.verifySSL,
+		"bypass_head_request":       simple.bypassHEADRequest,
+		"treat_redirect_as_failure": simple.treatRedirectAsFailure,
+	} {
+		if value == nil || *value {
+			t.Fatalf("expected %s to be sent as false, got %v", option, value)
+		}
+	}
+
+	script := expandSyntheticsMonitorOptions(schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"type": "SCRIPT_API",
+	}))
+
+	if script != (syntheticsMonitorOptions{}) {
+		t.Fatalf("expected no options for a SCRIPT_API monitor, got %+v", script)
+	}
+}
+
+func TestReadSyntheticsMonitorStruct_options(t *testing.T) {
+	d := resourceNewRelicSyntheticsMonitor().TestResourceData()
+	d.Set("validation_string", "Welcome")
+	d.Set("verify_ssl", true)
+
+	monitor := &synthetics.Monitor{
+		Type:              "SIMPLE",
+		Frequency:         1440,
+		BypassHEADRequest: util.BoolPtr(true),
+	}
+
+	if err := readSyntheticsMonitorStruct(monitor, d); err != nil {
+		t.Fatal(err)
+	}
+
+	if frequency := d.Get("frequency").(int); frequency != 1440 {
+		t.Fatalf("expected frequency 1440, got %d", frequency)
+	}
+
+	if v := d.Get("validation_string").(string); v != "" {
+		t.Fatalf("expected validation_string removed outside Terraform to be unset, got %q", v)
+	}
+
+	if d.Get("verify_ssl").(bool) || !d.Get("bypass_head_request").(bool) {
+		t.Fatalf("expected verify_ssl false and bypass_head_request true, got %v and %v",
+			d.Get("verify_ssl"), d.Get("bypass_head_request"))
+	}
+}
+
+func TestExpandSyntheticsMonitorOptions_customHeaders(t *testing.T) {
+	r := resourceNewRelicSyntheticsMonitor()
+
+	simple := expandSyntheticsMonitorOptions(schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"type":         "SIMPLE",
+		"request_body": `{"ping": true}`,
+		"custom_header": []interface{}{
+			map[string]interface{}{"name": "X-Monitor", "value": "terraform"},
+			map[string]interface{}{"name": "Authorization", "value": "Bearer secret"},
+		},
+	}))
+
+	expected := []syntheticsCustomHeader{
+		{Name: "Authorization", Value: "Bearer secret"},
+		{Name: "X-Monitor", Value: "terraform"},
+	}
+	if simple.customHeaders == nil || !reflect.DeepEqual(*simple.customHeaders, expected) {
+		t.Fatalf("expected custom headers %v, got %v", expected, simple.customHeaders)
+	}
+
+	if simple.requestBody == nil || *simple.requestBody != `{"ping": true}` {
+		t.Fatalf("expected request_body to be sent, got %v", simple.requestBody)
+	}
+
+	// Headers are always sent, so that removed headers are cleared.
+	browser := expandSyntheticsMonitorOptions(schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"type": "BROWSER",
+	}))
+
+	if browser.customHeaders == nil || len(*browser.customHeaders) != 0 {
+		t.Fatalf("expected no custom headers to be sent, got %v", browser.customHeaders)
+	}
+
+	if browser.requestBody != nil {
+		t.Fatalf("expected no request_body for a BROWSER monitor, got %q", *browser.requestBody)
+	}
+}
+
+func TestReadSyntheticsMonitorStruct_customHeaders(t *testing.T) {
+	d := resourceNewRelicSyntheticsMonitor().TestResourceData()
+
+	monitor := &synthetics.Monitor{
+		Type: "SIMPLE",
+		Options: map[string]interface{}{
+			"customHeaders": []interface{}{
+				map[string]interface{}{"name": "Authorization", "value": "Bearer secret"},
+			},
+			"requestBody": `{"ping": true}`,
+		},
+	}
+
+	if err := readSyntheticsMonitorStruct(monitor, d); err != nil {
+		t.Fatal(err)
+	}
+
+	headers := d.Get("custom_header").(*schema.Set).List()
+	if len(headers) != 1 || !reflect.DeepEqual(headers[0], map[string]interface{}{"name": "Authorization", "value": "Bearer secret"}) {
+		t.Fatalf("expected the Authorization header to be read, got %v", headers)
+	}
+
+	if v := d.Get("request_body").(string); v != `{"ping": true}` {
+		t.Fatalf("expected request_body to be read, got %q", v)
+	}
+}
+
+func TestResourceNewRelicSyntheticsMonitorCreate_customHeaders(t *testing.T) {
+	var created map[string]interface{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.Method == "POST" {
+			json.NewDecoder(r.Body).Decode(&created)
+			created["id"] = "6b8a2b6e-0d77-4c8a-9a1b-2f53c8b6f7a1"
+			w.Header().Set("Location", "https://synthetics.newrelic.com/synthetics/api/v3/monitors/6b8a2b6e-0d77-4c8a-9a1b-2f53c8b6f7a1")
+			w.WriteHeader(http.StatusCreated)
+			return
+		}
+
+		json.NewEncoder(w).Encode(created)
+	}))
+	defer server.Close()
+
+	client, err := (&Config{APIKey: "foo", SyntheticsAPIURL: server.URL + "/synthetics/api"}).ClientSynthetics()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := resourceNewRelicSyntheticsMonitor()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"name":         "foo",
+		"type":         "SIMPLE",
+		"frequency":    5,
+		"status":       "ENABLED",
+		"locations":    []interface{}{"AWS_US_EAST_1"},
+		"uri":          "https://example.com",
+		"request_body": `{"ping": true}`,
+		"custom_header": []interface{}{
+			map[string]interface{}{"name": "Authorization", "value": "Bearer secret"},
+		},
+	})
+
+	if err := r.Create(d, &ProviderConfig{Synthetics: client}); err != nil {
+		t.Fatal(err)
+	}
+
+	options, _ := created["options"].(map[string]interface{})
+	expected := []interface{}{map[string]interface{}{"name": "Authorization", "value": "Bearer secret"}}
+	if !reflect.DeepEqual(options["customHeaders"], expected) || options["requestBody"] != `{"ping": true}` {
+		t.Fatalf("expected the custom headers and request body to be sent in the options, got %v", created["options"])
+	}
+
+	if n := d.Get("custom_header").(*schema.Set).Len(); n != 1 {
+		t.Fatalf("expected 1 custom header to be read back, got %d", n)
+	}
+
+	if v := d.Get("request_body").(string); v != `{"ping": true}` {
+		t.Fatalf("expected request_body to be read back, got %q", v)
+	}
+}
+
+func TestResourceNewRelicSyntheticsMonitorUpdate_removedCustomHeaders(t *testing.T) {
+	var updated map[string]interface{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.Method == "PATCH" {
+			json.NewDecoder(r.Body).Decode(&updated)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		w.Write([]byte(`{"id":"6b8a2b6e-0d77-4c8a-9a1b-2f53c8b6f7a1","type":"SIMPLE","options":{"verifySSL":true}}`))
+	}))
+	defer server.Close()
+
+	client, err := (&Config{APIKey: "foo", SyntheticsAPIURL: server.URL + "/synthetics/api"}).ClientSynthetics()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := resourceNewRelicSyntheticsMonitor()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"name":       "foo",
+		"type":       "SIMPLE",
+		"frequency":  5,
+		"status":     "ENABLED",
+		"locations":  []interface{}{"AWS_US_EAST_1"},
+		"uri":        "https://example.com",
+		"verify_ssl": true,
+	})
+	d.SetId("6b8a2b6e-0d77-4c8a-9a1b-2f53c8b6f7a1")
+
+	if err := r.Update(d, &ProviderConfig{Synthetics: client}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The other options are sent along with the headers so that they're kept.
+	options, _ := updated["options"].(map[string]interface{})
+	if headers, ok := options["customHeaders"].([]interface{}); !ok || len(headers) != 0 || options["verifySSL"] != true {
+		t.Fatalf("expected no custom headers to be sent with the other options, got %v", updated["options"])
+	}
+}
+
+func TestResourceNewRelicSyntheticsMonitorRead_notFound(t *testing.T) {
+	testResourceReadNotFound(t, resourceNewRelicSyntheticsMonitor(), "6b8a2b6e-0d77-4c8a-9a1b-2f53c8b6f7a1")
+}
+
 func testAccCheckNewRelicSyntheticsMonitorExists(n string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -124,3 +554,32 @@ resource "newrelic_synthetics_monitor" "foo" {
 }
 `, rName)
 }
+
+func testAccCheckNewRelicSyntheticsMonitorConfigLocations(rName string, locations ...string) string {
+	return fmt.Sprintf(`
+
+resource "newrelic_synthetics_monitor" "foo" {
+  name = "%[1]s"
+  type = "SIMPLE"
+  frequency = 1
+  status = "DISABLED"
+  locations = ["%[2]s"]
+  uri = "https://google.com"
+}
+`, rName, strings.Join(locations, `", "`))
+}
+
+func testAccCheckNewRelicSyntheticsMonitorConfigOptions(rName string, monitorType string, options string) string {
+	return fmt.Sprintf(`
+
+resource "newrelic_synthetics_monitor" "foo" {
+  name = "%[1]s"
+  type = "%[2]s"
+  frequency = 1
+  status = "DISABLED"
+  locations = ["AWS_US_EAST_1"]
+  uri = "https://google.com"
+%[3]s
+}
+`, rName, monitorType, options)
+}
@@ -0,0 +1,131 @@
+package newrelic
+
+import (
+	"log"
+	"regexp"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+func resourceNewRelicSyntheticsSecureCredential() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceNewRelicSyntheticsSecureCredentialCreate,
+		Read:   resourceNewRelicSyntheticsSecureCredentialRead,
+		Update: resourceNewRelicSyntheticsSecureCredentialUpdate,
+		Delete: resourceNewRelicSyntheticsSecureCredentialDelete,
+		Importer: &schema.ResourceImporter{
+			State: importSyntheticsSecureCredential,
+		},
+		Schema: map[string]*schema.Schema{
+			"key": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: validation.All(
+					validation.StringLenBetween(1, 64),
+					validation.StringMatch(regexp.MustCompile(`^[A-Z0-9_]+$`), "must contain only uppercase letters, numbers, and underscores"),
+				),
+			},
+			"value": {
+				Type:      schema.TypeString,
+				Required:  true,
+				Sensitive: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"created_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"last_updated": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func importSyntheticsSecureCredential(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	d.Set("key", d.Id())
+	return []*schema.ResourceData{d}, nil
+}
+
+func buildSyntheticsSecureCredentialStruct(d *schema.ResourceData) *syntheticsSecureCredential {
+	credential := syntheticsSecureCredential{
+		Key:         d.Get("key").(string),
+		Value:       d.Get("value").(string),
+		Description: d.Get("description").(string),
+	}
+
+	return &credential
+}
+
+func resourceNewRelicSyntheticsSecureCredentialCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ProviderConfig).Synthetics
+	credential := buildSyntheticsSecureCredentialStruct(d)
+
+	log.Printf("[INFO] Creating New Relic Synthetics secure credential %s", credential.Key)
+
+	if err := createSyntheticsSecureCredential(client, *credential); err != nil {
+		return err
+	}
+
+	d.SetId(credential.Key)
+	return resourceNewRelicSyntheticsSecureCredentialRead(d, meta)
+}
+
+func resourceNewRelicSyntheticsSecureCredentialRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ProviderConfig).Synthetics
+
+	log.Printf("[INFO] Reading New Relic Synthetics secure credential %s", d.Id())
+
+	credential, err := getSyntheticsSecureCredential(client, d.Id())
+	if err != nil {
+		if err == errSyntheticsNotFound {
+			d.SetId("")
+			return nil
+		}
+
+		return err
+	}
+
+	// The API never returns the value, so only the key and description
+	// are compared against the configuration.
+	d.Set("key", credential.Key)
+	d.Set("description", credential.Description)
+	d.Set("created_at", credential.CreatedAt)
+	d.Set("last_updated", credential.LastUpdated)
+
+	return nil
+}
+
+func resourceNewRelicSyntheticsSecureCredentialUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ProviderConfig).Synthetics
+	credential := buildSyntheticsSecureCredentialStruct(d)
+
+	log.Printf("[INFO] Updating New Relic Synthetics secure credential %s", d.Id())
+
+	if err := updateSyntheticsSecureCredential(client, *credential); err != nil {
+		return err
+	}
+
+	return resourceNewRelicSyntheticsSecureCredentialRead(d, meta)
+}
+
+func resourceNewRelicSyntheticsSecureCredentialDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ProviderConfig).Synthetics
+
+	log.Printf("[INFO] Deleting New Relic Synthetics secure credential %s", d.Id())
+
+	if err := deleteSyntheticsSecureCredential(client, d.Id()); err != nil {
+		if err == errSyntheticsNotFound {
+			return nil
+		}
+		return err
+	}
+
+	return nil
+}
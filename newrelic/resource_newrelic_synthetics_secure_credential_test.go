@@ -0,0 +1,114 @@
+package newrelic
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccNewRelicSyntheticsSecureCredential_Basic(t *testing.T) {
+	rName := strings.ToUpper(fmt.Sprintf("TF_TEST_%s", acctest.RandString(5)))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNewRelicSyntheticsSecureCredentialDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckNewRelicSyntheticsSecureCredentialConfig(rName, "foo", "tf-test"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicSyntheticsSecureCredentialExists("newrelic_synthetics_secure_credential.foo"),
+					resource.TestCheckResourceAttr(
+						"newrelic_synthetics_secure_credential.foo", "key", rName),
+					resource.TestCheckResourceAttr(
+						"newrelic_synthetics_secure_credential.foo", "description", "tf-test"),
+				),
+			},
+			{
+				Config: testAccCheckNewRelicSyntheticsSecureCredentialConfig(rName, "bar", "tf-test-updated"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicSyntheticsSecureCredentialExists("newrelic_synthetics_secure_credential.foo"),
+					resource.TestCheckResourceAttr(
+						"newrelic_synthetics_secure_credential.foo", "description", "tf-test-updated"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccNewRelicSyntheticsSecureCredential_import(t *testing.T) {
+	resourceName := "newrelic_synthetics_secure_credential.foo"
+	rName := strings.ToUpper(fmt.Sprintf("TF_TEST_%s", acctest.RandString(5)))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNewRelicSyntheticsSecureCredentialDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckNewRelicSyntheticsSecureCredentialConfig(rName, "foo", "tf-test"),
+			},
+
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"value"},
+			},
+		},
+	})
+}
+
+func testAccCheckNewRelicSyntheticsSecureCredentialExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No synthetics secure credential ID is set")
+		}
+
+		client := testAccProvider.Meta().(*ProviderConfig).Synthetics
+
+		found, err := getSyntheticsSecureCredential(client, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		if found.Key != rs.Primary.ID {
+			return fmt.Errorf("Synthetics secure credential not found: %v - %v", rs.Primary.ID, found)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckNewRelicSyntheticsSecureCredentialDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*ProviderConfig).Synthetics
+	for _, r := range s.RootModule().Resources {
+		if r.Type != "newrelic_synthetics_secure_credential" {
+			continue
+		}
+
+		_, err := getSyntheticsSecureCredential(client, r.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("Synthetics secure credential still exists")
+		}
+	}
+	return nil
+}
+
+func testAccCheckNewRelicSyntheticsSecureCredentialConfig(key string, value string, description string) string {
+	return fmt.Sprintf(`
+resource "newrelic_synthetics_secure_credential" "foo" {
+  key         = "%[1]s"
+  value       = "%[2]s"
+  description = "%[3]s"
+}
+`, key, value, description)
+}
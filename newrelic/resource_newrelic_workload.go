@@ -0,0 +1,245 @@
+package newrelic
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceNewRelicWorkload() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceNewRelicWorkloadCreate,
+		Read:   resourceNewRelicWorkloadRead,
+		Update: resourceNewRelicWorkloadUpdate,
+		Delete: resourceNewRelicWorkloadDelete,
+		Importer: &schema.ResourceImporter{
+			State: importWorkload,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"api_key": apiKeySchema(),
+			"account_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"entity_guids": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			// A list rather than a set, so that queries keep the order they're
+			// declared in.
+			"entity_search_query": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"query": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+					},
+				},
+			},
+			"tags":     entityTagsSchema(),
+			"tags_all": entityTagsAllSchema(),
+			"guid": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"workload_id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"permalink": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+		CustomizeDiff: customizeDiffWorkload,
+	}
+}
+
+func customizeDiffWorkload(d *schema.ResourceDiff, meta interface{}) error {
+	if err := validateWorkloadEntities(d, meta); err != nil {
+		return err
+	}
+
+	return customizeDiffEntityTags(d, meta)
+}
+
+// validateWorkloadEntities requires entity_guids or entity_search_query, since
+// a workload without either has no entities.
+func validateWorkloadEntities(d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("entity_guids") || !d.NewValueKnown("entity_search_query") {
+		return nil
+	}
+
+	_, hasGUIDs := d.GetOk("entity_guids")
+	_, hasQueries := d.GetOk("entity_search_query")
+
+	if !hasGUIDs && !hasQueries {
+		return fmt.Errorf("one of entity_guids or entity_search_query must be set")
+	}
+
+	return nil
+}
+
+func expandWorkload(d *schema.ResourceData) *workloadInput {
+	input := workloadInput{
+		Name:                d.Get("name").(string),
+		EntityGUIDs:         []string{},
+		EntitySearchQueries: []workloadEntitySearchQuery{},
+	}
+
+	for _, guid := range d.Get("entity_guids").(*schema.Set).List() {
+		input.EntityGUIDs = append(input.EntityGUIDs, guid.(string))
+	}
+
+	for _, q := range d.Get("entity_search_query").([]interface{}) {
+		query := q.(map[string]interface{})
+		input.EntitySearchQueries = append(input.EntitySearchQueries, workloadEntitySearchQuery{
+			Query: query["query"].(string),
+		})
+	}
+
+	return &input
+}
+
+func flattenWorkload(workload *workload, d *schema.ResourceData) error {
+	d.Set("guid", workload.GUID)
+	d.Set("workload_id", workload.ID)
+	d.Set("account_id", workload.Account.ID)
+	d.Set("name", workload.Name)
+	d.Set("permalink", workload.Permalink)
+
+	guids := make([]interface{}, 0, len(workload.Entities))
+	for _, entity := range workload.Entities {
+		guids = append(guids, entity.GUID)
+	}
+
+	if err := d.Set("entity_guids", schema.NewSet(schema.HashString, guids)); err != nil {
+		return err
+	}
+
+	queries := make([]interface{}, 0, len(workload.EntitySearchQueries))
+	for _, query := range workload.EntitySearchQueries {
+		queries = append(queries, map[string]interface{}{
+			"query": query.Query,
+		})
+	}
+
+	return d.Set("entity_search_query", queries)
+}
+
+// importWorkload imports a workload by its GUID, which also identifies its
+// account.
+func importWorkload(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := entityGUIDParts(d.Id())
+	if parts == nil {
+		return nil, fmt.Errorf("Error importing workload %q, expected the workload GUID", d.Id())
+	}
+
+	accountID, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("Error importing workload %q, expected the workload GUID", d.Id())
+	}
+
+	d.Set("account_id", accountID)
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceNewRelicWorkloadCreate(d *schema.ResourceData, meta interface{}) error {
+	client, err := meta.(*ProviderConfig).clientFor(d)
+	if err != nil {
+		return err
+	}
+
+	input := expandWorkload(d)
+
+	log.Printf("[INFO] Creating New Relic workload %s", input.Name)
+
+	workload, err := createWorkload(client, d.Get("account_id").(int), *input)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(workload.GUID)
+
+	if err := applyEntityTags(meta.(*ProviderConfig).stopContext(), client, workload.GUID, d); err != nil {
+		return err
+	}
+
+	return resourceNewRelicWorkloadRead(d, meta)
+}
+
+func resourceNewRelicWorkloadRead(d *schema.ResourceData, meta interface{}) error {
+	client, err := meta.(*ProviderConfig).clientFor(d)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Reading New Relic workload %s", d.Id())
+
+	workload, err := getWorkload(client, d.Get("account_id").(int), d.Id())
+	if err != nil {
+		if err == errEntityNotFound {
+			d.SetId("")
+			return nil
+		}
+
+		return err
+	}
+
+	if err := flattenWorkload(workload, d); err != nil {
+		return err
+	}
+
+	return readEntityTags(client, d.Id(), d)
+}
+
+func resourceNewRelicWorkloadUpdate(d *schema.ResourceData, meta interface{}) error {
+	client, err := meta.(*ProviderConfig).clientFor(d)
+	if err != nil {
+		return err
+	}
+
+	input := expandWorkload(d)
+
+	log.Printf("[INFO] Updating New Relic workload %s", d.Id())
+
+	if err := updateWorkload(client, d.Id(), *input); err != nil {
+		return err
+	}
+
+	if err := applyEntityTags(meta.(*ProviderConfig).stopContext(), client, d.Id(), d); err != nil {
+		return err
+	}
+
+	return resourceNewRelicWorkloadRead(d, meta)
+}
+
+func resourceNewRelicWorkloadDelete(d *schema.ResourceData, meta interface{}) error {
+	client, err := meta.(*ProviderConfig).clientFor(d)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Deleting New Relic workload %s", d.Id())
+
+	if err := deleteWorkload(client, d.Id()); err != nil {
+		return err
+	}
+
+	return nil
+}
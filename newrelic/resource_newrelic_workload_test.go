@@ -0,0 +1,256 @@
+package newrelic
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/config"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccNewRelicWorkload_Basic(t *testing.T) {
+	accountID := testAccAccountID(t)
+	resourceName := "newrelic_workload.foo"
+	rName := acctest.RandString(5)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNewRelicWorkloadDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckNewRelicWorkloadConfig(accountID, rName, "Transaction"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicWorkloadExists(resourceName),
+					resource.TestCheckResourceAttrSet(resourceName, "guid"),
+					resource.TestCheckResourceAttrSet(resourceName, "permalink"),
+					resource.TestCheckResourceAttr(resourceName, "entity_search_query.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "entity_search_query.0.query", "name LIKE 'tf-test-"+rName+"%'"),
+				),
+			},
+			{
+				Config: testAccCheckNewRelicWorkloadConfig(accountID, rName, "Host"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicWorkloadExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "entity_search_query.1.query", "type = 'Host'"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestResourceNewRelicWorkload(t *testing.T) {
+	guid := "MTIzNDV8TlIxfFdPUktMT0FEfDEyMw"
+
+	var sent map[string]interface{}
+	var readAccountID interface{}
+
+	meta, closeServer := testNotificationsProviderConfig(t, func(query string, variables map[string]interface{}) string {
+		if strings.Contains(query, "workloadCreate") || strings.Contains(query, "workloadUpdate") {
+			sent = variables["workload"].(map[string]interface{})
+		}
+
+		if strings.Contains(query, "workloadUpdate") {
+			return `{"data":{"workloadUpdate":{"guid":"` + guid + `"}}}`
+		}
+
+		if !strings.Contains(query, "workloadCreate") {
+			readAccountID = variables["accountId"]
+		}
+
+		entities := []interface{}{}
+		for _, g := range sent["entityGuids"].([]interface{}) {
+			entities = append(entities, map[string]interface{}{"guid": g})
+		}
+
+		workload := map[string]interface{}{
+			"guid":                guid,
+			"id":                  123,
+			"name":                sent["name"],
+			"permalink":           "https://one.newrelic.com/redirect/entity/" + guid,
+			"account":             map[string]interface{}{"id": 12345},
+			"entities":            entities,
+			"entitySearchQueries": sent["entitySearchQueries"],
+		}
+
+		data := map[string]interface{}{"actor": map[string]interface{}{"account": map[string]interface{}{"workload": map[string]interface{}{"collection": workload}}}}
+		if strings.Contains(query, "workloadCreate") {
+			data = map[string]interface{}{"workloadCreate": workload}
+		}
+
+		body, _ := json.Marshal(map[string]interface{}{"data": data})
+		return string(body)
+	})
+	defer closeServer()
+
+	r := resourceNewRelicWorkload()
+	d := r.TestResourceData()
+	d.Set("account_id", 12345)
+	d.Set("name", "foo")
+	d.Set("entity_guids", []interface{}{"MTIzNDV8QVBNfEFQUExJQ0FUSU9OfDQ1Ng"})
+	d.Set("entity_search_query", []interface{}{
+		map[string]interface{}{"query": "tags.team = 'payments'"},
+		map[string]interface{}{"query": "type = 'HOST'"},
+	})
+
+	if err := r.Create(d, meta); err != nil {
+		t.Fatal(err)
+	}
+
+	if d.Id() != guid || d.Get("workload_id").(int) != 123 {
+		t.Fatalf("expected workload %s with ID 123, got %s and %d", guid, d.Id(), d.Get("workload_id").(int))
+	}
+
+	if readAccountID != 12345.0 {
+		t.Fatalf("expected the workload to be read from account 12345, got %v", readAccountID)
+	}
+
+	// Search queries are read back in the order they're declared in.
+	for i, query := range []string{"tags.team = 'payments'", "type = 'HOST'"} {
+		if actual := d.Get(fmt.Sprintf("entity_search_query.%d.query", i)).(string); actual != query {
+			t.Fatalf("expected query %d to be %q, got %q", i, query, actual)
+		}
+	}
+
+	if guids := d.Get("entity_guids").(*schema.Set); guids.Len() != 1 || !guids.Contains("MTIzNDV8QVBNfEFQUExJQ0FUSU9OfDQ1Ng") {
+		t.Fatalf("expected the entity GUID to be read back, got %v", guids.List())
+	}
+
+	// Removing the entity GUIDs sends an empty list, so they're removed.
+	d.Set("entity_guids", []interface{}{})
+	if err := r.Update(d, meta); err != nil {
+		t.Fatal(err)
+	}
+
+	if guids := sent["entityGuids"].([]interface{}); len(guids) != 0 {
+		t.Fatalf("expected no entity GUIDs to be sent, got %v", guids)
+	}
+
+	if guids := d.Get("entity_guids").(*schema.Set); guids.Len() != 0 {
+		t.Fatalf("expected no entity GUIDs to be read back, got %v", guids.List())
+	}
+}
+
+func TestValidateWorkloadEntities(t *testing.T) {
+	r := resourceNewRelicWorkload()
+
+	diff := func(c map[string]interface{}) error {
+		c["account_id"] = 12345
+		c["name"] = "foo"
+
+		rc, err := config.NewRawConfig(c)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = r.Diff(nil, terraform.NewResourceConfig(rc), &ProviderConfig{})
+		return err
+	}
+
+	if err := diff(map[string]interface{}{"entity_guids": []interface{}{"MTIzNDV8QVBNfEFQUExJQ0FUSU9OfDQ1Ng"}}); err != nil {
+		t.Fatalf("expected no error for entity_guids, got %q", err)
+	}
+
+	if err := diff(map[string]interface{}{"entity_search_query": []interface{}{map[string]interface{}{"query": "type = 'HOST'"}}}); err != nil {
+		t.Fatalf("expected no error for entity_search_query, got %q", err)
+	}
+
+	expected := "one of entity_guids or entity_search_query must be set"
+	if err := diff(map[string]interface{}{}); err == nil || err.Error() != expected {
+		t.Fatalf("expected %q, got %q", expected, err)
+	}
+}
+
+func TestResourceNewRelicWorkload_immutableAttributes(t *testing.T) {
+	testResourceAttributesForceNew(t, resourceNewRelicWorkload(), "account_id")
+}
+
+func TestResourceNewRelicWorkloadImport(t *testing.T) {
+	d := resourceNewRelicWorkload().TestResourceData()
+	d.SetId("MTIzNDV8TlIxfFdPUktMT0FEfDEyMw")
+
+	if _, err := importWorkload(d, &ProviderConfig{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if accountID := d.Get("account_id").(int); accountID != 12345 {
+		t.Fatalf("expected the account ID of the GUID, got %d", accountID)
+	}
+
+	d.SetId("123")
+	if _, err := importWorkload(d, &ProviderConfig{}); err == nil {
+		t.Fatal("expected an error importing a workload by a numeric ID")
+	}
+}
+
+func testAccCheckNewRelicWorkloadDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*ProviderConfig).Client
+	for _, r := range s.RootModule().Resources {
+		if r.Type != "newrelic_workload" {
+			continue
+		}
+
+		accountID, _ := strconv.Atoi(r.Primary.Attributes["account_id"])
+
+		_, err := getWorkload(client, accountID, r.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("Workload still exists")
+		}
+	}
+	return nil
+}
+
+func testAccCheckNewRelicWorkloadExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No workload GUID is set")
+		}
+
+		client := testAccProvider.Meta().(*ProviderConfig).Client
+
+		accountID, _ := strconv.Atoi(rs.Primary.Attributes["account_id"])
+
+		found, err := getWorkload(client, accountID, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		if found.GUID != rs.Primary.ID {
+			return fmt.Errorf("Workload not found: %v - %v", rs.Primary.ID, found)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckNewRelicWorkloadConfig(accountID string, rName string, entityType string) string {
+	return fmt.Sprintf(`
+resource "newrelic_workload" "foo" {
+  account_id = %[1]s
+  name       = "tf-test-%[2]s"
+
+  entity_search_query {
+    query = "name LIKE 'tf-test-%[2]s%%'"
+  }
+
+  entity_search_query {
+    query = "type = '%[3]s'"
+  }
+}
+`, accountID, rName, entityType)
+}
@@ -0,0 +1,197 @@
+package newrelic
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	newrelic "github.com/paultyng/go-newrelic/v4/api"
+)
+
+const (
+	defaultMaxRetries    = 3
+	defaultMinRetryDelay = time.Second
+	maxRetryDelay        = 30 * time.Second
+)
+
+// createPollMinDelay and createPollMaxDelay bound the delay between reads of
+// a newly created resource. They're lowered in tests.
+var (
+	createPollMinDelay = 500 * time.Millisecond
+	createPollMaxDelay = 10 * time.Second
+)
+
+// retryTransport is an http.RoundTripper that retries requests which were
+// rejected with 429 Too Many Requests or 503 Service Unavailable.
+//
+// A 429 means the request was not processed, so it is retried for any
+// method. A 503 is only retried for idempotent methods.
+type retryTransport struct {
+	transport     http.RoundTripper
+	maxRetries    int
+	minRetryDelay time.Duration
+
+	// sleep is replaced in tests.
+	sleep func(context.Context, time.Duration) error
+}
+
+func newRetryTransport(transport http.RoundTripper, maxRetries int, minRetryDelay time.Duration) *retryTransport {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	if minRetryDelay <= 0 {
+		minRetryDelay = defaultMinRetryDelay
+	}
+
+	return &retryTransport{
+		transport:     transport,
+		maxRetries:    maxRetries,
+		minRetryDelay: minRetryDelay,
+		sleep:         sleep,
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 1; ; attempt++ {
+		resp, err := t.transport.RoundTrip(req)
+		if err != nil {
+			return resp, err
+		}
+
+		if !t.shouldRetry(req, resp) {
+			return resp, nil
+		}
+
+		resp.Body.Close()
+
+		if attempt > t.maxRetries {
+			return nil, fmt.Errorf("New Relic API returned %s after %d attempts", resp.Status, attempt)
+		}
+
+		if req.Body != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+
+		delay := t.retryDelay(attempt, resp)
+		log.Printf("[WARN] New Relic API returned %s for %s %s, retrying in %s (attempt %d of %d)",
+			resp.Status, req.Method, req.URL.Path, delay, attempt, t.maxRetries+1)
+
+		if err := t.sleep(req.Context(), delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (t *retryTransport) shouldRetry(req *http.Request, resp *http.Response) bool {
+	if t.maxRetries <= 0 {
+		return false
+	}
+
+	// Requests with a body that can't be replayed are never retried.
+	if req.Body != nil && req.GetBody == nil {
+		return false
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusServiceUnavailable:
+		return isIdempotentMethod(req.Method)
+	}
+
+	return false
+}
+
+// retryDelay honors the Retry-After header when present and otherwise
+// backs off exponentially from minRetryDelay with full jitter.
+func (t *retryTransport) retryDelay(attempt int, resp *http.Response) time.Duration {
+	if v := resp.Header.Get("Retry-After"); v != "" {
+		if seconds, err := strconv.Atoi(v); err == nil && seconds >= 0 {
+			return time.Duration(seconds) * time.Second
+		}
+
+		if at, err := http.ParseTime(v); err == nil {
+			if d := time.Until(at); d > 0 {
+				return d
+			}
+			return 0
+		}
+	}
+
+	return jitteredBackoff(t.minRetryDelay, maxRetryDelay, attempt)
+}
+
+// jitteredBackoff backs off exponentially from min, up to max, with full
+// jitter.
+func jitteredBackoff(min time.Duration, max time.Duration, attempt int) time.Duration {
+	backoff := min << uint(attempt-1)
+	if backoff <= 0 || backoff > max {
+		backoff = max
+	}
+
+	return min + time.Duration(rand.Int63n(int64(backoff)))
+}
+
+// sleep waits for d, or until ctx is done, in which case it returns the
+// error of ctx.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// waitForCreated calls read until it stops returning newrelic.ErrNotFound,
+// timeout elapses or ctx is done. The API can answer 404 for a resource for a
+// short while after creating it, which would otherwise fail the read that
+// follows the create or the creation of the resources that depend on it.
+func waitForCreated(ctx context.Context, timeout time.Duration, read func() error) error {
+	deadline := time.Now().Add(timeout)
+
+	for attempt := 1; ; attempt++ {
+		err := read()
+		if err != newrelic.ErrNotFound {
+			return err
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return fmt.Errorf("still not found after %s", timeout)
+		}
+
+		delay := jitteredBackoff(createPollMinDelay, createPollMaxDelay, attempt)
+		if delay > remaining {
+			delay = remaining
+		}
+
+		log.Printf("[DEBUG] Waiting %s for the created resource to become readable (attempt %d)", delay, attempt)
+
+		if err := sleep(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
+
+func isIdempotentMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,226 @@
+package newrelic
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	newrelic "github.com/paultyng/go-newrelic/v4/api"
+)
+
+func testRetryServer(statuses ...int) (*httptest.Server, *int) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		status := statuses[len(statuses)-1]
+		if calls < len(statuses) {
+			status = statuses[calls]
+		}
+		calls++
+		w.WriteHeader(status)
+	}))
+
+	return server, &calls
+}
+
+func testRetryClient(maxRetries int) (*http.Client, *[]time.Duration) {
+	var delays []time.Duration
+	transport := newRetryTransport(nil, maxRetries, time.Millisecond)
+	transport.sleep = func(ctx context.Context, d time.Duration) error {
+		delays = append(delays, d)
+		return nil
+	}
+
+	return &http.Client{Transport: transport}, &delays
+}
+
+func TestRetryTransport_RetriesTooManyRequests(t *testing.T) {
+	server, calls := testRetryServer(http.StatusTooManyRequests, http.StatusTooManyRequests, http.StatusOK)
+	defer server.Close()
+
+	client, _ := testRetryClient(3)
+
+	resp, err := client.Post(server.URL, "application/json", strings.NewReader("{}"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+
+	if *calls != 3 {
+		t.Fatalf("expected 3 calls, got %d", *calls)
+	}
+}
+
+func TestRetryTransport_ServiceUnavailableOnlyIdempotent(t *testing.T) {
+	server, calls := testRetryServer(http.StatusServiceUnavailable, http.StatusOK)
+	defer server.Close()
+
+	client, _ := testRetryClient(3)
+
+	resp, err := client.Post(server.URL, "application/json", strings.NewReader("{}"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("expected status 503, got %d", resp.StatusCode)
+	}
+
+	if *calls != 1 {
+		t.Fatalf("expected 1 call, got %d", *calls)
+	}
+
+	resp, err = client.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+}
+
+func TestRetryTransport_ReportsAttempts(t *testing.T) {
+	server, calls := testRetryServer(http.StatusTooManyRequests)
+	defer server.Close()
+
+	client, _ := testRetryClient(2)
+
+	_, err := client.Get(server.URL)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	if !strings.Contains(err.Error(), "after 3 attempts") {
+		t.Fatalf("expected error to report 3 attempts, got %s", err)
+	}
+
+	if *calls != 3 {
+		t.Fatalf("expected 3 calls, got %d", *calls)
+	}
+}
+
+func TestRetryTransport_RetryAfter(t *testing.T) {
+	transport := newRetryTransport(nil, 3, time.Millisecond)
+	resp := &http.Response{Header: http.Header{}}
+	resp.Header.Set("Retry-After", "7")
+
+	if d := transport.retryDelay(1, resp); d != 7*time.Second {
+		t.Fatalf("expected a 7s delay, got %s", d)
+	}
+}
+
+func TestRetryTransport_Disabled(t *testing.T) {
+	server, calls := testRetryServer(http.StatusTooManyRequests)
+	defer server.Close()
+
+	client, _ := testRetryClient(0)
+
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if resp.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("expected status 429, got %d", resp.StatusCode)
+	}
+
+	if *calls != 1 {
+		t.Fatalf("expected 1 call, got %d", *calls)
+	}
+}
+
+func TestRetryTransport_CancelledDuringRetryAfter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: newRetryTransport(nil, 3, time.Millisecond)}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	req, _ := http.NewRequest("GET", server.URL, nil)
+
+	start := time.Now()
+	_, err := client.Do(req.WithContext(ctx))
+
+	if err == nil || !strings.Contains(err.Error(), context.DeadlineExceeded.Error()) {
+		t.Fatalf("expected the context error, got %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("expected the wait to end with the context, took %s", elapsed)
+	}
+}
+
+// testFastCreatePolling shortens the delays of waitForCreated until the
+// returned func is called.
+func testFastCreatePolling() func() {
+	minDelay, maxDelay := createPollMinDelay, createPollMaxDelay
+	createPollMinDelay, createPollMaxDelay = time.Millisecond, 5*time.Millisecond
+
+	return func() {
+		createPollMinDelay, createPollMaxDelay = minDelay, maxDelay
+	}
+}
+
+func TestWaitForCreated(t *testing.T) {
+	defer testFastCreatePolling()()
+
+	reads := 0
+	err := waitForCreated(context.Background(), time.Minute, func() error {
+		reads++
+		if reads < 3 {
+			return newrelic.ErrNotFound
+		}
+		return nil
+	})
+
+	if err != nil || reads != 3 {
+		t.Fatalf("expected the resource to be found on the 3rd read, got %v after %d reads", err, reads)
+	}
+
+	// Other errors aren't waited out.
+	forbidden := errors.New("403 Forbidden")
+
+	reads = 0
+	err = waitForCreated(context.Background(), time.Minute, func() error {
+		reads++
+		return forbidden
+	})
+
+	if err != forbidden || reads != 1 {
+		t.Fatalf("expected the read error after 1 read, got %v after %d reads", err, reads)
+	}
+
+	err = waitForCreated(context.Background(), 20*time.Millisecond, func() error {
+		return newrelic.ErrNotFound
+	})
+
+	if err == nil || !strings.Contains(err.Error(), "still not found after 20ms") {
+		t.Fatalf("expected the wait to time out, got %v", err)
+	}
+
+	// Interrupting Terraform ends the wait.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	reads = 0
+	err = waitForCreated(ctx, time.Minute, func() error {
+		reads++
+		return newrelic.ErrNotFound
+	})
+
+	if err != context.Canceled || reads != 1 {
+		t.Fatalf("expected the wait to be cancelled after 1 read, got %v after %d reads", err, reads)
+	}
+}
@@ -0,0 +1,238 @@
+package newrelic
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	synthetics "github.com/dollarshaveclub/new-relic-synthetics-go"
+)
+
+// The synthetics client library does not cover every Synthetics API, so the
+// remaining endpoints are called here using the client's API key and HTTP
+// client.
+
+const syntheticsAPIURL = "https://synthetics.newrelic.com/synthetics/api"
+
+var (
+	// errSyntheticsNotFound is returned when a Synthetics API resource
+	// can't be found.
+	errSyntheticsNotFound = errors.New("error: synthetics resource not found")
+)
+
+// syntheticsSecureCredential represents a Synthetics secure credential. The
+// API never returns the value.
+type syntheticsSecureCredential struct {
+	Key         string `json:"key"`
+	Value       string `json:"value,omitempty"`
+	Description string `json:"description,omitempty"`
+	CreatedAt   string `json:"createdAt,omitempty"`
+	LastUpdated string `json:"lastUpdated,omitempty"`
+}
+
+// syntheticsCustomHeader is a header sent with the requests of a monitor.
+type syntheticsCustomHeader struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// syntheticsMonitorCreateRequest sends a monitor with all of its options.
+// The client library only sends the options it models and leaves out custom
+// headers and the request body.
+type syntheticsMonitorCreateRequest struct {
+	synthetics.CreateMonitorArgs
+	Options map[string]interface{} `json:"options,omitempty"`
+}
+
+// syntheticsMonitorUpdateRequest sends the updated attributes of a monitor
+// with all of its options.
+type syntheticsMonitorUpdateRequest struct {
+	synthetics.UpdateMonitorArgs
+	Options map[string]interface{} `json:"options,omitempty"`
+}
+
+// syntheticsLocation represents a public or private location Synthetics
+// monitors can run from.
+type syntheticsLocation struct {
+	Name    string `json:"name"`
+	Label   string `json:"label"`
+	Private bool   `json:"private"`
+}
+
+func syntheticsRequest(client *synthetics.Client, method string, path string, body interface{}, result interface{}) error {
+	_, err := syntheticsRequestWithHeader(client, method, path, body, result)
+	return err
+}
+
+// syntheticsRequestWithHeader is syntheticsRequest also returning the
+// response headers, e.g. the Location of a created monitor.
+func syntheticsRequestWithHeader(client *synthetics.Client, method string, path string, body interface{}, result interface{}) (http.Header, error) {
+	var reqBody io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reqBody = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequest(method, syntheticsAPIURL+path, reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("error: Synthetics request could not be created: %s", err)
+	}
+
+	req.Header.Add("X-Api-Key", client.APIKey)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	httpClient := client.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error: could not perform Synthetics request %s %s: %s", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, errSyntheticsNotFound
+	}
+
+	if resp.StatusCode/100 != 2 {
+		msg, _ := ioutil.ReadAll(resp.Body)
+		return nil, fmt.Errorf("error: invalid response from Synthetics request %s %s with code %d. Message: %s", method, path, resp.StatusCode, msg)
+	}
+
+	if result != nil {
+		if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+			return nil, fmt.Errorf("error: could not parse Synthetics JSON response: %s", err)
+		}
+	}
+
+	return resp.Header, nil
+}
+
+func getSyntheticsSecureCredential(client *synthetics.Client, key string) (*syntheticsSecureCredential, error) {
+	var credential syntheticsSecureCredential
+
+	if err := syntheticsRequest(client, "GET", "/v1/secure-credentials/"+url.PathEscape(key), nil, &credential); err != nil {
+		return nil, err
+	}
+
+	return &credential, nil
+}
+
+func createSyntheticsSecureCredential(client *synthetics.Client, credential syntheticsSecureCredential) error {
+	return syntheticsRequest(client, "POST", "/v1/secure-credentials", credential, nil)
+}
+
+func updateSyntheticsSecureCredential(client *synthetics.Client, credential syntheticsSecureCredential) error {
+	return syntheticsRequest(client, "PUT", "/v1/secure-credentials/"+url.PathEscape(credential.Key), credential, nil)
+}
+
+func deleteSyntheticsSecureCredential(client *synthetics.Client, key string) error {
+	return syntheticsRequest(client, "DELETE", "/v1/secure-credentials/"+url.PathEscape(key), nil, nil)
+}
+
+func createSyntheticsMonitor(client *synthetics.Client, monitor syntheticsMonitorCreateRequest) (string, error) {
+	header, err := syntheticsRequestWithHeader(client, "POST", "/v3/monitors", monitor, nil)
+	if err != nil {
+		return "", err
+	}
+
+	location := header.Get("Location")
+	if !strings.HasPrefix(location, syntheticsAPIURL+"/v3/monitors/") {
+		return "", fmt.Errorf("error: could not find an ID for monitor %s in location header %q", monitor.Name, location)
+	}
+
+	return path.Base(location), nil
+}
+
+func updateSyntheticsMonitor(client *synthetics.Client, id string, monitor syntheticsMonitorUpdateRequest) error {
+	return syntheticsRequest(client, "PATCH", "/v3/monitors/"+url.PathEscape(id), monitor, nil)
+}
+
+// syntheticsMonitorCustomHeaders returns the custom headers in the options of
+// monitor, which the client library doesn't parse.
+func syntheticsMonitorCustomHeaders(monitor *synthetics.Monitor) []syntheticsCustomHeader {
+	headers := []syntheticsCustomHeader{}
+
+	raw, _ := monitor.Options["customHeaders"].([]interface{})
+	for _, h := range raw {
+		if header, ok := h.(map[string]interface{}); ok {
+			name, _ := header["name"].(string)
+			value, _ := header["value"].(string)
+			headers = append(headers, syntheticsCustomHeader{Name: name, Value: value})
+		}
+	}
+
+	return headers
+}
+
+func listSyntheticsLocations(client *synthetics.Client) ([]syntheticsLocation, error) {
+	var locations []syntheticsLocation
+
+	if err := syntheticsRequest(client, "GET", "/v1/locations", nil, &locations); err != nil {
+		return nil, err
+	}
+
+	return locations, nil
+}
+
+// syntheticsURLTransport is an http.RoundTripper that sends requests for the
+// US Synthetics API, which the client library hardcodes, to baseURL instead,
+// e.g. the EU Synthetics API.
+//
+// Location headers are mapped back to the US URL since the client library
+// parses them to find the IDs of created monitors.
+type syntheticsURLTransport struct {
+	transport http.RoundTripper
+	baseURL   string
+}
+
+func newSyntheticsURLTransport(transport http.RoundTripper, baseURL string) *syntheticsURLTransport {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	return &syntheticsURLTransport{
+		transport: transport,
+		baseURL:   strings.TrimRight(baseURL, "/"),
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *syntheticsURLTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if reqURL := req.URL.String(); strings.HasPrefix(reqURL, syntheticsAPIURL) {
+		u, err := url.Parse(t.baseURL + strings.TrimPrefix(reqURL, syntheticsAPIURL))
+		if err != nil {
+			return nil, err
+		}
+
+		r := new(http.Request)
+		*r = *req
+		r.URL = u
+		r.Host = u.Host
+		req = r
+	}
+
+	resp, err := t.transport.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+
+	if location := resp.Header.Get("Location"); strings.HasPrefix(location, t.baseURL) {
+		resp.Header.Set("Location", syntheticsAPIURL+strings.TrimPrefix(location, t.baseURL))
+	}
+
+	return resp, nil
+}
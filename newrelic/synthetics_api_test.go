@@ -0,0 +1,35 @@
+package newrelic
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSyntheticsURLTransport(t *testing.T) {
+	var path string
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		w.Header().Set("Location", server.URL+"/synthetics/api/v3/monitors/abc")
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: newSyntheticsURLTransport(nil, server.URL+"/synthetics/api")}
+
+	resp, err := client.Post(syntheticsAPIURL+"/v3/monitors", "application/json", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if path != "/synthetics/api/v3/monitors" {
+		t.Fatalf("expected the request to be sent to /synthetics/api/v3/monitors, got %s", path)
+	}
+
+	expected := "https://synthetics.newrelic.com/synthetics/api/v3/monitors/abc"
+	if location := resp.Header.Get("Location"); location != expected {
+		t.Fatalf("expected Location %s, got %s", expected, location)
+	}
+}
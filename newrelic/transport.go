@@ -0,0 +1,82 @@
+package newrelic
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/url"
+)
+
+// httpTransport returns the transport for requests to New Relic when a proxy,
+// CA certificate, insecure_skip_verify or parallelism is configured, or nil to
+// use the default transport, which honours the HTTP_PROXY and HTTPS_PROXY
+// environment variables.
+func (c *Config) httpTransport() (http.RoundTripper, error) {
+	if c.ProxyURL == "" && c.CACertFile == "" && !c.InsecureSkipVerify && c.Parallelism == 0 {
+		return nil, nil
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	// The default of 2 idle connections per host would make most concurrent
+	// requests open a new connection.
+	if c.Parallelism > 0 {
+		transport.MaxIdleConnsPerHost = c.Parallelism
+	}
+
+	if c.ProxyURL != "" {
+		proxyURL, err := url.Parse(c.ProxyURL)
+		if err != nil || proxyURL.Host == "" {
+			return nil, fmt.Errorf("Error parsing proxy_url %q: expected a URL such as http://proxy.example.com:3128", c.ProxyURL)
+		}
+
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	tlsConfig, err := c.tlsConfig()
+	if err != nil {
+		return nil, err
+	}
+	transport.TLSClientConfig = tlsConfig
+
+	if c.requestSlots != nil {
+		return newConcurrencyTransport(transport, c.requestSlots), nil
+	}
+
+	return transport, nil
+}
+
+// tlsConfig returns the TLS configuration trusting the certificates in
+// CACertFile in addition to the system's.
+func (c *Config) tlsConfig() (*tls.Config, error) {
+	tlsConfig := &tls.Config{}
+
+	if c.InsecureSkipVerify {
+		log.Printf("[WARN] insecure_skip_verify is set, the TLS certificates of the New Relic APIs will not be verified")
+		tlsConfig.InsecureSkipVerify = true
+	}
+
+	if c.CACertFile == "" {
+		return tlsConfig, nil
+	}
+
+	pem, err := ioutil.ReadFile(c.CACertFile)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading cacert_file: %s", err)
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("cacert_file %s contains no PEM encoded certificates", c.CACertFile)
+	}
+	tlsConfig.RootCAs = pool
+
+	return tlsConfig, nil
+}
@@ -0,0 +1,123 @@
+package newrelic
+
+import (
+	"encoding/pem"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func testCACertFile(t *testing.T, server *httptest.Server) string {
+	path := filepath.Join(t.TempDir(), "ca.pem")
+	cert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	if err := ioutil.WriteFile(path, cert, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func TestConfigHTTPTransport_default(t *testing.T) {
+	transport, err := (&Config{}).httpTransport()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if transport != nil {
+		t.Fatalf("expected the default transport, got %#v", transport)
+	}
+}
+
+func TestConfigHTTPTransport_caCertFile(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	if _, err := http.Get(server.URL); err == nil {
+		t.Fatal("expected the server's certificate to be untrusted by default")
+	}
+
+	transport, err := (&Config{CACertFile: testCACertFile(t, server)}).httpTransport()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := (&http.Client{Transport: transport}).Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+}
+
+func TestConfigHTTPTransport_insecureSkipVerify(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	transport, err := (&Config{InsecureSkipVerify: true}).httpTransport()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := (&http.Client{Transport: transport}).Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+}
+
+func TestConfigHTTPTransport_invalidCACertFile(t *testing.T) {
+	invalid := filepath.Join(t.TempDir(), "invalid.pem")
+	if err := ioutil.WriteFile(invalid, []byte("not a certificate"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := map[string]string{
+		invalid: "contains no PEM encoded certificates",
+		filepath.Join(os.TempDir(), "missing-ca.pem"): "Error reading cacert_file",
+	}
+
+	for path, expected := range cases {
+		_, err := (&Config{CACertFile: path}).httpTransport()
+		if err == nil || !strings.Contains(err.Error(), expected) {
+			t.Fatalf("expected an error containing %q for %s, got %v", expected, path, err)
+		}
+	}
+}
+
+func TestConfigHTTPTransport_proxyURL(t *testing.T) {
+	var proxied string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r.URL.String()
+	}))
+	defer proxy.Close()
+
+	transport, err := (&Config{ProxyURL: proxy.URL}).httpTransport()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := (&http.Client{Transport: transport}).Get("http://api.example.com/v2/applications.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if proxied != "http://api.example.com/v2/applications.json" {
+		t.Fatalf("expected the request to be sent through the proxy, got %q", proxied)
+	}
+
+	if _, err := (&Config{ProxyURL: "proxy.example.com"}).httpTransport(); err == nil {
+		t.Fatal("expected an error for a proxy_url without a scheme")
+	}
+}
+
+func TestConfigClient_caCertFile(t *testing.T) {
+	_, err := (&Config{APIKey: "foo", CACertFile: filepath.Join(os.TempDir(), "missing-ca.pem")}).Client()
+	if err == nil || !strings.Contains(err.Error(), "Error reading cacert_file") {
+		t.Fatalf("expected an error reading cacert_file, got %v", err)
+	}
+}
@@ -2,8 +2,14 @@ package newrelic
 
 import (
 	"fmt"
+	"net/mail"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
 )
 
 func float64Gte(gte float64) schema.SchemaValidateFunc {
@@ -41,3 +47,239 @@ func intInSlice(valid []int) schema.SchemaValidateFunc {
 		return
 	}
 }
+
+// validateThresholdOperator accepts the operators thresholds are compared
+// with, which are the same for APM, NRQL and Infrastructure conditions.
+func validateThresholdOperator(i interface{}, k string) (s []string, es []error) {
+	return validation.StringInSlice([]string{"above", "below", "equal"}, false)(i, k)
+}
+
+// validateRunbookURL accepts an absolute http or https URL, or "" to clear
+// the runbook URL.
+func validateRunbookURL(i interface{}, k string) (s []string, es []error) {
+	v, ok := i.(string)
+	if !ok {
+		es = append(es, fmt.Errorf("expected type of %s to be string", k))
+		return
+	}
+
+	if v == "" {
+		return
+	}
+
+	u, err := url.Parse(v)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		es = append(es, fmt.Errorf("expected %s to be an http or https URL, got %q", k, v))
+	}
+
+	return
+}
+
+// validateNotBlank rejects strings that are empty or only whitespace.
+func validateNotBlank(i interface{}, k string) (s []string, es []error) {
+	v, ok := i.(string)
+	if !ok {
+		es = append(es, fmt.Errorf("expected type of %s to be string", k))
+		return
+	}
+
+	if strings.TrimSpace(v) == "" {
+		es = append(es, fmt.Errorf("expected %s to not be blank", k))
+	}
+
+	return
+}
+
+// validateEmailList accepts a comma separated list of email addresses, e.g.
+// "foo@example.com, bar@example.com".
+func validateEmailList(i interface{}, k string) (s []string, es []error) {
+	v, ok := i.(string)
+	if !ok {
+		es = append(es, fmt.Errorf("expected type of %s to be string", k))
+		return
+	}
+
+	for _, r := range strings.Split(v, ",") {
+		r = strings.TrimSpace(r)
+
+		addr, err := mail.ParseAddress(r)
+		if err != nil || addr.Address != r {
+			es = append(es, fmt.Errorf("expected %s to be a comma separated list of email addresses, got %q", k, r))
+		}
+	}
+
+	return
+}
+
+// validateSlackWebhookURL accepts an https Slack incoming webhook URL.
+func validateSlackWebhookURL(i interface{}, k string) (s []string, es []error) {
+	v, ok := i.(string)
+	if !ok {
+		es = append(es, fmt.Errorf("expected type of %s to be string", k))
+		return
+	}
+
+	u, err := url.Parse(v)
+	if err != nil || u.Scheme != "https" || u.Host != "hooks.slack.com" {
+		// The URL is a secret, so it isn't included in the error.
+		es = append(es, fmt.Errorf("expected %s to be an https://hooks.slack.com/ webhook URL", k))
+	}
+
+	return
+}
+
+func validateDuration(i interface{}, k string) (s []string, es []error) {
+	v, ok := i.(string)
+	if !ok {
+		es = append(es, fmt.Errorf("expected type of %s to be string", k))
+		return
+	}
+
+	if _, err := time.ParseDuration(v); err != nil {
+		es = append(es, fmt.Errorf("expected %s to be a duration such as \"1s\" or \"500ms\", got %q", k, v))
+	}
+
+	return
+}
+
+// validateTermPriorities ensures at most one term is defined per priority,
+// and that one of them is the critical term, since a condition with only a
+// warning threshold never opens violations. Terms whose priority isn't known
+// yet are skipped.
+func validateTermPriorities(terms []interface{}) error {
+	seen := make(map[string]bool, len(terms))
+	known := true
+
+	for _, t := range terms {
+		term, ok := t.(map[string]interface{})
+		if !ok {
+			known = false
+			continue
+		}
+
+		priority, _ := term["priority"].(string)
+		if priority == "" {
+			known = false
+			continue
+		}
+
+		if seen[priority] {
+			return fmt.Errorf("only one term with priority %q may be defined", priority)
+		}
+		seen[priority] = true
+	}
+
+	if known && len(terms) > 0 && !seen["critical"] {
+		return fmt.Errorf("a term with priority \"critical\" is required")
+	}
+
+	return nil
+}
+
+// validateNaiveDateTime accepts a date and time without a time zone offset,
+// e.g. "2019-10-14T22:00:00", as used by alert muting rule schedules.
+func validateNaiveDateTime(i interface{}, k string) (s []string, es []error) {
+	v, ok := i.(string)
+	if !ok {
+		es = append(es, fmt.Errorf("expected type of %s to be string", k))
+		return
+	}
+
+	if _, err := time.Parse("2006-01-02T15:04:05", v); err != nil {
+		es = append(es, fmt.Errorf("expected %s to be a date and time such as \"2019-10-14T22:00:00\", got %q", k, v))
+	}
+
+	return
+}
+
+func validateEntityGUID(i interface{}, k string) (s []string, es []error) {
+	v, ok := i.(string)
+	if !ok {
+		es = append(es, fmt.Errorf("expected type of %s to be string", k))
+		return
+	}
+
+	if !isEntityGUID(v) {
+		es = append(es, fmt.Errorf("expected %s to be a valid entity GUID, got %q", k, v))
+	}
+
+	return
+}
+
+// validateHTTPStatusCodes accepts an HTTP status code, e.g. "404", or an
+// inclusive range of them, e.g. "500-599".
+func validateHTTPStatusCodes(i interface{}, k string) (s []string, es []error) {
+	v, ok := i.(string)
+	if !ok {
+		es = append(es, fmt.Errorf("expected type of %s to be string", k))
+		return
+	}
+
+	codes := strings.SplitN(v, "-", 2)
+	prev := 0
+	for _, c := range codes {
+		code, err := strconv.Atoi(c)
+		if err != nil || len(c) != 3 || code < 100 || code <= prev {
+			es = append(es, fmt.Errorf("expected %s to be an HTTP status code or range of them, e.g. 404 or 500-599, got %q", k, v))
+			return
+		}
+		prev = code
+	}
+
+	return
+}
+
+// validateInfraThresholdDuration ensures an Infra alert condition threshold
+// duration is within the API's range of 1 to 60 minutes. Values in seconds are
+// the usual mistake, so the error says which unit is expected.
+func validateInfraThresholdDuration(i interface{}, k string) (s []string, es []error) {
+	v, ok := i.(int)
+	if !ok {
+		es = append(es, fmt.Errorf("expected type of %s to be int", k))
+		return
+	}
+
+	if v < 1 || v > 60 {
+		es = append(es, fmt.Errorf("expected %s to be between 1 and 60 minutes, got %d; durations are in minutes, not seconds", k, v))
+	}
+
+	return
+}
+
+// syntheticsMonitorFrequencies are the intervals, in minutes, the Synthetics
+// API can run monitors at.
+var syntheticsMonitorFrequencies = []int{1, 5, 10, 15, 30, 60, 360, 720, 1440}
+
+// validateSyntheticsMonitorFrequency only accepts the frequencies the API
+// supports, and names the closest ones otherwise, so that a monitor never
+// runs at a cadence other than the configured one.
+func validateSyntheticsMonitorFrequency(i interface{}, k string) (s []string, es []error) {
+	v, ok := i.(int)
+	if !ok {
+		es = append(es, fmt.Errorf("expected type of %s to be int", k))
+		return
+	}
+
+	closest := []string{}
+	for j, f := range syntheticsMonitorFrequencies {
+		if v == f {
+			return
+		}
+
+		if v < f {
+			if j > 0 {
+				closest = append(closest, strconv.Itoa(syntheticsMonitorFrequencies[j-1]))
+			}
+			closest = append(closest, strconv.Itoa(f))
+			break
+		}
+	}
+
+	if len(closest) == 0 {
+		closest = append(closest, strconv.Itoa(syntheticsMonitorFrequencies[len(syntheticsMonitorFrequencies)-1]))
+	}
+
+	es = append(es, fmt.Errorf("expected %s to be one of %v minutes, got %d; the closest supported frequencies are %s",
+		k, syntheticsMonitorFrequencies, v, strings.Join(closest, " and ")))
+	return
+}
@@ -55,6 +55,355 @@ func TestValidationFloat64Gte(t *testing.T) {
 	})
 }
 
+func TestValidationThresholdOperator(t *testing.T) {
+	runTestCases(t, []testCase{
+		{
+			val: "above",
+			f:   validateThresholdOperator,
+		},
+		{
+			val: "below",
+			f:   validateThresholdOperator,
+		},
+		{
+			val: "equal",
+			f:   validateThresholdOperator,
+		},
+		{
+			val:         "above_or_equal",
+			f:           validateThresholdOperator,
+			expectedErr: regexp.MustCompile("expected [\\w]+ to be one of \\[above below equal\\], got above_or_equal"),
+		},
+	})
+}
+
+func TestValidationDuration(t *testing.T) {
+	runTestCases(t, []testCase{
+		{
+			val: "1s",
+			f:   validateDuration,
+		},
+		{
+			val: "500ms",
+			f:   validateDuration,
+		},
+		{
+			val:         "5",
+			f:           validateDuration,
+			expectedErr: regexp.MustCompile("expected [\\w]+ to be a duration"),
+		},
+		{
+			val:         1,
+			f:           validateDuration,
+			expectedErr: regexp.MustCompile("expected type of [\\w]+ to be string"),
+		},
+	})
+}
+
+func TestValidationNotBlank(t *testing.T) {
+	runTestCases(t, []testCase{
+		{
+			val: "var assert = require('assert');",
+			f:   validateNotBlank,
+		},
+		{
+			val:         " \n\t",
+			f:           validateNotBlank,
+			expectedErr: regexp.MustCompile("expected [\\w]+ to not be blank"),
+		},
+		{
+			val:         "",
+			f:           validateNotBlank,
+			expectedErr: regexp.MustCompile("expected [\\w]+ to not be blank"),
+		},
+		{
+			val:         1,
+			f:           validateNotBlank,
+			expectedErr: regexp.MustCompile("expected type of [\\w]+ to be string"),
+		},
+	})
+}
+
+func TestValidationEmailList(t *testing.T) {
+	runTestCases(t, []testCase{
+		{
+			val: "foo@example.com",
+			f:   validateEmailList,
+		},
+		{
+			val: "foo@example.com, bar@example.com",
+			f:   validateEmailList,
+		},
+		{
+			val:         "foo@example.com,bar",
+			f:           validateEmailList,
+			expectedErr: regexp.MustCompile("expected [\\w]+ to be a comma separated list of email addresses, got \"bar\""),
+		},
+		{
+			val:         "Foo <foo@example.com>",
+			f:           validateEmailList,
+			expectedErr: regexp.MustCompile("expected [\\w]+ to be a comma separated list of email addresses"),
+		},
+		{
+			val:         "",
+			f:           validateEmailList,
+			expectedErr: regexp.MustCompile("expected [\\w]+ to be a comma separated list of email addresses"),
+		},
+		{
+			val:         1,
+			f:           validateEmailList,
+			expectedErr: regexp.MustCompile("expected type of [\\w]+ to be string"),
+		},
+	})
+}
+
+func TestValidationSlackWebhookURL(t *testing.T) {
+	runTestCases(t, []testCase{
+		{
+			val: "https://hooks.slack.com/services/T000/B000/XXXX",
+			f:   validateSlackWebhookURL,
+		},
+		{
+			val:         "http://hooks.slack.com/services/T000/B000/XXXX",
+			f:           validateSlackWebhookURL,
+			expectedErr: regexp.MustCompile("expected [\\w]+ to be an https://hooks.slack.com/ webhook URL"),
+		},
+		{
+			val:         "https://hooks.example.com/services/T000/B000/XXXX",
+			f:           validateSlackWebhookURL,
+			expectedErr: regexp.MustCompile("expected [\\w]+ to be an https://hooks.slack.com/ webhook URL"),
+		},
+		{
+			val:         1,
+			f:           validateSlackWebhookURL,
+			expectedErr: regexp.MustCompile("expected type of [\\w]+ to be string"),
+		},
+	})
+}
+
+func TestValidationRunbookURL(t *testing.T) {
+	runTestCases(t, []testCase{
+		{
+			val: "https://runbooks.example.com/service",
+			f:   validateRunbookURL,
+		},
+		{
+			val: "http://runbooks.example.com",
+			f:   validateRunbookURL,
+		},
+		{
+			val: "",
+			f:   validateRunbookURL,
+		},
+		{
+			val:         "runbooks.example.com",
+			f:           validateRunbookURL,
+			expectedErr: regexp.MustCompile("expected [\\w]+ to be an http or https URL, got \"runbooks.example.com\""),
+		},
+		{
+			val:         "ftp://runbooks.example.com",
+			f:           validateRunbookURL,
+			expectedErr: regexp.MustCompile("expected [\\w]+ to be an http or https URL"),
+		},
+		{
+			val:         "https://",
+			f:           validateRunbookURL,
+			expectedErr: regexp.MustCompile("expected [\\w]+ to be an http or https URL"),
+		},
+		{
+			val:         1,
+			f:           validateRunbookURL,
+			expectedErr: regexp.MustCompile("expected type of [\\w]+ to be string"),
+		},
+	})
+}
+
+func TestValidationNaiveDateTime(t *testing.T) {
+	runTestCases(t, []testCase{
+		{
+			val: "2019-10-14T22:00:00",
+			f:   validateNaiveDateTime,
+		},
+		{
+			val:         "2019-10-14T22:00:00Z",
+			f:           validateNaiveDateTime,
+			expectedErr: regexp.MustCompile("expected [\\w]+ to be a date and time"),
+		},
+		{
+			val:         "2019-10-14",
+			f:           validateNaiveDateTime,
+			expectedErr: regexp.MustCompile("expected [\\w]+ to be a date and time"),
+		},
+		{
+			val:         1,
+			f:           validateNaiveDateTime,
+			expectedErr: regexp.MustCompile("expected type of [\\w]+ to be string"),
+		},
+	})
+}
+
+func TestValidationEntityGUID(t *testing.T) {
+	runTestCases(t, []testCase{
+		{
+			val: "MTIzNDU2fEFQTXxBUFBMSUNBVElPTnw3ODkw",
+			f:   validateEntityGUID,
+		},
+		{
+			val:         "not-a-guid",
+			f:           validateEntityGUID,
+			expectedErr: regexp.MustCompile("expected [\\w]+ to be a valid entity GUID, got \"not-a-guid\""),
+		},
+		{
+			val:         "bm8tcGlwZXM=",
+			f:           validateEntityGUID,
+			expectedErr: regexp.MustCompile("expected [\\w]+ to be a valid entity GUID"),
+		},
+		{
+			val:         1,
+			f:           validateEntityGUID,
+			expectedErr: regexp.MustCompile("expected type of [\\w]+ to be string"),
+		},
+	})
+}
+
+func TestValidationHTTPStatusCodes(t *testing.T) {
+	runTestCases(t, []testCase{
+		{
+			val: "404",
+			f:   validateHTTPStatusCodes,
+		},
+		{
+			val: "500-599",
+			f:   validateHTTPStatusCodes,
+		},
+		{
+			val:         "4xx",
+			f:           validateHTTPStatusCodes,
+			expectedErr: regexp.MustCompile("expected [\\w]+ to be an HTTP status code or range of them, e.g. 404 or 500-599, got \"4xx\""),
+		},
+		{
+			val:         "599-500",
+			f:           validateHTTPStatusCodes,
+			expectedErr: regexp.MustCompile("expected [\\w]+ to be an HTTP status code or range of them"),
+		},
+		{
+			val:         "50",
+			f:           validateHTTPStatusCodes,
+			expectedErr: regexp.MustCompile("expected [\\w]+ to be an HTTP status code or range of them"),
+		},
+		{
+			val:         1,
+			f:           validateHTTPStatusCodes,
+			expectedErr: regexp.MustCompile("expected type of [\\w]+ to be string"),
+		},
+	})
+}
+
+func TestValidateTermPriorities(t *testing.T) {
+	valid := []interface{}{
+		map[string]interface{}{"priority": "critical"},
+		map[string]interface{}{"priority": "warning"},
+	}
+
+	if err := validateTermPriorities(valid); err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	duplicate := []interface{}{
+		map[string]interface{}{"priority": "critical"},
+		map[string]interface{}{"priority": "critical"},
+	}
+
+	err := validateTermPriorities(duplicate)
+	if err == nil {
+		t.Fatal("expected an error for duplicate priorities")
+	}
+
+	if !regexp.MustCompile(`only one term with priority "critical"`).MatchString(err.Error()) {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	warningOnly := []interface{}{
+		map[string]interface{}{"priority": "warning"},
+	}
+
+	err = validateTermPriorities(warningOnly)
+	if err == nil || err.Error() != `a term with priority "critical" is required` {
+		t.Fatalf("expected an error for a missing critical term, got %v", err)
+	}
+
+	// The critical term may have an interpolated priority.
+	unknown := []interface{}{
+		map[string]interface{}{"priority": ""},
+		map[string]interface{}{"priority": "warning"},
+	}
+
+	if err := validateTermPriorities(unknown); err != nil {
+		t.Fatalf("expected no error when a priority isn't known, got %s", err)
+	}
+}
+
+func TestValidationInfraThresholdDuration(t *testing.T) {
+	runTestCases(t, []testCase{
+		{
+			val: 1,
+			f:   validateInfraThresholdDuration,
+		},
+		{
+			val: 60,
+			f:   validateInfraThresholdDuration,
+		},
+		{
+			val:         0,
+			f:           validateInfraThresholdDuration,
+			expectedErr: regexp.MustCompile("expected [\\w]+ to be between 1 and 60 minutes, got 0"),
+		},
+		{
+			val:         300,
+			f:           validateInfraThresholdDuration,
+			expectedErr: regexp.MustCompile("expected [\\w]+ to be between 1 and 60 minutes, got 300; durations are in minutes, not seconds"),
+		},
+		{
+			val:         "5",
+			f:           validateInfraThresholdDuration,
+			expectedErr: regexp.MustCompile("expected type of [\\w]+ to be int"),
+		},
+	})
+}
+
+func TestValidationSyntheticsMonitorFrequency(t *testing.T) {
+	runTestCases(t, []testCase{
+		{
+			val: 1,
+			f:   validateSyntheticsMonitorFrequency,
+		},
+		{
+			val: 1440,
+			f:   validateSyntheticsMonitorFrequency,
+		},
+		{
+			val:         3,
+			f:           validateSyntheticsMonitorFrequency,
+			expectedErr: regexp.MustCompile(`expected [\w]+ to be one of \[1 5 10 15 30 60 360 720 1440\] minutes, got 3; the closest supported frequencies are 1 and 5$`),
+		},
+		{
+			val:         0,
+			f:           validateSyntheticsMonitorFrequency,
+			expectedErr: regexp.MustCompile(`got 0; the closest supported frequencies are 1$`),
+		},
+		{
+			val:         2880,
+			f:           validateSyntheticsMonitorFrequency,
+			expectedErr: regexp.MustCompile(`got 2880; the closest supported frequencies are 1440$`),
+		},
+		{
+			val:         "5",
+			f:           validateSyntheticsMonitorFrequency,
+			expectedErr: regexp.MustCompile("expected type of [\\w]+ to be int"),
+		},
+	})
+}
+
 func runTestCases(t *testing.T, cases []testCase) {
 	matchErr := func(errs []error, r *regexp.Regexp) bool {
 		// err must match one provided